@@ -0,0 +1,221 @@
+package chconn
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/vahid-sohrabloo/chconn/v3/internal/helper"
+)
+
+const (
+	// sshUserMarker and jwtUserMarker are sent as the user in the hello packet to select the
+	// authentication method. They start with a space so they cannot be a user name.
+	sshUserMarker = " SSH KEY AUTHENTICATION "
+	jwtUserMarker = " JWT AUTHENTICATION "
+)
+
+// Authenticator authenticates a connection during the hello handshake.
+//
+// The implementations are PasswordAuth (the default), SSHKeyAuth and JWTAuth.
+type Authenticator interface {
+	// hello writes the credentials of the hello packet, sends it and performs any additional
+	// exchange the method needs before the server hello.
+	hello(ctx context.Context, ch *conn) error
+}
+
+// PasswordAuth authenticates with Config.User and Config.Password. It is used when Config.Auth is nil.
+type PasswordAuth struct{}
+
+func (PasswordAuth) hello(_ context.Context, ch *conn) error {
+	ch.writer.String(ch.config.User)
+	ch.writer.String(ch.config.Password)
+	return ch.flushHello()
+}
+
+// SSHKeyAuth authenticates Config.User with an SSH key registered for the user on the server
+// (CREATE USER ... IDENTIFIED WITH ssh_key BY KEY ...). The server sends a challenge that is
+// signed with Signer, so no secret is sent over the connection.
+//
+// Signer is usually an *rsa.PrivateKey, *ecdsa.PrivateKey or ed25519.PrivateKey, but it can be
+// any crypto.Signer such as a key kept in an agent or an HSM.
+type SSHKeyAuth struct {
+	Signer crypto.Signer
+}
+
+func (a *SSHKeyAuth) hello(_ context.Context, ch *conn) error {
+	if a.Signer == nil {
+		return errors.New("ssh key authentication: no signer")
+	}
+	ch.writer.String(sshUserMarker + ch.config.User)
+	ch.writer.String("")
+	ch.writer.Uvarint(clientSSHChallengeRequest)
+	if err := ch.flushHello(); err != nil {
+		return err
+	}
+
+	packet, err := ch.reader.Uvarint()
+	if err != nil {
+		return &readError{"ssh key authentication: read packet type", err}
+	}
+	switch packet {
+	case serverSSHChallenge:
+	case serverException:
+		return ch.handleServerException()
+	default:
+		return ch.unexpected("serverSSHChallenge", packet)
+	}
+	challenge, err := ch.reader.String()
+	if err != nil {
+		return &readError{"ssh key authentication: read challenge", err}
+	}
+
+	signature, err := a.sign(sshChallengeMessage(ch.config.Database, ch.config.User, challenge))
+	if err != nil {
+		return fmt.Errorf("ssh key authentication: %w", err)
+	}
+	ch.writer.Uvarint(clientSSHChallengeResponse)
+	ch.writer.ByteString(signature)
+	return ch.flushHello()
+}
+
+// sshChallengeMessage returns the message signed in response to the challenge of the server.
+func sshChallengeMessage(database, user, challenge string) []byte {
+	return []byte(strconv.FormatUint(helper.ClientTCPVersion, 10) + database + user + challenge)
+}
+
+// sign signs message and returns the signature in the SSH wire format (RFC 4253, RFC 5656 and
+// RFC 8332).
+func (a *SSHKeyAuth) sign(message []byte) ([]byte, error) {
+	var format string
+	var blob []byte
+	switch pub := a.Signer.Public().(type) {
+	case ed25519.PublicKey:
+		sig, err := a.Signer.Sign(rand.Reader, message, crypto.Hash(0))
+		if err != nil {
+			return nil, err
+		}
+		format, blob = "ssh-ed25519", sig
+	case *rsa.PublicKey:
+		// ssh-rsa signatures use SHA-1, which servers reject.
+		digest := sha512.Sum512(message)
+		sig, err := a.Signer.Sign(rand.Reader, digest[:], crypto.SHA512)
+		if err != nil {
+			return nil, err
+		}
+		format, blob = "rsa-sha2-512", sig
+	case *ecdsa.PublicKey:
+		var digest []byte
+		var hash crypto.Hash
+		switch pub.Curve.Params().BitSize {
+		case 256:
+			sum := sha256.Sum256(message)
+			digest, hash = sum[:], crypto.SHA256
+		case 384:
+			sum := sha512.Sum384(message)
+			digest, hash = sum[:], crypto.SHA384
+		case 521:
+			sum := sha512.Sum512(message)
+			digest, hash = sum[:], crypto.SHA512
+		default:
+			return nil, fmt.Errorf("unsupported ecdsa curve %s", pub.Curve.Params().Name)
+		}
+		der, err := a.Signer.Sign(rand.Reader, digest, hash)
+		if err != nil {
+			return nil, err
+		}
+		var rs struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(der, &rs); err != nil {
+			return nil, fmt.Errorf("parse ecdsa signature: %w", err)
+		}
+		format = "ecdsa-sha2-nistp" + strconv.Itoa(pub.Curve.Params().BitSize)
+		blob = appendSSHString(appendSSHString(nil, sshMPInt(rs.R)), sshMPInt(rs.S))
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", pub)
+	}
+	return appendSSHString(appendSSHString(nil, []byte(format)), blob), nil
+}
+
+// appendSSHString appends b to dst as an SSH string: a uint32 length and the bytes.
+func appendSSHString(dst, b []byte) []byte {
+	dst = binary.BigEndian.AppendUint32(dst, uint32(len(b)))
+	return append(dst, b...)
+}
+
+// sshMPInt returns the bytes of the SSH mpint encoding of the non-negative n.
+func sshMPInt(n *big.Int) []byte {
+	b := n.Bytes()
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return b
+}
+
+// sshKeyLoader loads the private key of the ssh_key setting. It is set by RegisterSSHKeyLoader.
+var sshKeyLoader func(path, passphrase string) (crypto.Signer, error)
+
+// RegisterSSHKeyLoader sets the function ParseConfig uses to load the private key file of the
+// ssh_key setting, decrypting it with ssh_key_passphrase if that is not empty. It must be called
+// from an init function.
+//
+// Importing the sshkey package registers a loader for OpenSSH, PKCS#1, PKCS#8 and SEC1 PEM keys:
+//
+//	import _ "github.com/vahid-sohrabloo/chconn/v3/sshkey"
+//
+// Without a loader, connection strings that set ssh_key are rejected.
+func RegisterSSHKeyLoader(load func(path, passphrase string) (crypto.Signer, error)) {
+	sshKeyLoader = load
+}
+
+// JWTAuth authenticates with a JSON Web Token, as used by ClickHouse Cloud. Config.User and
+// Config.Password are not sent.
+//
+// Token is called for each new connection, so it can return a refreshed token.
+type JWTAuth struct {
+	Token func(ctx context.Context) (string, error)
+}
+
+func (a *JWTAuth) hello(ctx context.Context, ch *conn) error {
+	if a.Token == nil {
+		return errors.New("jwt authentication: no token provider")
+	}
+	token, err := a.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("jwt authentication: %w", err)
+	}
+	ch.writer.String(jwtUserMarker)
+	ch.writer.String(token)
+	return ch.flushHello()
+}
+
+// JWTAuthFromFile returns a JWTAuth that reads the token from the file at path for each new
+// connection, so the file can be rotated without changing the config.
+func JWTAuthFromFile(path string) *JWTAuth {
+	return &JWTAuth{Token: func(context.Context) (string, error) {
+		token, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(token)), nil
+	}}
+}
+
+// StaticJWTAuth returns a JWTAuth that always sends token.
+func StaticJWTAuth(token string) *JWTAuth {
+	return &JWTAuth{Token: func(context.Context) (string, error) {
+		return token, nil
+	}}
+}
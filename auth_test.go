@@ -0,0 +1,88 @@
+package chconn
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHKeyAuthSign(t *testing.T) {
+	t.Parallel()
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecdsa384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name   string
+		signer crypto.Signer
+		format string
+	}{
+		{name: "ed25519", signer: ed25519Key, format: ssh.KeyAlgoED25519},
+		{name: "ecdsa", signer: ecdsaKey, format: ssh.KeyAlgoECDSA256},
+		{name: "ecdsa384", signer: ecdsa384Key, format: ssh.KeyAlgoECDSA384},
+		{name: "rsa", signer: rsaKey, format: ssh.KeyAlgoRSASHA512},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			auth := &SSHKeyAuth{Signer: tt.signer}
+			message := sshChallengeMessage("db", "user", "challenge")
+			blob, err := auth.sign(message)
+			require.NoError(t, err)
+
+			var sig ssh.Signature
+			require.NoError(t, ssh.Unmarshal(blob, &sig))
+			assert.Equal(t, tt.format, sig.Format)
+
+			pub, err := ssh.NewPublicKey(tt.signer.Public())
+			require.NoError(t, err)
+			require.NoError(t, pub.Verify(message, &sig))
+		})
+	}
+}
+
+func TestParseConfigAuth(t *testing.T) {
+	t.Parallel()
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("token1\n"), 0o600))
+
+	config, err := ParseConfig("host=localhost jwt=token0")
+	require.NoError(t, err)
+	require.IsType(t, &JWTAuth{}, config.Auth)
+	token, err := config.Auth.(*JWTAuth).Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token0", token)
+
+	config, err = ParseConfig("host=localhost jwt_file=" + tokenPath)
+	require.NoError(t, err)
+	jwtAuth := config.Auth.(*JWTAuth)
+	token, err = jwtAuth.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token1", token)
+	require.NoError(t, os.WriteFile(tokenPath, []byte("token2"), 0o600))
+	token, err = jwtAuth.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token2", token)
+
+	config, err = ParseConfig("host=localhost")
+	require.NoError(t, err)
+	assert.Nil(t, config.Auth)
+}
@@ -113,6 +113,14 @@ func (b *block) readColumnsHeader() error {
 func (b *block) readColumnsData(needValidateData bool, columns ...column.ColumnCore) error {
 	b.c.reader.SetCompress(b.c.compress)
 	defer b.c.reader.SetCompress(false)
+	// Dynamic and JSON columns create their DateTime subcolumns from the server info, so pass
+	// the session_timezone of the query instead of the server time zone.
+	serverInfo := b.c.serverInfo
+	if tz := b.c.timezone(); tz != serverInfo.Timezone {
+		si := *serverInfo
+		si.Timezone = tz
+		serverInfo = &si
+	}
 	for _, col := range columns {
 		colHeader, err := readColumnHeader(b.c.reader, b.c.serverInfo)
 		if err != nil {
@@ -122,7 +130,7 @@ func (b *block) readColumnsData(needValidateData bool, columns ...column.ColumnC
 		if err != nil {
 			return fmt.Errorf("read column header %q: %w", string(colHeader.Name), err)
 		}
-		err = col.ReadHeader(b.c.reader, b.c.serverInfo)
+		err = col.ReadHeader(b.c.reader, serverInfo)
 		if err != nil {
 			return fmt.Errorf("read column header \"%s\": %w", string(col.Name()), err)
 		}
@@ -138,7 +146,9 @@ func (b *block) readColumnsData(needValidateData bool, columns ...column.ColumnC
 	return nil
 }
 
-func (b *block) reorderColumns(columns []column.ColumnCore) ([]column.ColumnCore, error) {
+// reorderColumns sorts columns in place into the order of the block header. moved reports
+// whether any column changed position.
+func (b *block) reorderColumns(columns []column.ColumnCore) (_ []column.ColumnCore, moved bool, err error) {
 	for i, c := range b.ColumnsHeader {
 		// check if already sorted
 		if bytes.Equal(columns[i].Name(), b.ColumnsHeader[i].Name) {
@@ -146,14 +156,15 @@ func (b *block) reorderColumns(columns []column.ColumnCore) ([]column.ColumnCore
 		}
 		index, col := findColumn(columns, c.Name)
 		if col == nil {
-			return nil, &ColumnNotFoundError{
+			return nil, false, &ColumnNotFoundError{
 				Column: string(c.Name),
 			}
 		}
 		columns[index] = columns[i]
 		columns[i] = col
+		moved = true
 	}
-	return columns, nil
+	return columns, moved, nil
 }
 
 func findColumn(columns []column.ColumnCore, name []byte) (int, column.ColumnCore) {
@@ -198,15 +209,28 @@ func (b *block) writeColumnsBuffer(columns ...column.ColumnCore) error {
 		b.headerWriter.ByteString(column.Name)
 		b.headerWriter.ByteString(column.ChType)
 
+		sparse := b.sparseColumn(columns[i])
 		if b.c.serverInfo.Revision >= helper.DbmsMinProtocolWithCustomSerialization {
-			b.headerWriter.Uint8(0)
+			if sparse != nil {
+				// has custom serialization, serialization kind: sparse
+				b.headerWriter.Uint8(1)
+				b.headerWriter.Uint8(1)
+			} else {
+				b.headerWriter.Uint8(0)
+			}
 		}
 
 		columns[i].HeaderWriter(b.headerWriter)
 		if _, err := b.headerWriter.WriteTo(b.c.writerToCompress); err != nil {
 			return &writeError{"block: write header block data for column " + string(column.Name), err}
 		}
-		if _, err := columns[i].WriteTo(b.c.writerToCompress); err != nil {
+		var err error
+		if sparse != nil {
+			_, err = sparse.WriteSparseTo(b.c.writerToCompress)
+		} else {
+			_, err = columns[i].WriteTo(b.c.writerToCompress)
+		}
+		if err != nil {
 			return &writeError{"block: write block data for column " + string(column.Name), err}
 		}
 	}
@@ -214,13 +238,47 @@ func (b *block) writeColumnsBuffer(columns ...column.ColumnCore) error {
 	if err != nil {
 		return &writeError{"block: flush block data", err}
 	}
+	return b.c.endPacket()
+}
+
+// writeEncodedColumns writes columns whose data is already serialized, each with the default serialization.
+func (b *block) writeEncodedColumns(data [][]byte) error {
+	for i, column := range b.ColumnsHeader {
+		b.headerWriter.Reset()
+		b.headerWriter.ByteString(column.Name)
+		b.headerWriter.ByteString(column.ChType)
+		if b.c.serverInfo.Revision >= helper.DbmsMinProtocolWithCustomSerialization {
+			b.headerWriter.Uint8(0)
+		}
+		if _, err := b.headerWriter.WriteTo(b.c.writerToCompress); err != nil {
+			return &writeError{"block: write header block data for column " + string(column.Name), err}
+		}
+		if _, err := b.c.writerToCompress.Write(data[i]); err != nil {
+			return &writeError{"block: write block data for column " + string(column.Name), err}
+		}
+	}
+	err := b.c.flushCompress()
+	if err != nil {
+		return &writeError{"block: flush block data", err}
+	}
+	return b.c.endPacket()
+}
+
+// sparseColumn returns col if it should be sent with sparse serialization, or nil.
+func (b *block) sparseColumn(col column.ColumnCore) column.SparseColumn {
+	if b.c.serverInfo.Revision < helper.DbmsMinRevisionWithSparseSerialization {
+		return nil
+	}
+	if sc, ok := col.(column.SparseColumn); ok && sc.UseSparse(b.c.config.SparseRatio) {
+		return sc
+	}
 	return nil
 }
 
 func (b *block) getColumnsByChType() ([]column.ColumnCore, error) {
 	columns := make([]column.ColumnCore, len(b.ColumnsHeader))
 	for i, col := range b.ColumnsHeader {
-		columnByType, err := column.ColumnByType(col.ChType, 0, false, false, b.c.serverInfo.Timezone)
+		columnByType, err := column.ColumnByType(col.ChType, 0, false, false, b.c.timezone())
 		if err != nil {
 			return nil, err
 		}
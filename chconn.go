@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/vahid-sohrabloo/chconn/v3/column"
@@ -36,6 +38,10 @@ const (
 	clientData = 2
 	// Check that connection to the server is alive.
 	clientPing = 4
+	// Request a challenge to sign for SSH key authentication.
+	clientSSHChallengeRequest = 11
+	// The signed challenge of SSH key authentication.
+	clientSSHChallengeResponse = 12
 )
 
 const (
@@ -71,6 +77,8 @@ const (
 	serverProfileEvents = 14
 	// Receive server's (session-wide) default timezone
 	serverTimezoneUpdate = 17
+	// Challenge to sign for SSH key authentication.
+	serverSSHChallenge = 18
 )
 
 const (
@@ -124,6 +132,10 @@ type Conn interface {
 		query string,
 		queryOptions *QueryOptions,
 	) error
+	// ExecScript executes the semicolon-separated statements of script one by one. Semicolons in quoted strings,
+	// quoted identifiers, heredocs and comments don't separate statements. It stops on the first error and
+	// returns it as a *ScriptError.
+	ExecScript(ctx context.Context, script string) error
 	// Insert executes a insert query and commit all columns data.
 	//
 	// If the query is successful, the columns buffer will be reset.
@@ -149,10 +161,46 @@ type Conn interface {
 		ctx context.Context,
 		query string,
 		queryOptions *QueryOptions) (InsertStmt, error)
+	// InsertFromReader executes an insert query with a FORMAT clause, e.g. "INSERT INTO t FORMAT CSV", with the data
+	// read from r until EOF. The data is sent as is and parsed by the server.
+	//
+	// The data is sent in the query packet, whose length comes first, so only readers whose remaining length is
+	// known (regular *os.File and readers with a Len() int method such as *bytes.Reader) are streamed. Other
+	// readers are read into memory first; use InsertFromSizedReader to stream them. The server reads the whole
+	// query packet into memory and rejects one larger than its maximum query string size (1 GiB by default), so
+	// split larger data into several inserts.
+	//
+	// NOTE: only use for insert query
+	InsertFromReader(ctx context.Context, query string, r io.Reader) error
+	// InsertFromReaderWithOption executes an insert query with a FORMAT clause with the query options and the data
+	// read from r until EOF. See InsertFromReader.
+	//
+	// NOTE: only use for insert query
+	InsertFromReaderWithOption(ctx context.Context, query string, r io.Reader, queryOptions *QueryOptions) error
+	// InsertFromSizedReader executes an insert query with a FORMAT clause with the query options and the next size
+	// bytes of r, which are streamed to the server without buffering them. It fails and closes the connection if r
+	// ends before size bytes. See InsertFromReader.
+	//
+	// NOTE: only use for insert query
+	InsertFromSizedReader(ctx context.Context, query string, r io.Reader, size int64, queryOptions *QueryOptions) error
 	// Select executes a query and return select stmt.
 	//
 	// NOTE: only use for select query
 	Select(ctx context.Context, query string, columns ...column.ColumnCore) (SelectStmt, error)
+	// SelectFormatted executes a select query with a FORMAT clause, e.g. "SELECT * FROM t FORMAT CSV", and writes
+	// the rows formatted by the server to w block by block. Only the row formats without a prefix, a suffix or a
+	// delimiter between rows are supported, such as CSV, TSV and JSONEachRow, along with the *WithNames and
+	// *WithNamesAndTypes variants of CSV, TSV, TSVRaw, JSONCompactEachRow and JSONCompactStringsEachRow, whose
+	// header is written from the result columns of a DESCRIBE of the query. Formats with a prefix or suffix, such
+	// as JSON, XML and Pretty, and binary formats, such as Parquet, Arrow and Native, return an error.
+	//
+	// NOTE: only use for select query
+	SelectFormatted(ctx context.Context, query string, w io.Writer) error
+	// SelectFormattedWithOption executes a select query with a FORMAT clause with the query options and writes
+	// the rows formatted by the server to w. See SelectFormatted.
+	//
+	// NOTE: only use for select query
+	SelectFormattedWithOption(ctx context.Context, query string, w io.Writer, queryOptions *QueryOptions) error
 	// Select executes a query with the the query options and return select stmt.
 	//
 	// NOTE: only use for select query
@@ -196,11 +244,23 @@ type writeFlusher interface {
 	Flush() error
 }
 
+// compressWriter is the writer of compressed blocks, see readerwriter.NewCompressWriter.
+type compressWriter interface {
+	writeFlusher
+	SetMethod(method byte, level int)
+	SetCounters(compressed, uncompressed *uint64)
+}
+
 type conn struct {
 	conn              net.Conn          // the underlying TCP connection
 	parameterStatuses map[string]string // parameters that have been reported by the server
 	serverInfo        *shared.ServerInfo
 	clientInfo        *ClientInfo
+	// queryTimezone is the session_timezone of the current query sent by the server, empty for the
+	// server timezone.
+	queryTimezone string
+	// queryStats is the statistics of the current query.
+	queryStats *QueryStats
 
 	config *Config
 
@@ -209,6 +269,9 @@ type conn struct {
 	writer           *readerwriter.Writer
 	writerTo         io.Writer
 	writerToCompress io.Writer
+	// compressWriter writes to writerTo. It is created by the first compressed query.
+	compressWriter compressWriter
+	chunkedWriter  *readerwriter.ChunkedWriter
 
 	reader   *readerwriter.Reader
 	compress bool
@@ -244,6 +307,22 @@ func ConnectConfig(octx context.Context, config *Config) (c Conn, err error) {
 		panic("config must be created by ParseConfig")
 	}
 
+	if t, ok := config.Tracer.(ConnectTracer); ok {
+		octx = t.TraceConnectStart(octx, TraceConnectStartData{Config: config})
+		defer func() {
+			t.TraceConnectEnd(octx, TraceConnectEndData{Conn: c, Err: err})
+		}()
+	}
+
+	// Log the target fields rather than the connection string, which can hold credentials.
+	target := []any{"host", config.Host, "port", config.Port, "user", config.User, "database", config.Database}
+	config.log(octx, slog.LevelDebug, "connecting", target...)
+	defer func() {
+		if err != nil {
+			config.log(octx, slog.LevelError, "connect failed", append(target, "err", err)...)
+		}
+	}()
+
 	// Simplify usage by treating primary config and fallbacks the same.
 	fallbackConfigs := []*FallbackConfig{
 		{
@@ -256,6 +335,9 @@ func ConnectConfig(octx context.Context, config *Config) (c Conn, err error) {
 	ctx := octx
 	var lookupErrors []error
 	fallbackConfigs, lookupErrors = expandWithIPs(ctx, config.LookupFunc, fallbackConfigs)
+	for _, lookupErr := range lookupErrors {
+		config.log(octx, slog.LevelWarn, "hostname lookup failed", "err", lookupErr)
+	}
 	if len(fallbackConfigs) == 0 {
 		// If no hosts resolved, report the first lookup error if available.
 		if len(lookupErrors) > 0 {
@@ -279,10 +361,13 @@ func ConnectConfig(octx context.Context, config *Config) (c Conn, err error) {
 		c, err = connect(ctx, config, fc)
 		if err == nil {
 			foundBestServer = true
+			config.log(octx, slog.LevelInfo, "connected", "host", fc.Host, "port", fc.Port,
+				"server", c.ServerInfo().Name, "revision", c.ServerInfo().Revision)
 			break
 		} else if chErr, ok := err.(*ChError); ok {
 			return nil, &connectError{config: config, msg: "server error", err: chErr}
 		}
+		config.log(octx, slog.LevelWarn, "connect attempt failed", "host", fc.Host, "port", fc.Port, "err", err)
 	}
 
 	if !foundBestServer && fallbackConfig != nil {
@@ -350,8 +435,6 @@ func connect(ctx context.Context, config *Config, fallbackConfig *FallbackConfig
 	c := new(conn)
 	c.config = config
 
-	c.compress = config.Compress != CompressNone
-
 	var err error
 	network, address := NetworkAddress(fallbackConfig.Host, fallbackConfig.Port)
 	c.conn, err = config.DialFunc(ctx, network, address)
@@ -400,39 +483,132 @@ func connect(ctx context.Context, config *Config, fallbackConfig *FallbackConfig
 	} else {
 		c.writerTo = c.conn
 	}
-	if c.compress {
-		c.writerToCompress = readerwriter.NewCompressWriter(c.writerTo, byte(config.Compress))
-	} else {
-		c.writerToCompress = c.writerTo
-	}
+	c.setCompression(nil)
 
 	c.serverInfo = &shared.ServerInfo{}
-	err = c.hello()
+	err = c.hello(ctx)
 	if err != nil {
 		return nil, preferContextOverNetTimeoutError(ctx, err)
 	}
 
-	c.sendAddendum()
+	err = c.sendAddendum()
+	if err != nil {
+		return nil, preferContextOverNetTimeoutError(ctx, err)
+	}
 
 	c.block = newBlock(c)
 	c.profileEvent = newProfileEvent()
+	c.queryStats = &QueryStats{}
 	c.status = connStatusIdle
 
 	return c, nil
 }
 
-func (ch *conn) sendAddendum() {
+func (ch *conn) sendAddendum() error {
 	v := ch.negotiatedVersion()
+	var sendChunked, recvChunked bool
+	if v >= helper.DbmsMinProtocolVersionWithChunkedPackets {
+		var err error
+		// The server's receive preference applies to the packets we send, and vice versa.
+		sendChunked, err = negotiateChunked(ch.config.ChunkedSend, ChunkedMode(ch.serverInfo.ProtoRecvChunked), "send")
+		if err != nil {
+			return err
+		}
+		recvChunked, err = negotiateChunked(ch.config.ChunkedRecv, ChunkedMode(ch.serverInfo.ProtoSendChunked), "receive")
+		if err != nil {
+			return err
+		}
+	}
+
 	if v >= helper.DbmsMinProtocolWithQuotaKey {
 		ch.writer.String(ch.config.QuotaKey)
 	}
 	if v >= helper.DbmsMinProtocolVersionWithChunkedPackets {
-		ch.writer.String("notchunked") // proto_send_chunked
-		ch.writer.String("notchunked") // proto_recv_chunked
+		ch.writer.String(chunkedString(sendChunked)) // proto_send_chunked
+		ch.writer.String(chunkedString(recvChunked)) // proto_recv_chunked
 	}
 	if v >= helper.DbmsMinRevisionWithVersionedParallelReplicas {
 		ch.writer.Uvarint(0) // parallel replicas protocol version
 	}
+	if !sendChunked && !recvChunked {
+		// The addendum is sent with the first query.
+		return nil
+	}
+
+	// The framing starts after the addendum.
+	if _, err := ch.writer.WriteTo(ch.writerTo); err != nil {
+		return &writeError{"write addendum", err}
+	}
+	if sendChunked {
+		ch.chunkedWriter = readerwriter.NewChunkedWriter(ch.writerTo)
+		ch.writerTo = ch.chunkedWriter
+		ch.compressWriter = nil
+		ch.setCompression(nil)
+	}
+	if recvChunked {
+		ch.reader.SetMainReader(readerwriter.NewChunkedReader(ch.reader.MainReader()))
+	}
+	return nil
+}
+
+// negotiateChunked returns whether packets of one direction are chunked, given the preference
+// of the client and of the server for that direction.
+func negotiateChunked(client, server ChunkedMode, direction string) (bool, error) {
+	if client == "" {
+		client = NotChunkedOptional
+	}
+	clientChunked := strings.HasPrefix(string(client), "chunked")
+	serverChunked := strings.HasPrefix(string(server), "chunked")
+	switch {
+	case server == "" || strings.HasSuffix(string(server), "_optional"):
+		return clientChunked, nil
+	case strings.HasSuffix(string(client), "_optional"):
+		return serverChunked, nil
+	case clientChunked != serverChunked:
+		return false, fmt.Errorf("incompatible protocol: %s is %s, server requires %s",
+			direction, chunkedString(clientChunked), chunkedString(serverChunked))
+	}
+	return serverChunked, nil
+}
+
+func chunkedString(chunked bool) string {
+	if chunked {
+		return string(Chunked)
+	}
+	return string(NotChunked)
+}
+
+// endPacket ends a packet sent to the server. With chunked packets, the buffered data is sent
+// and the packet is terminated with an empty chunk.
+func (ch *conn) endPacket() error {
+	if ch.chunkedWriter == nil {
+		return nil
+	}
+	if _, err := ch.writer.WriteTo(ch.writerTo); err != nil {
+		return &writeError{"write packet", err}
+	}
+	if err := ch.chunkedWriter.EndPacket(); err != nil {
+		return &writeError{"end packet", err}
+	}
+	return nil
+}
+
+// setCompression sets the compression of the next query: c, or Config.Compress and
+// Config.CompressLevel if c is nil.
+func (ch *conn) setCompression(c *Compression) {
+	if c == nil {
+		c = &Compression{Method: ch.config.Compress, Level: ch.config.CompressLevel}
+	}
+	ch.compress = c.Method != CompressNone
+	if !ch.compress {
+		ch.writerToCompress = ch.writerTo
+		return
+	}
+	if ch.compressWriter == nil {
+		ch.compressWriter = readerwriter.NewCompressWriter(ch.writerTo, byte(c.Method)).(compressWriter)
+	}
+	ch.compressWriter.SetMethod(byte(c.Method), c.Level)
+	ch.writerToCompress = ch.compressWriter
 }
 
 func (ch *conn) flushCompress() error {
@@ -447,18 +623,20 @@ func (ch *conn) RawConn() net.Conn {
 }
 
 // send hello to ClickHouse
-func (ch *conn) hello() error {
+func (ch *conn) hello(ctx context.Context) error {
 	ch.writer.Uvarint(clientHello)
 	ch.writer.String(ch.config.ClientName)
 	ch.writer.Uvarint(clientVersionMajor)
 	ch.writer.Uvarint(clientVersionMinor)
 	ch.writer.Uvarint(helper.ClientTCPVersion)
 	ch.writer.String(ch.config.Database)
-	ch.writer.String(ch.config.User)
-	ch.writer.String(ch.config.Password)
 
-	if _, err := ch.writer.WriteTo(ch.writerTo); err != nil {
-		return fmt.Errorf("write hello: %w", err)
+	var auth Authenticator = PasswordAuth{}
+	if ch.config.Auth != nil {
+		auth = ch.config.Auth
+	}
+	if err := auth.hello(ctx, ch); err != nil {
+		return err
 	}
 
 	res, err := ch.receiveAndProcessData(emptyQueryOptions)
@@ -466,7 +644,23 @@ func (ch *conn) hello() error {
 		return err
 	}
 	if ch.serverInfo.Revision == 0 {
-		return &unexpectedPacket{expected: "serverHello", actual: res}
+		return ch.unexpected("serverHello", res)
+	}
+	return nil
+}
+
+// timezone returns the timezone of the current query: its session_timezone if the server sent a
+// timezone update, otherwise the server timezone.
+func (ch *conn) timezone() string {
+	if ch.queryTimezone != "" {
+		return ch.queryTimezone
+	}
+	return ch.serverInfo.Timezone
+}
+
+func (ch *conn) flushHello() error {
+	if _, err := ch.writer.WriteTo(ch.writerTo); err != nil {
+		return fmt.Errorf("write hello: %w", err)
 	}
 	return nil
 }
@@ -505,14 +699,27 @@ func (ch *conn) unlock() {
 	}
 }
 
-func (ch *conn) sendQueryWithOption(
-	query,
-	queryID string,
-	settings Settings,
-	parameters *Parameters,
+func (ch *conn) sendQueryWithOption(query string, queryOptions *QueryOptions) error {
+	return ch.sendQueryWithData(query, nil, 0, queryOptions)
+}
+
+// sendQueryWithData sends a query followed by dataLen bytes of data, the inline data of an insert query.
+func (ch *conn) sendQueryWithData(
+	query string,
+	data io.Reader,
+	dataLen int64,
+	queryOptions *QueryOptions,
 ) error {
+	settings, parameters := queryOptions.Settings, queryOptions.Parameters
+	ch.queryTimezone = ""
+	ch.queryStats = &QueryStats{}
+	ch.setCompression(queryOptions.Compression)
+	if ch.compress {
+		ch.compressWriter.SetCounters(&ch.queryStats.CompressedBytesSent, &ch.queryStats.UncompressedBytesSent)
+	}
+	ch.reader.SetCompressCounters(&ch.queryStats.CompressedBytesReceived, &ch.queryStats.UncompressedBytesReceived)
 	ch.writer.Uvarint(clientQuery)
-	ch.writer.String(queryID)
+	ch.writer.String(queryOptions.QueryID)
 	if ch.serverInfo.Revision >= helper.DbmsMinRevisionWithClientInfo {
 		if ch.clientInfo == nil {
 			ch.clientInfo = &ClientInfo{}
@@ -549,7 +756,11 @@ func (ch *conn) sendQueryWithOption(
 		ch.writer.Uint8(0)
 	}
 
-	ch.writer.String(query)
+	if data == nil {
+		ch.writer.String(query)
+	} else if err := ch.writeQueryData(query, data, dataLen); err != nil {
+		return err
+	}
 
 	if ch.serverInfo.Revision >= helper.DbmsMinProtocolWithParameters {
 		parameters.write(ch.writer)
@@ -558,6 +769,9 @@ func (ch *conn) sendQueryWithOption(
 		return errors.New("parameters are not supported by the server")
 	}
 
+	if err := ch.endPacket(); err != nil {
+		return err
+	}
 	return ch.sendEmptyBlock()
 }
 
@@ -578,7 +792,10 @@ func (ch *conn) sendData(block *block, numRows int) error {
 
 func (ch *conn) sendEmptyBlock() error {
 	ch.block.reset()
-	return ch.sendData(ch.block, 0)
+	if err := ch.sendData(ch.block, 0); err != nil {
+		return err
+	}
+	return ch.endPacket()
 }
 
 func (ch *conn) Close() error {
@@ -587,6 +804,7 @@ func (ch *conn) Close() error {
 	}
 	ch.contextWatcher.Unwatch()
 	ch.status = connStatusClosed
+	ch.config.log(context.Background(), slog.LevelDebug, "connection closed", "addr", ch.conn.RemoteAddr())
 	return ch.conn.Close()
 }
 
@@ -601,6 +819,7 @@ func (ch *conn) processProfileInfo(queryOption *QueryOptions) error {
 	if err := profile.read(ch); err != nil {
 		return err
 	}
+	ch.queryStats.addProfile(profile)
 	if queryOption.OnProfile != nil {
 		queryOption.OnProfile(profile)
 	}
@@ -626,6 +845,7 @@ func (ch *conn) processProfileEvents(queryOption *QueryOptions) error {
 		return err
 	}
 	// profileEvent.read → readColumnsData has its own defer SetCompress(false).
+	ch.queryStats.addProfileEvents(ch.profileEvent)
 	if queryOption.OnProfileEvent != nil {
 		queryOption.OnProfileEvent(ch.profileEvent)
 	}
@@ -637,6 +857,7 @@ func (ch *conn) processProgress(queryOption *QueryOptions) error {
 	if err := progress.read(ch); err != nil {
 		return err
 	}
+	ch.queryStats.addProgress(progress)
 	if queryOption.OnProgress != nil {
 		queryOption.OnProgress(progress)
 	}
@@ -667,6 +888,8 @@ func (ch *conn) handleServerException() error {
 	}
 	// Close connection by default, unless OnError callback says otherwise.
 	if ch.config.OnError == nil || ch.config.OnError(ch, chErr) {
+		ch.config.log(context.Background(), slog.LevelDebug, "closing connection after server error",
+			"code", int32(chErr.Code), "name", chErr.Name)
 		ch.Close()
 	}
 	return chErr
@@ -710,8 +933,11 @@ func (ch *conn) processNonDataPacket(packet uint64, queryOption *QueryOptions) (
 	case serverLog:
 		err = ch.readCompressibleBlock()
 	case serverTimezoneUpdate:
-		ch.reader.String() //nolint:errcheck //no needed
+		if ch.queryTimezone, err = ch.reader.String(); err != nil {
+			return nil, &readError{"timezone update: read timezone", err}
+		}
 	default:
+		ch.config.log(context.Background(), slog.LevelWarn, "packet not implemented", "packet", packet)
 		return nil, &notImplementedPacket{packet: packet}
 	}
 	if err != nil {
@@ -739,6 +965,11 @@ type QueryOptions struct {
 	OnProfile      func(*Profile)
 	OnProfileEvent func(*ProfileEvent)
 	Parameters     *Parameters
+	// Compression overrides Config.Compress and Config.CompressLevel for the query, e.g. ZSTD for a
+	// large insert or CompressNone for a small query. It sets how the client compresses the data it
+	// sends; the server compresses its data if the query is compressed, with the method of the
+	// network_compression_method setting.
+	Compression *Compression
 }
 
 func (ch *conn) Exec(ctx context.Context, query string) error {
@@ -762,6 +993,15 @@ func (ch *conn) ExecWithOption(
 	return nil
 }
 
+func (ch *conn) ExecScript(ctx context.Context, script string) error {
+	for i, stmt := range helper.SplitScript(script) {
+		if err := ch.ExecWithOption(ctx, stmt.Query, nil); err != nil {
+			return &ScriptError{Statement: i, Line: stmt.Line, Query: stmt.Query, Err: err}
+		}
+	}
+	return nil
+}
+
 func readServerInfo(srv *shared.ServerInfo, r *readerwriter.Reader) error {
 	// The server decides which fields to include based on the client's TCP version,
 	// so we must use the minimum of client and server versions when determining
@@ -810,10 +1050,10 @@ func readServerInfoWithClientVersion(srv *shared.ServerInfo, r *readerwriter.Rea
 		}
 	}
 	if v >= helper.DbmsMinProtocolVersionWithChunkedPackets {
-		if _, err = r.String(); err != nil {
+		if srv.ProtoSendChunked, err = r.String(); err != nil {
 			return &readError{"ServerInfo: could not read proto_send_chunked_srv", err}
 		}
-		if _, err = r.String(); err != nil {
+		if srv.ProtoRecvChunked, err = r.String(); err != nil {
 			return &readError{"ServerInfo: could not read proto_recv_chunked_srv", err}
 		}
 	}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v3/column"
 )
 
 func TestConnect(t *testing.T) {
@@ -273,6 +274,40 @@ func TestExecCtxError(t *testing.T) {
 	assert.True(t, c.IsClosed())
 }
 
+func TestExecScript(t *testing.T) {
+	t.Parallel()
+
+	c, err := Connect(context.Background(), os.Getenv("CHX_TEST_TCP_CONN_STRING"))
+	require.NoError(t, err)
+	defer c.Close()
+
+	err = c.ExecScript(context.Background(), `DROP TABLE IF EXISTS test_exec_script;
+-- the table; of the test
+CREATE TABLE test_exec_script (s String) ENGINE = Memory;
+INSERT INTO test_exec_script SELECT 'a;b';
+INSERT INTO test_exec_script SELECT $$c;d$$;`)
+	require.NoError(t, err)
+
+	col := column.NewString()
+	stmt, err := c.Select(context.Background(), "SELECT s FROM test_exec_script ORDER BY s", col)
+	require.NoError(t, err)
+	var got []string
+	for stmt.Next() {
+		got = col.Read(got)
+	}
+	require.NoError(t, stmt.Err())
+	assert.Equal(t, []string{"a;b", "c;d"}, got)
+
+	err = c.ExecScript(context.Background(), "SELECT 1;\n\nSELECT throwIf(1);\nSELECT 2")
+	var scriptErr *ScriptError
+	require.ErrorAs(t, err, &scriptErr)
+	assert.Equal(t, 1, scriptErr.Statement)
+	assert.Equal(t, 3, scriptErr.Line)
+	assert.Equal(t, "SELECT throwIf(1)", scriptErr.Query)
+	var chErr *ChError
+	assert.ErrorAs(t, err, &chErr)
+}
+
 func TestReceivePackError(t *testing.T) {
 	t.Parallel()
 
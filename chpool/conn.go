@@ -2,6 +2,7 @@ package chpool
 
 import (
 	"context"
+	"log/slog"
 
 	puddle "github.com/jackc/puddle/v2"
 	"github.com/vahid-sohrabloo/chconn/v3"
@@ -64,6 +65,7 @@ func (ch *conn) Release() {
 	ch.res = nil
 
 	if conn.IsClosed() || conn.IsBusy() {
+		ch.p.log(slog.LevelDebug, "destroying connection", "reason", "released_closed_or_busy")
 		res.Destroy()
 		// Signal to the health check to run since we just destroyed a connections
 		// and we might be below minConns now
@@ -76,6 +78,7 @@ func (ch *conn) Release() {
 	// so we also check the lifetime here and force a health check
 	if ch.p.isExpired(res) {
 		ch.p.lifetimeDestroyCount.Add(1)
+		ch.p.log(slog.LevelDebug, "destroying connection", "reason", "max_lifetime")
 		res.Destroy()
 		// Signal to the health check to run since we just destroyed a connections
 		// and we might be below minConns now
@@ -92,6 +95,7 @@ func (ch *conn) Release() {
 		if ch.p.afterRelease(conn) {
 			res.Release()
 		} else {
+			ch.p.log(slog.LevelDebug, "destroying connection", "reason", "after_release")
 			res.Destroy()
 			// Signal to the health check to run since we just destroyed a connections
 			// and we might be below minConns now
@@ -3,6 +3,8 @@ package chpool
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"math/rand"
 	"runtime"
 	"strconv"
@@ -97,6 +99,8 @@ type Pool interface {
 		query string,
 		queryOptions *chconn.QueryOptions,
 	) error
+	// ExecScript acquires a connection and executes the statements of script on it. See chconn.Conn.ExecScript.
+	ExecScript(ctx context.Context, script string) error
 	// Insert executes a insert query and commit all columns data.
 	//
 	// If the query is successful, the columns buffer will be reset.
@@ -122,10 +126,41 @@ type Pool interface {
 		ctx context.Context,
 		query string,
 		queryOptions *chconn.QueryOptions) (chconn.InsertStmt, error)
+	// InsertFromReader acquires a connection and executes an insert query with a FORMAT clause with the data read
+	// from r. See chconn.Conn.InsertFromReader.
+	//
+	// NOTE: only use for insert query
+	InsertFromReader(ctx context.Context, query string, r io.Reader) error
+	// InsertFromReaderWithOption acquires a connection and executes an insert query with a FORMAT clause with the
+	// query options and the data read from r. See chconn.Conn.InsertFromReader.
+	//
+	// NOTE: only use for insert query
+	InsertFromReaderWithOption(ctx context.Context, query string, r io.Reader, queryOptions *chconn.QueryOptions) error
+	// InsertFromSizedReader acquires a connection and executes an insert query with a FORMAT clause with the
+	// query options and the next size bytes of r. See chconn.Conn.InsertFromSizedReader.
+	//
+	// NOTE: only use for insert query
+	InsertFromSizedReader(
+		ctx context.Context,
+		query string,
+		r io.Reader,
+		size int64,
+		queryOptions *chconn.QueryOptions,
+	) error
 	// Select executes a query and return select stmt.
 	//
 	// NOTE: only use for select query
 	Select(ctx context.Context, query string, columns ...column.ColumnCore) (chconn.SelectStmt, error)
+	// SelectFormatted acquires a connection and executes a select query with a FORMAT clause, writing the rows
+	// formatted by the server to w. See chconn.Conn.SelectFormatted.
+	//
+	// NOTE: only use for select query
+	SelectFormatted(ctx context.Context, query string, w io.Writer) error
+	// SelectFormattedWithOption acquires a connection and executes a select query with a FORMAT clause with the
+	// query options, writing the rows formatted by the server to w. See chconn.Conn.SelectFormatted.
+	//
+	// NOTE: only use for select query
+	SelectFormattedWithOption(ctx context.Context, query string, w io.Writer, queryOptions *chconn.QueryOptions) error
 	// Select executes a query with a query options and return select stmt.
 	//
 	// NOTE: only use for select query
@@ -189,6 +224,8 @@ type pool struct {
 	beforeAcquire         func(context.Context, chconn.Conn) bool
 	afterRelease          func(chconn.Conn) bool
 	beforeClose           func(chconn.Conn)
+	acquireTracer         AcquireTracer
+	logger                *slog.Logger
 	shouldPing            func(time.Duration) bool
 	minConns              int32
 	maxConns              int32
@@ -263,6 +300,10 @@ type Config struct {
 	// behavior pings connections that have been idle for more than 1 second.
 	ShouldPing func(idleDuration time.Duration) bool
 
+	// Logger receives the events of the pool, such as connections destroyed by the health check or failed pings.
+	// If nil, ConnConfig.Logger is used.
+	Logger *slog.Logger
+
 	createdByParseConfig bool // Used to enforce created by ParseConfig rule.
 }
 
@@ -328,6 +369,11 @@ func NewWithConfig(config *Config) (Pool, error) {
 		healthCheckChan:       make(chan struct{}, 1),
 		closeChan:             make(chan struct{}),
 	}
+	p.acquireTracer, _ = config.ConnConfig.Tracer.(AcquireTracer)
+	p.logger = config.Logger
+	if p.logger == nil {
+		p.logger = config.ConnConfig.Logger
+	}
 
 	var err error
 	p.p, err = puddle.NewPool(
@@ -519,9 +565,18 @@ func (p *pool) Close() {
 	p.closeOnce.Do(func() {
 		close(p.closeChan)
 		p.p.Close()
+		p.log(slog.LevelDebug, "pool closed")
 	})
 }
 
+// log writes a record to the logger of the pool. It does nothing if the logger is not set.
+func (p *pool) log(level slog.Level, msg string, args ...any) {
+	if p.logger == nil || !p.logger.Enabled(context.Background(), level) {
+		return
+	}
+	p.logger.Log(context.Background(), level, msg, args...)
+}
+
 func (p *pool) isExpired(res *puddle.Resource[*connResource]) bool {
 	return time.Now().After(res.Value().maxAgeTime)
 }
@@ -558,10 +613,12 @@ func (p *pool) checkHealth() {
 		// If checkMinConns failed we don't destroy any connections since we couldn't
 		// even get to minConns
 		if err := p.checkMinConns(); err != nil {
-			// Should we log this error somewhere?
+			p.log(slog.LevelWarn, "health check could not create min connections", "min_conns", p.minConns, "err", err)
 			break
 		}
 		if err := p.checkMinIdleConns(); err != nil {
+			p.log(slog.LevelWarn, "health check could not create min idle connections",
+				"min_idle_conns", p.minIdleConns, "err", err)
 			break
 		}
 		if !p.checkConnsHealth() {
@@ -588,12 +645,14 @@ func (p *pool) checkConnsHealth() bool {
 		// We're okay going under minConns if the lifetime is up
 		if p.isExpired(res) && totalConns >= p.minConns {
 			p.lifetimeDestroyCount.Add(1)
+			p.log(slog.LevelDebug, "destroying connection", "reason", "max_lifetime")
 			res.Destroy()
 			destroyed = true
 			// Since Destroy is async we manually decrement totalConns.
 			totalConns--
 		} else if res.IdleDuration() > p.maxConnIdleTime && totalConns > p.minConns {
 			p.idleDestroyCount.Add(1)
+			p.log(slog.LevelDebug, "destroying connection", "reason", "max_idle", "idle", res.IdleDuration())
 			res.Destroy()
 			destroyed = true
 			// Since Destroy is async we manually decrement totalConns.
@@ -657,7 +716,17 @@ func (p *pool) createIdleResources(targetResources int) error {
 }
 
 // Acquire returns a connection (Conn) from the Pool
-func (p *pool) Acquire(ctx context.Context) (Conn, error) {
+func (p *pool) Acquire(ctx context.Context) (c Conn, err error) {
+	if p.acquireTracer != nil {
+		ctx = p.acquireTracer.TraceAcquireStart(ctx, p, TraceAcquireStartData{})
+		defer func() {
+			var conn chconn.Conn
+			if c != nil {
+				conn = c.Conn()
+			}
+			p.acquireTracer.TraceAcquireEnd(ctx, p, TraceAcquireEndData{Conn: conn, Err: err})
+		}()
+	}
 	for {
 		res, err := p.p.Acquire(ctx)
 		if err != nil {
@@ -671,6 +740,7 @@ func (p *pool) Acquire(ctx context.Context) (Conn, error) {
 			err := cr.conn.Ping(pingCtx)
 			cancel()
 			if err != nil {
+				p.log(slog.LevelWarn, "destroying connection", "reason", "ping_failed", "err", err)
 				res.Destroy()
 				continue
 			}
@@ -680,6 +750,7 @@ func (p *pool) Acquire(ctx context.Context) (Conn, error) {
 			return cr.getConn(p, res), nil
 		}
 
+		p.log(slog.LevelDebug, "destroying connection", "reason", "before_acquire")
 		res.Destroy()
 	}
 }
@@ -757,6 +828,18 @@ func (p *pool) ExecWithOption(
 	return err
 }
 
+// ExecScript acquires a connection from the Pool and executes the statements of script on it, so that session
+// settings set by a statement apply to the next ones.
+func (p *pool) ExecScript(ctx context.Context, script string) error {
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	err = c.Conn().ExecScript(ctx, script)
+	c.Release()
+	return err
+}
+
 // Query acquires a connection and executes a query that returns chconn.Rows.
 // See chconn.Rows documentation to close the returned Rows and return the acquired connection to the Pool.
 //
@@ -877,6 +960,63 @@ func (p *pool) InsertStreamWithOption(ctx context.Context, query string, queryOp
 	return s, nil
 }
 
+func (p *pool) SelectFormatted(ctx context.Context, query string, w io.Writer) error {
+	return p.SelectFormattedWithOption(ctx, query, w, nil)
+}
+
+func (p *pool) SelectFormattedWithOption(
+	ctx context.Context,
+	query string,
+	w io.Writer,
+	queryOptions *chconn.QueryOptions,
+) error {
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.Conn().SelectFormattedWithOption(ctx, query, w, queryOptions)
+	c.Release()
+	return err
+}
+
+func (p *pool) InsertFromReader(ctx context.Context, query string, r io.Reader) error {
+	return p.InsertFromReaderWithOption(ctx, query, r, nil)
+}
+
+func (p *pool) InsertFromReaderWithOption(
+	ctx context.Context,
+	query string,
+	r io.Reader,
+	queryOptions *chconn.QueryOptions,
+) error {
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.Conn().InsertFromReaderWithOption(ctx, query, r, queryOptions)
+	c.Release()
+	return err
+}
+
+func (p *pool) InsertFromSizedReader(
+	ctx context.Context,
+	query string,
+	r io.Reader,
+	size int64,
+	queryOptions *chconn.QueryOptions,
+) error {
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.Conn().InsertFromSizedReader(ctx, query, r, size, queryOptions)
+	c.Release()
+	return err
+}
+
 // Ping acquires a connection from the Pool and send ping
 // If returns without error, the database Ping is considered successful, otherwise, the error is returned.
 func (p *pool) Ping(ctx context.Context) error {
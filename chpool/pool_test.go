@@ -1,11 +1,15 @@
 package chpool
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"runtime"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -278,6 +282,55 @@ func TestPoolBeforeAcquire(t *testing.T) {
 	assert.EqualValues(t, 12, acquireAttempts)
 }
 
+type acquireTracer struct {
+	start atomic.Int32
+	end   []TraceAcquireEndData
+}
+
+func (t *acquireTracer) TraceQueryStart(ctx context.Context, _ chconn.Conn, _ chconn.TraceQueryStartData) context.Context {
+	return ctx
+}
+
+func (t *acquireTracer) TraceQueryEnd(context.Context, chconn.Conn, chconn.TraceQueryEndData) {}
+
+func (t *acquireTracer) TraceAcquireStart(ctx context.Context, _ Pool, _ TraceAcquireStartData) context.Context {
+	t.start.Add(1)
+	return ctx
+}
+
+func (t *acquireTracer) TraceAcquireEnd(_ context.Context, _ Pool, data TraceAcquireEndData) {
+	t.end = append(t.end, data)
+}
+
+func TestPoolAcquireTracer(t *testing.T) {
+	t.Parallel()
+
+	config, err := ParseConfig(os.Getenv("CHX_TEST_TCP_CONN_STRING"))
+	require.NoError(t, err)
+	tracer := &acquireTracer{}
+	config.ConnConfig.Tracer = tracer
+
+	db, err := NewWithConfig(config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	c, err := db.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, tracer.start.Load())
+	require.Len(t, tracer.end, 1)
+	assert.Equal(t, c.Conn(), tracer.end[0].Conn)
+	assert.NoError(t, tracer.end[0].Err)
+	c.Release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = db.Acquire(ctx)
+	require.Error(t, err)
+	require.Len(t, tracer.end, 2)
+	assert.Nil(t, tracer.end[1].Conn)
+	assert.Equal(t, err, tracer.end[1].Err)
+}
+
 func TestPoolAfterRelease(t *testing.T) {
 	t.Parallel()
 
@@ -1160,3 +1213,39 @@ func TestConnectEagerlyReachesMinPoolSize(t *testing.T) {
 
 	t.Fatal("did not reach min pool size")
 }
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestPoolLogHealthCheckError(t *testing.T) {
+	t.Parallel()
+
+	config, err := ParseConfig("host=127.0.0.1 port=1 password=secret pool_min_conns=1 pool_health_check_period=10ms")
+	require.NoError(t, err)
+	var buf syncBuffer
+	config.Logger = slog.New(slog.NewTextHandler(&buf, nil))
+
+	pool, err := NewWithConfig(config)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(buf.String(), `level=WARN msg="health check could not create min connections" min_conns=1`)
+	}, 5*time.Second, 10*time.Millisecond)
+	// The connections log to ConnConfig.Logger, which is not set.
+	assert.NotContains(t, buf.String(), "connect failed")
+}
@@ -17,6 +17,7 @@ func (e errRows) Values() []any                { return nil }
 func (e errRows) Conn() chconn.Conn            { return nil }
 func (e errRows) Columns() []column.ColumnCore { return nil }
 func (e errRows) CurrentRow() int              { return 0 }
+func (e errRows) Stats() *chconn.QueryStats    { return nil }
 
 type errRow struct {
 	err error
@@ -79,10 +80,22 @@ func (rows *poolRows) CurrentRow() int {
 	return rows.r.CurrentRow()
 }
 
+// RowLayouts returns the layouts cached for the result set, if the underlying rows keep them.
+func (rows *poolRows) RowLayouts() *chconn.RowLayouts {
+	if h, ok := rows.r.(interface{ RowLayouts() *chconn.RowLayouts }); ok {
+		return h.RowLayouts()
+	}
+	return nil
+}
+
 func (rows *poolRows) Columns() []column.ColumnCore {
 	return rows.r.Columns()
 }
 
+func (rows *poolRows) Stats() *chconn.QueryStats {
+	return rows.r.Stats()
+}
+
 type poolRow struct {
 	r   chconn.Row
 	c   Conn
@@ -0,0 +1,26 @@
+package chpool
+
+import (
+	"context"
+
+	"github.com/vahid-sohrabloo/chconn/v3"
+)
+
+// AcquireTracer traces the acquires of connections from a pool. It is used if the Tracer of
+// Config.ConnConfig implements it.
+type AcquireTracer interface {
+	// TraceAcquireStart is called at the start of Acquire. The returned context is passed to
+	// TraceAcquireEnd.
+	TraceAcquireStart(ctx context.Context, pool Pool, data TraceAcquireStartData) context.Context
+	// TraceAcquireEnd is called when Acquire returns.
+	TraceAcquireEnd(ctx context.Context, pool Pool, data TraceAcquireEndData)
+}
+
+// TraceAcquireStartData is the data of AcquireTracer.TraceAcquireStart.
+type TraceAcquireStartData struct{}
+
+// TraceAcquireEndData is the data of AcquireTracer.TraceAcquireEnd.
+type TraceAcquireEndData struct {
+	Conn chconn.Conn
+	Err  error
+}
@@ -0,0 +1,227 @@
+// Package chprom exports Prometheus metrics of chconn queries and chpool pools.
+//
+// A Collector records the duration, rows, bytes and errors of the queries through the
+// chconn.Config.OnQueryDone hook, how long connections of a pool are held through the
+// chpool.Config acquire and release hooks, and the statistics of the pools at each scrape:
+//
+//	collector := chprom.NewCollector(nil)
+//	config, err := chpool.ParseConfig(connString)
+//	...
+//	collector.Instrument("main", config)
+//	pool, err := chpool.NewWithConfig(config)
+//	...
+//	collector.AddPool("main", pool)
+//	prometheus.MustRegister(collector)
+package chprom
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vahid-sohrabloo/chconn/v3"
+	"github.com/vahid-sohrabloo/chconn/v3/chpool"
+)
+
+// Options are the options of a Collector.
+type Options struct {
+	// Namespace is the prefix of the metric names. Default "chconn".
+	Namespace string
+	// ConstLabels are added to all the metrics.
+	ConstLabels prometheus.Labels
+	// QueryLabels are the names of additional labels of the query metrics. QueryLabelValues returns
+	// their values for a query, in the same order.
+	QueryLabels      []string
+	QueryLabelValues func(conn chconn.Conn, done *chconn.QueryDone) []string
+	// DurationBuckets are the buckets in seconds of the duration histograms. Default prometheus.DefBuckets.
+	DurationBuckets []float64
+	// SizeBuckets are the buckets of the rows and bytes histograms. Default powers of 10 from 1 to 1e10.
+	SizeBuckets []float64
+}
+
+// Collector is a prometheus.Collector of query and pool metrics. It must be connected to the
+// queries with Instrument or InstrumentConn and to the pools with AddPool.
+type Collector struct {
+	opts Options
+
+	queryDuration *prometheus.HistogramVec
+	readRows      *prometheus.HistogramVec
+	readBytes     *prometheus.HistogramVec
+	writtenRows   *prometheus.HistogramVec
+	writtenBytes  *prometheus.HistogramVec
+	queryErrors   *prometheus.CounterVec
+	holdDuration  *prometheus.HistogramVec
+
+	mu    sync.Mutex
+	pools map[string]chpool.Pool
+	// acquired is the time each acquired connection was acquired at.
+	acquired sync.Map
+
+	poolDescs poolDescs
+}
+
+// NewCollector returns a Collector with opts, which may be nil.
+func NewCollector(opts *Options) *Collector {
+	c := &Collector{pools: map[string]chpool.Pool{}}
+	if opts != nil {
+		c.opts = *opts
+	}
+	if c.opts.Namespace == "" {
+		c.opts.Namespace = "chconn"
+	}
+	if c.opts.DurationBuckets == nil {
+		c.opts.DurationBuckets = prometheus.DefBuckets
+	}
+	if c.opts.SizeBuckets == nil {
+		c.opts.SizeBuckets = prometheus.ExponentialBuckets(1, 10, 11)
+	}
+
+	queryLabels := append([]string{"kind"}, c.opts.QueryLabels...)
+	histogram := func(name, help string, buckets []float64) *prometheus.HistogramVec {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   c.opts.Namespace,
+			Name:        name,
+			Help:        help,
+			ConstLabels: c.opts.ConstLabels,
+			Buckets:     buckets,
+		}, queryLabels)
+	}
+	c.queryDuration = histogram("query_duration_seconds", "Duration of the queries.", c.opts.DurationBuckets)
+	c.readRows = histogram("query_read_rows", "Rows read by the queries on the server.", c.opts.SizeBuckets)
+	c.readBytes = histogram("query_read_bytes", "Bytes read by the queries on the server.", c.opts.SizeBuckets)
+	c.writtenRows = histogram("query_written_rows", "Rows written by the queries on the server.", c.opts.SizeBuckets)
+	c.writtenBytes = histogram("query_written_bytes", "Bytes written by the queries on the server.", c.opts.SizeBuckets)
+	c.queryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   c.opts.Namespace,
+		Name:        "query_errors_total",
+		Help:        "Failed queries by ClickHouse error name, such as UNKNOWN_TABLE, or \"client\" for errors that are not server errors.",
+		ConstLabels: c.opts.ConstLabels,
+	}, append(queryLabels, "error"))
+	c.holdDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace:   c.opts.Namespace,
+		Name:        "pool_conn_hold_duration_seconds",
+		Help:        "Time connections are held between acquire and release.",
+		ConstLabels: c.opts.ConstLabels,
+		Buckets:     c.opts.DurationBuckets,
+	}, []string{"pool"})
+	c.poolDescs = newPoolDescs(c.opts.Namespace, c.opts.ConstLabels)
+	return c
+}
+
+// Instrument sets the hooks of config to record the queries of the pool and how long its
+// connections are held. name is the pool label of the hold duration and must be the name passed
+// to AddPool. Hooks already set in config are still called.
+func (c *Collector) Instrument(name string, config *chpool.Config) {
+	c.InstrumentConn(config.ConnConfig)
+
+	beforeAcquire := config.BeforeAcquire
+	config.BeforeAcquire = func(ctx context.Context, conn chconn.Conn) bool {
+		if beforeAcquire != nil && !beforeAcquire(ctx, conn) {
+			return false
+		}
+		c.acquired.Store(conn, time.Now())
+		return true
+	}
+	afterRelease := config.AfterRelease
+	config.AfterRelease = func(conn chconn.Conn) bool {
+		if acquired, ok := c.acquired.LoadAndDelete(conn); ok {
+			c.holdDuration.WithLabelValues(name).Observe(time.Since(acquired.(time.Time)).Seconds())
+		}
+		return afterRelease == nil || afterRelease(conn)
+	}
+	// Connections closed while acquired are not released.
+	beforeClose := config.BeforeClose
+	config.BeforeClose = func(conn chconn.Conn) {
+		c.acquired.Delete(conn)
+		if beforeClose != nil {
+			beforeClose(conn)
+		}
+	}
+}
+
+// InstrumentConn sets the OnQueryDone hook of config to record its queries. A hook already set
+// in config is still called.
+func (c *Collector) InstrumentConn(config *chconn.Config) {
+	onQueryDone := config.OnQueryDone
+	config.OnQueryDone = func(conn chconn.Conn, done *chconn.QueryDone) {
+		c.observeQuery(conn, done)
+		if onQueryDone != nil {
+			onQueryDone(conn, done)
+		}
+	}
+}
+
+// AddPool adds a pool whose statistics are exported with the pool label name.
+func (c *Collector) AddPool(name string, pool chpool.Pool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pools[name] = pool
+}
+
+// RemovePool removes the pool added with name, e.g. when it is closed.
+func (c *Collector) RemovePool(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pools, name)
+}
+
+func (c *Collector) observeQuery(conn chconn.Conn, done *chconn.QueryDone) {
+	kind := "select"
+	if done.Insert {
+		kind = "insert"
+	}
+	labels := []string{kind}
+	if c.opts.QueryLabelValues != nil {
+		labels = append(labels, c.opts.QueryLabelValues(conn, done)...)
+	}
+
+	c.queryDuration.WithLabelValues(labels...).Observe(done.Duration.Seconds())
+	if done.Stats != nil {
+		c.readRows.WithLabelValues(labels...).Observe(float64(done.Stats.ReadRows))
+		c.readBytes.WithLabelValues(labels...).Observe(float64(done.Stats.ReadBytes))
+		if done.Insert || done.Stats.WrittenRows > 0 {
+			c.writtenRows.WithLabelValues(labels...).Observe(float64(done.Stats.WrittenRows))
+			c.writtenBytes.WithLabelValues(labels...).Observe(float64(done.Stats.WrittenBytes))
+		}
+	}
+	if done.Err != nil {
+		name := "client"
+		var chErr *chconn.ChError
+		if errors.As(done.Err, &chErr) {
+			name = chErr.Code.String()
+		}
+		c.queryErrors.WithLabelValues(append(labels, name)...).Inc()
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.queryDuration.Describe(ch)
+	c.readRows.Describe(ch)
+	c.readBytes.Describe(ch)
+	c.writtenRows.Describe(ch)
+	c.writtenBytes.Describe(ch)
+	c.queryErrors.Describe(ch)
+	c.holdDuration.Describe(ch)
+	c.poolDescs.describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.queryDuration.Collect(ch)
+	c.readRows.Collect(ch)
+	c.readBytes.Collect(ch)
+	c.writtenRows.Collect(ch)
+	c.writtenBytes.Collect(ch)
+	c.queryErrors.Collect(ch)
+	c.holdDuration.Collect(ch)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name, pool := range c.pools {
+		c.poolDescs.collect(ch, name, pool.Stat())
+	}
+}
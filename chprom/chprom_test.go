@@ -0,0 +1,103 @@
+package chprom
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vahid-sohrabloo/chconn/v3"
+	"github.com/vahid-sohrabloo/chconn/v3/chpool"
+)
+
+func TestCollectorQueries(t *testing.T) {
+	t.Parallel()
+
+	c := NewCollector(&Options{
+		QueryLabels: []string{"table"},
+		QueryLabelValues: func(_ chconn.Conn, done *chconn.QueryDone) []string {
+			return []string{strings.Fields(done.Query)[3]}
+		},
+	})
+	config, err := chconn.ParseConfig("")
+	require.NoError(t, err)
+	var called int
+	config.OnQueryDone = func(chconn.Conn, *chconn.QueryDone) { called++ }
+	c.InstrumentConn(config)
+
+	config.OnQueryDone(nil, &chconn.QueryDone{
+		Query:    "SELECT * FROM events",
+		Duration: 20 * time.Millisecond,
+		Stats:    &chconn.QueryStats{ReadRows: 100, ReadBytes: 800},
+	})
+	config.OnQueryDone(nil, &chconn.QueryDone{
+		Query:    "INSERT INTO INTO events",
+		Insert:   true,
+		Duration: time.Second,
+		Stats:    &chconn.QueryStats{WrittenRows: 10, WrittenBytes: 80},
+	})
+	config.OnQueryDone(nil, &chconn.QueryDone{
+		Query: "SELECT * FROM missing",
+		Err:   &chconn.ChError{Code: chconn.ChErrorUnknownTable},
+	})
+	config.OnQueryDone(nil, &chconn.QueryDone{
+		Query: "SELECT * FROM events",
+		Err:   errors.New("broken pipe"),
+	})
+	assert.Equal(t, 4, called)
+
+	assert.Equal(t, 3, testutil.CollectAndCount(c, "chconn_query_duration_seconds"))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.queryErrors.WithLabelValues("select", "missing", "UNKNOWN_TABLE")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.queryErrors.WithLabelValues("select", "events", "client")))
+	require.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP chconn_query_written_rows Rows written by the queries on the server.
+# TYPE chconn_query_written_rows histogram
+chconn_query_written_rows_bucket{kind="insert",table="events",le="1"} 0
+chconn_query_written_rows_bucket{kind="insert",table="events",le="10"} 1
+chconn_query_written_rows_bucket{kind="insert",table="events",le="100"} 1
+chconn_query_written_rows_bucket{kind="insert",table="events",le="1000"} 1
+chconn_query_written_rows_bucket{kind="insert",table="events",le="10000"} 1
+chconn_query_written_rows_bucket{kind="insert",table="events",le="100000"} 1
+chconn_query_written_rows_bucket{kind="insert",table="events",le="1e+06"} 1
+chconn_query_written_rows_bucket{kind="insert",table="events",le="1e+07"} 1
+chconn_query_written_rows_bucket{kind="insert",table="events",le="1e+08"} 1
+chconn_query_written_rows_bucket{kind="insert",table="events",le="1e+09"} 1
+chconn_query_written_rows_bucket{kind="insert",table="events",le="1e+10"} 1
+chconn_query_written_rows_bucket{kind="insert",table="events",le="+Inf"} 1
+chconn_query_written_rows_sum{kind="insert",table="events"} 10
+chconn_query_written_rows_count{kind="insert",table="events"} 1
+`), "chconn_query_written_rows"))
+}
+
+func TestCollectorPool(t *testing.T) {
+	t.Parallel()
+
+	config, err := chpool.ParseConfig(os.Getenv("CHX_TEST_TCP_CONN_STRING"))
+	require.NoError(t, err)
+	c := NewCollector(nil)
+	c.Instrument("main", config)
+	pool, err := chpool.NewWithConfig(config)
+	require.NoError(t, err)
+	defer pool.Close()
+	c.AddPool("main", pool)
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(c))
+
+	require.NoError(t, pool.Exec(context.Background(), "SELECT 1"))
+	// AfterRelease is called asynchronously.
+	assert.Eventually(t, func() bool {
+		return testutil.CollectAndCount(c, "chconn_pool_conn_hold_duration_seconds") == 1
+	}, time.Second, 10*time.Millisecond)
+
+	count, err := testutil.GatherAndCount(registry, "chconn_pool_acquires_total", "chconn_query_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
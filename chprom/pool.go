@@ -0,0 +1,74 @@
+package chprom
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vahid-sohrabloo/chconn/v3/chpool"
+)
+
+// poolDescs are the descriptions of the metrics of chpool.Stat.
+type poolDescs struct {
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	newConnsCount        *prometheus.Desc
+	lifetimeDestroyCount *prometheus.Desc
+	idleDestroyCount     *prometheus.Desc
+	acquiredConns        *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	idleConns            *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+}
+
+func newPoolDescs(namespace string, constLabels prometheus.Labels) poolDescs {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "pool", name), help, []string{"pool"}, constLabels)
+	}
+	return poolDescs{
+		acquireCount:         desc("acquires_total", "Successful acquires from the pool."),
+		acquireDuration:      desc("acquire_duration_seconds_total", "Total duration of the successful acquires."),
+		canceledAcquireCount: desc("canceled_acquires_total", "Acquires canceled by a context."),
+		emptyAcquireCount:    desc("empty_acquires_total", "Acquires that waited because the pool was empty."),
+		newConnsCount:        desc("new_conns_total", "Connections opened by the pool."),
+		lifetimeDestroyCount: desc("max_lifetime_destroys_total", "Connections closed because of MaxConnLifetime."),
+		idleDestroyCount:     desc("max_idle_destroys_total", "Connections closed because of MaxConnIdleTime."),
+		acquiredConns:        desc("acquired_conns", "Currently acquired connections."),
+		constructingConns:    desc("constructing_conns", "Connections being opened."),
+		idleConns:            desc("idle_conns", "Currently idle connections."),
+		totalConns:           desc("total_conns", "Connections in the pool."),
+		maxConns:             desc("max_conns", "Maximum size of the pool."),
+	}
+}
+
+func (d *poolDescs) describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		d.acquireCount, d.acquireDuration, d.canceledAcquireCount, d.emptyAcquireCount, d.newConnsCount,
+		d.lifetimeDestroyCount, d.idleDestroyCount, d.acquiredConns, d.constructingConns, d.idleConns,
+		d.totalConns, d.maxConns,
+	} {
+		ch <- desc
+	}
+}
+
+func (d *poolDescs) collect(ch chan<- prometheus.Metric, name string, s *chpool.Stat) {
+	counter := func(desc *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, v, name)
+	}
+	gauge := func(desc *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v, name)
+	}
+	counter(d.acquireCount, float64(s.AcquireCount()))
+	counter(d.acquireDuration, s.AcquireDuration().Seconds())
+	counter(d.canceledAcquireCount, float64(s.CanceledAcquireCount()))
+	counter(d.emptyAcquireCount, float64(s.EmptyAcquireCount()))
+	counter(d.newConnsCount, float64(s.NewConnsCount()))
+	counter(d.lifetimeDestroyCount, float64(s.MaxLifetimeDestroyCount()))
+	counter(d.idleDestroyCount, float64(s.MaxIdleDestroyCount()))
+	gauge(d.acquiredConns, float64(s.AcquiredConns()))
+	gauge(d.constructingConns, float64(s.ConstructingConns()))
+	gauge(d.idleConns, float64(s.IdleConns()))
+	gauge(d.totalConns, float64(s.TotalConns()))
+	gauge(d.maxConns, float64(s.MaxConns()))
+}
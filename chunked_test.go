@@ -0,0 +1,82 @@
+package chconn
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+func TestNegotiateChunked(t *testing.T) {
+	tests := []struct {
+		client, server ChunkedMode
+		want           bool
+		wantErr        string
+	}{
+		{client: "", server: NotChunkedOptional, want: false},
+		{client: "", server: Chunked, want: true},
+		{client: "", server: NotChunked, want: false},
+		{client: Chunked, server: NotChunkedOptional, want: true},
+		{client: ChunkedOptional, server: NotChunked, want: false},
+		{client: NotChunkedOptional, server: ChunkedOptional, want: false},
+		{client: Chunked, server: Chunked, want: true},
+		{client: Chunked, server: "", want: true},
+		{client: NotChunked, server: Chunked, wantErr: "incompatible protocol: send is notchunked, server requires chunked"},
+		{client: Chunked, server: NotChunked, wantErr: "incompatible protocol: send is chunked, server requires notchunked"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.client)+"/"+string(tt.server), func(t *testing.T) {
+			got, err := negotiateChunked(tt.client, tt.server, "send")
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConnectChunked(t *testing.T) {
+	t.Parallel()
+
+	for _, compress := range []CompressMethod{CompressNone, CompressLZ4} {
+		config, err := ParseConfig(os.Getenv("CHX_TEST_TCP_CONN_STRING"))
+		require.NoError(t, err)
+		config.ChunkedSend = Chunked
+		config.ChunkedRecv = Chunked
+		config.Compress = compress
+
+		ctx := context.Background()
+		c, err := ConnectConfig(ctx, config)
+		require.NoError(t, err)
+		require.NotNil(t, c.(*conn).chunkedWriter)
+
+		require.NoError(t, c.Ping(ctx))
+
+		require.NoError(t, c.Exec(ctx, "DROP TABLE IF EXISTS test_chunked"))
+		require.NoError(t, c.Exec(ctx, "CREATE TABLE test_chunked (id UInt64, name String) ENGINE = Memory"))
+
+		colID := column.New[uint64]()
+		colName := column.NewString()
+		for i := range 1000 {
+			colID.Append(uint64(i))
+			colName.Append("name")
+		}
+		require.NoError(t, c.Insert(ctx, "INSERT INTO test_chunked (id, name) VALUES", colID, colName))
+
+		sum, err := QueryOne[uint64](ctx, c, "SELECT sum(id) FROM test_chunked WHERE name = {name:String}",
+			StringParameter("name", "name"))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(999*1000/2), sum)
+
+		ids, err := QueryAll[uint64](ctx, c, "SELECT number FROM system.numbers LIMIT 100000")
+		require.NoError(t, err)
+		assert.Len(t, ids, 100000)
+
+		require.NoError(t, c.Close())
+	}
+}
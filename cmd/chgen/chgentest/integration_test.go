@@ -123,7 +123,7 @@ func TestIntegration_InsertAndSelect(t *testing.T) {
 	cols := NewTestModelColumns()
 	cols.Write(&inputFull)
 	cols.Write(&inputNullNil)
-	err = conn.Insert(ctx, cols.InsertQuery("test_chgen"), cols.Columns()...)
+	err = conn.Insert(ctx, cols.InsertQuery("test_chgen"), cols.InsertColumns()...)
 	require.NoError(t, err)
 
 	// SELECT
@@ -256,7 +256,7 @@ func TestIntegration_SelectMultipleBlocks(t *testing.T) {
 			OptionalMeta:   nil,
 		})
 	}
-	err = conn.Insert(ctx, cols.InsertQuery("test_chgen_blocks"), cols.Columns()...)
+	err = conn.Insert(ctx, cols.InsertQuery("test_chgen_blocks"), cols.InsertColumns()...)
 	require.NoError(t, err)
 
 	// Read back and count
@@ -270,4 +270,14 @@ func TestIntegration_SelectMultipleBlocks(t *testing.T) {
 		count++
 	}
 	assert.Equal(t, rowCount, count)
+
+	// Read back with the generated row scanner
+	count = 0
+	for m, err := range chconn.QueryIterWith(ctx, conn, RowToTestModel, "SELECT * FROM test_chgen_blocks ORDER BY id") {
+		require.NoError(t, err)
+		require.Equal(t, uint64(count), m.ID)
+		require.Equal(t, uint32(now.Unix()), m.UpdatedAt)
+		count++
+	}
+	assert.Equal(t, rowCount, count)
 }
@@ -16,7 +16,7 @@ const (
 
 // TestModel is a representative model with common ClickHouse column types.
 //
-//go:generate go tool chgen columns --input model.go
+//go:generate go tool chgen columns --input model.go --with-scan
 type TestModel struct {
 	// Primitives
 	ID       uint64  `db:"id" chtype:"UInt64"`
@@ -3,6 +3,9 @@
 package chgentest
 
 import (
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v3"
 	"github.com/vahid-sohrabloo/chconn/v3/column"
 	"github.com/vahid-sohrabloo/chconn/v3/types"
 )
@@ -85,7 +88,7 @@ func NewTestModelColumns() *TestModelColumns {
 	return t
 }
 
-// Columns returns the list of ColumnCore for use with SelectStmt.
+// Columns returns the list of ColumnCore for use with SelectStmt. Use InsertColumns with Insert.
 func (t *TestModelColumns) Columns() []column.ColumnCore {
 	return []column.ColumnCore{
 		t.ID,
@@ -113,6 +116,34 @@ func (t *TestModelColumns) Columns() []column.ColumnCore {
 	}
 }
 
+// InsertColumns returns the list of ColumnCore for use with Insert and InsertQuery, without MATERIALIZED and ALIAS columns.
+func (t *TestModelColumns) InsertColumns() []column.ColumnCore {
+	return []column.ColumnCore{
+		t.ID,
+		t.Name,
+		t.Score,
+		t.Active,
+		t.SmallNum,
+		t.Category,
+		t.NullScore,
+		t.NullName,
+		t.CreatedAt,
+		t.UpdatedAt,
+		t.CountryCode,
+		t.LangCode,
+		t.Tags,
+		t.Metadata,
+		t.Status,
+		t.UUID,
+		t.Location,
+		t.DeletedAt,
+		t.OptionalScores,
+		t.TagGroups,
+		t.NullCategory,
+		t.OptionalMeta,
+	}
+}
+
 // Write appends a single TestModel row to all columns.
 func (t *TestModelColumns) Write(m *TestModel) {
 	t.ID.Append(m.ID)
@@ -228,3 +259,207 @@ func (t *TestModelColumns) ColumnNames() string {
 func (t *TestModelColumns) InsertQuery(table string) string {
 	return "INSERT INTO " + table + " (id, name, score, active, small_num, category, null_score, null_name, created_at, updated_at, country_code, lang_code, tags, metadata, status, uuid, location, deleted_at, optional_scores, tag_groups, null_category, optional_meta) VALUES"
 }
+
+// testModelScan holds the result columns of a query resolved for TestModel.ScanRow.
+type testModelScan struct {
+	idx               []int
+	colID             column.RowReader[uint64]
+	colName           column.RowReader[string]
+	colScore          column.RowReader[float64]
+	colActive         column.RowReader[bool]
+	colSmallNum       column.RowReader[int8]
+	colCategory       column.RowReader[string]
+	colNullScore      column.NullableRowReader[*int64]
+	colNullName       column.NullableRowReader[*string]
+	colCreatedAt      column.RowReader[time.Time]
+	colUpdatedAt      *column.Date[types.DateTime]
+	colCountryCode    column.RowReader[[2]byte]
+	colLangCode       column.RowReader[[2]byte]
+	colTags           column.RowReader[[]string]
+	colStatus         column.RowReader[int8]
+	colUUID           column.RowReader[types.UUID]
+	colLocation       column.RowReader[types.Point]
+	colDeletedAt      column.NullableRowReader[*time.Time]
+	colOptionalScores column.NullableRowReader[[]*int64]
+	colTagGroups      column.RowReader[[][]string]
+	colNullCategory   column.NullableRowReader[*string]
+}
+
+var testModelScanLayout = chconn.NewRowLayout(func(cols []column.ColumnCore) (*testModelScan, error) {
+	idx, err := chconn.LookupColumns(cols,
+		"id",
+		"name",
+		"score",
+		"active",
+		"small_num",
+		"category",
+		"null_score",
+		"null_name",
+		"created_at",
+		"updated_at",
+		"country_code",
+		"lang_code",
+		"tags",
+		"metadata",
+		"status",
+		"uuid",
+		"location",
+		"deleted_at",
+		"optional_scores",
+		"tag_groups",
+		"null_category",
+		"optional_meta",
+	)
+	if err != nil {
+		return nil, err
+	}
+	s := &testModelScan{idx: idx}
+	s.colID, _ = cols[idx[0]].(column.RowReader[uint64])
+	s.colName, _ = cols[idx[1]].(column.RowReader[string])
+	s.colScore, _ = cols[idx[2]].(column.RowReader[float64])
+	s.colActive, _ = cols[idx[3]].(column.RowReader[bool])
+	s.colSmallNum, _ = cols[idx[4]].(column.RowReader[int8])
+	s.colCategory, _ = cols[idx[5]].(column.RowReader[string])
+	s.colNullScore, _ = cols[idx[6]].(column.NullableRowReader[*int64])
+	s.colNullName, _ = cols[idx[7]].(column.NullableRowReader[*string])
+	s.colCreatedAt, _ = cols[idx[8]].(column.RowReader[time.Time])
+	s.colUpdatedAt, _ = cols[idx[9]].(*column.Date[types.DateTime])
+	s.colCountryCode, _ = cols[idx[10]].(column.RowReader[[2]byte])
+	s.colLangCode, _ = cols[idx[11]].(column.RowReader[[2]byte])
+	s.colTags, _ = cols[idx[12]].(column.RowReader[[]string])
+	s.colStatus, _ = cols[idx[14]].(column.RowReader[int8])
+	s.colUUID, _ = cols[idx[15]].(column.RowReader[types.UUID])
+	s.colLocation, _ = cols[idx[16]].(column.RowReader[types.Point])
+	s.colDeletedAt, _ = cols[idx[17]].(column.NullableRowReader[*time.Time])
+	s.colOptionalScores, _ = cols[idx[18]].(column.NullableRowReader[[]*int64])
+	s.colTagGroups, _ = cols[idx[19]].(column.RowReader[[][]string])
+	s.colNullCategory, _ = cols[idx[20]].(column.NullableRowReader[*string])
+	return s, nil
+})
+
+// ScanRow scans the current row of rows into m.
+func (m *TestModel) ScanRow(rows chconn.CollectableRow) error {
+	s, err := testModelScanLayout.Get(rows)
+	if err != nil {
+		return err
+	}
+	row, err := chconn.CurrentRow(rows)
+	if err != nil {
+		return err
+	}
+	cols := rows.Columns()
+	if s.colID != nil {
+		m.ID = s.colID.Row(row)
+	} else if err = cols[s.idx[0]].Scan(row, &m.ID); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[0], Err: err}
+	}
+	if s.colName != nil {
+		m.Name = s.colName.Row(row)
+	} else if err = cols[s.idx[1]].Scan(row, &m.Name); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[1], Err: err}
+	}
+	if s.colScore != nil {
+		m.Score = s.colScore.Row(row)
+	} else if err = cols[s.idx[2]].Scan(row, &m.Score); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[2], Err: err}
+	}
+	if s.colActive != nil {
+		m.Active = s.colActive.Row(row)
+	} else if err = cols[s.idx[3]].Scan(row, &m.Active); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[3], Err: err}
+	}
+	if s.colSmallNum != nil {
+		m.SmallNum = s.colSmallNum.Row(row)
+	} else if err = cols[s.idx[4]].Scan(row, &m.SmallNum); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[4], Err: err}
+	}
+	if s.colCategory != nil {
+		m.Category = s.colCategory.Row(row)
+	} else if err = cols[s.idx[5]].Scan(row, &m.Category); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[5], Err: err}
+	}
+	if s.colNullScore != nil {
+		m.NullScore = s.colNullScore.RowP(row)
+	} else if err = cols[s.idx[6]].Scan(row, &m.NullScore); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[6], Err: err}
+	}
+	if s.colNullName != nil {
+		m.NullName = s.colNullName.RowP(row)
+	} else if err = cols[s.idx[7]].Scan(row, &m.NullName); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[7], Err: err}
+	}
+	if s.colCreatedAt != nil {
+		m.CreatedAt = s.colCreatedAt.Row(row)
+	} else if err = cols[s.idx[8]].Scan(row, &m.CreatedAt); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[8], Err: err}
+	}
+	if s.colUpdatedAt != nil {
+		m.UpdatedAt = uint32(s.colUpdatedAt.Base.Row(row))
+	} else if err = cols[s.idx[9]].Scan(row, &m.UpdatedAt); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[9], Err: err}
+	}
+	if s.colCountryCode != nil {
+		m.CountryCode = s.colCountryCode.Row(row)
+	} else if err = cols[s.idx[10]].Scan(row, &m.CountryCode); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[10], Err: err}
+	}
+	if s.colLangCode != nil {
+		m.LangCode = s.colLangCode.Row(row)
+	} else if err = cols[s.idx[11]].Scan(row, &m.LangCode); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[11], Err: err}
+	}
+	if s.colTags != nil {
+		m.Tags = s.colTags.Row(row)
+	} else if err = cols[s.idx[12]].Scan(row, &m.Tags); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[12], Err: err}
+	}
+	if err = cols[s.idx[13]].Scan(row, &m.Metadata); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[13], Err: err}
+	}
+	if s.colStatus != nil {
+		m.Status = TestModelStatus(s.colStatus.Row(row))
+	} else if err = cols[s.idx[14]].Scan(row, &m.Status); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[14], Err: err}
+	}
+	if s.colUUID != nil {
+		m.UUID = s.colUUID.Row(row)
+	} else if err = cols[s.idx[15]].Scan(row, &m.UUID); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[15], Err: err}
+	}
+	if s.colLocation != nil {
+		m.Location = s.colLocation.Row(row)
+	} else if err = cols[s.idx[16]].Scan(row, &m.Location); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[16], Err: err}
+	}
+	if s.colDeletedAt != nil {
+		m.DeletedAt = s.colDeletedAt.RowP(row)
+	} else if err = cols[s.idx[17]].Scan(row, &m.DeletedAt); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[17], Err: err}
+	}
+	if s.colOptionalScores != nil {
+		m.OptionalScores = s.colOptionalScores.RowP(row)
+	} else if err = cols[s.idx[18]].Scan(row, &m.OptionalScores); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[18], Err: err}
+	}
+	if s.colTagGroups != nil {
+		m.TagGroups = s.colTagGroups.Row(row)
+	} else if err = cols[s.idx[19]].Scan(row, &m.TagGroups); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[19], Err: err}
+	}
+	if s.colNullCategory != nil {
+		m.NullCategory = s.colNullCategory.RowP(row)
+	} else if err = cols[s.idx[20]].Scan(row, &m.NullCategory); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[20], Err: err}
+	}
+	if err = cols[s.idx[21]].Scan(row, &m.OptionalMeta); err != nil {
+		return chconn.ScanArgError{ColumnIndex: s.idx[21], Err: err}
+	}
+	return nil
+}
+
+// RowToTestModel returns a TestModel scanned from row. It can be used with CollectRows and QueryIterWith.
+func RowToTestModel(row chconn.CollectableRow) (TestModel, error) {
+	var m TestModel
+	err := m.ScanRow(row)
+	return m, err
+}
@@ -0,0 +1,123 @@
+package chgentest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+	"github.com/vahid-sohrabloo/chconn/v3/format"
+	"github.com/vahid-sohrabloo/chconn/v3/types"
+)
+
+// blockRows is a CollectableRow over columns decoded from a Native block,
+// which are created by type the same way as the columns of a query result.
+type blockRows struct {
+	columns []column.ColumnCore
+	row     int
+}
+
+func (r *blockRows) Scan(dest ...any) error {
+	for i, d := range dest {
+		if err := r.columns[i].Scan(r.row, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *blockRows) Columns() []column.ColumnCore { return r.columns }
+
+func (r *blockRows) CurrentRow() int { return r.row }
+
+func readTestModelBlock(t *testing.T, rows ...TestModel) []column.ColumnCore {
+	t.Helper()
+	cols := NewTestModelColumns()
+	for i := range rows {
+		cols.Write(&rows[i])
+	}
+	// Take the column types from the table definition used by the integration tests.
+	chTypes := map[string]string{}
+	for _, line := range strings.Split(createTableSQL, "\n")[1:] {
+		name, chType, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if ok && !strings.HasPrefix(name, ")") {
+			chTypes[name] = strings.TrimSuffix(chType, ",")
+		}
+	}
+	for _, col := range cols.Columns() {
+		col.SetType([]byte(chTypes[string(col.Name())]))
+	}
+	var buf bytes.Buffer
+	require.NoError(t, format.NewNativeWriter(&buf).WriteBlock(cols.Columns()...))
+	columns, err := format.NewNativeReader().ReadBlock(&buf, nil)
+	require.NoError(t, err)
+	return columns
+}
+
+func TestRowToTestModel(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	deletedAt := now.Add(-time.Hour)
+	input := []TestModel{
+		{
+			ID:             42,
+			Name:           "hello",
+			Score:          3.14,
+			Active:         true,
+			SmallNum:       -7,
+			Category:       "test",
+			NullScore:      ptr(int64(123)),
+			NullName:       ptr("world"),
+			CreatedAt:      now,
+			UpdatedAt:      uint32(now.Unix()),
+			CountryCode:    [2]byte{'U', 'S'},
+			LangCode:       [2]byte{'e', 'n'},
+			Tags:           []string{"go", "clickhouse"},
+			Metadata:       map[string]string{"key": "value"},
+			Status:         TestModelStatusActive,
+			UUID:           types.UUID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			Location:       types.Point{Col1: 1.5, Col2: 2.5},
+			DeletedAt:      &deletedAt,
+			OptionalScores: []*int64{ptr(int64(10)), nil, ptr(int64(30))},
+			TagGroups:      [][]string{{"a", "b"}, {"c"}},
+			NullCategory:   ptr("special"),
+			OptionalMeta:   map[string]*int64{"x": ptr(int64(1)), "y": nil},
+		},
+		{
+			ID:        43,
+			Name:      "nil_test",
+			CreatedAt: now,
+			UpdatedAt: uint32(now.Unix()),
+			// Maps are decoded as empty, not nil.
+			Metadata:     map[string]string{},
+			Status:       TestModelStatusInactive,
+			OptionalMeta: map[string]*int64{},
+		},
+	}
+
+	rows := &blockRows{columns: readTestModelBlock(t, input...)}
+	for i := range input {
+		rows.row = i
+		got, err := RowToTestModel(rows)
+		require.NoError(t, err)
+
+		got.CreatedAt = got.CreatedAt.UTC()
+		if got.DeletedAt != nil {
+			*got.DeletedAt = got.DeletedAt.UTC()
+		}
+		assert.Equal(t, input[i], got)
+	}
+}
+
+func TestRowToTestModel_MissingColumn(t *testing.T) {
+	t.Parallel()
+
+	columns := readTestModelBlock(t, TestModel{ID: 1, NullCategory: ptr("a")})
+	rows := &blockRows{columns: columns[1:]}
+	_, err := RowToTestModel(rows)
+	require.EqualError(t, err, "cannot find field id in returned row")
+}
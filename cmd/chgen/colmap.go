@@ -27,6 +27,7 @@ type colInfo struct {
 	isNested         bool
 	subColumns       []tupleSubCol // sub-column info for Tuple/Nested
 	goType           string        // the Go struct type name (for Tuple: "Address", for Nested: "Phone")
+	enumBase         string        // underlying integer type of Enum8/Enum16 columns ("int8" or "int16")
 }
 
 // fixedStringRe matches [N]byte Go types.
@@ -441,11 +442,16 @@ func baseColMapping(goType string, chType string) (colInfo, error) { //nolint:go
 	// Enum types: Enum8(...) or Enum16(...)
 	if strings.HasPrefix(chType, "Enum8(") || strings.HasPrefix(chType, "Enum16(") {
 		// Any custom Go type maps to Base[GoType]
+		enumBase := "int8"
+		if strings.HasPrefix(chType, "Enum16(") {
+			enumBase = "int16"
+		}
 		return colInfo{
 			fieldType:    fmt.Sprintf("*column.Base[%s]", goType),
 			constructor:  fmt.Sprintf("column.New[%s]()", goType),
 			appendMethod: "Append",
 			rowMethod:    "Row",
+			enumBase:     enumBase,
 		}, nil
 	}
 
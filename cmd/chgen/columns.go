@@ -20,6 +20,7 @@ import (
 type columnsConfig struct {
 	input    string
 	withIter bool
+	withScan bool
 }
 
 func runColumns(args []string) error {
@@ -27,6 +28,7 @@ func runColumns(args []string) error {
 	fs := flag.NewFlagSet("columns", flag.ExitOnError)
 	fs.StringVar(&cfg.input, "input", "", "Input Go file (default: $GOFILE)")
 	fs.BoolVar(&cfg.withIter, "with-iter", false, "Generate Iter() method")
+	fs.BoolVar(&cfg.withScan, "with-scan", false, "Generate ScanRow() method and RowTo<Model> function")
 
 	if err := fs.Parse(args); err != nil {
 		return err
@@ -42,16 +44,17 @@ func runColumns(args []string) error {
 	base := strings.TrimSuffix(cfg.input, filepath.Ext(cfg.input))
 	outFile := base + "_columns_gen.go"
 
-	return generateColumns(cfg.input, outFile, cfg.withIter)
+	return generateColumns(cfg.input, outFile, cfg.withIter, cfg.withScan)
 }
 
 // fieldInfo describes a single struct field with db/chtype tags.
 type fieldInfo struct {
-	Name   string
-	GoType string
-	DBName string
-	ChType string
-	Col    colInfo
+	Name     string
+	GoType   string
+	DBName   string
+	ChType   string
+	Col      colInfo
+	ReadOnly bool // MATERIALIZED or ALIAS column (chdefault tag), skipped on insert
 }
 
 // structInfo describes a struct with tagged fields.
@@ -219,7 +222,7 @@ func resolveTupleSubColumns(pkg *packages.Package, fset *token.FileSet, parentFi
 }
 
 // generateColumns parses inputFile, finds tagged structs, and writes generated code to outFile.
-func generateColumns(inputFile, outFile string, withIter bool) error { //nolint:gocyclo
+func generateColumns(inputFile, outFile string, withIter, withScan bool) error { //nolint:gocyclo
 	absInput, err := filepath.Abs(inputFile)
 	if err != nil {
 		return fmt.Errorf("resolving input path: %w", err)
@@ -312,12 +315,14 @@ func generateColumns(inputFile, outFile string, withIter bool) error { //nolint:
 				ci.subColumns = subs
 			}
 
+			chDefault := tag.Get("chdefault")
 			si.Fields = append(si.Fields, fieldInfo{
-				Name:   fieldName,
-				GoType: goType,
-				DBName: dbName,
-				ChType: chType,
-				Col:    ci,
+				Name:     fieldName,
+				GoType:   goType,
+				DBName:   dbName,
+				ChType:   chType,
+				Col:      ci,
+				ReadOnly: chDefault == "MATERIALIZED" || chDefault == "ALIAS",
 			})
 		}
 
@@ -338,6 +343,9 @@ func generateColumns(inputFile, outFile string, withIter bool) error { //nolint:
 
 	for _, s := range structs {
 		writeColumnsStruct(&buf, s, withIter)
+		if withScan {
+			writeScanRow(&buf, s)
+		}
 	}
 
 	// Run goimports on the output
@@ -361,6 +369,17 @@ func columnNamesList(s structInfo) string {
 	return strings.Join(names, ", ")
 }
 
+// insertColumnNamesList is like columnNamesList without the read-only columns.
+func insertColumnNamesList(s structInfo) string {
+	var names []string
+	for _, f := range s.Fields { //nolint:gocritic
+		if !f.ReadOnly {
+			names = append(names, f.DBName)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
 func writeColumnsStruct(buf *bytes.Buffer, s structInfo, withIter bool) { //nolint:gocyclo,funlen
 	name := s.Name
 	colsName := name + "Columns"
@@ -453,7 +472,7 @@ func writeColumnsStruct(buf *bytes.Buffer, s structInfo, withIter bool) { //noli
 	fmt.Fprintf(buf, "}\n\n")
 
 	// Columns() method — returns Tuple/ArrayBase, not sub-columns
-	fmt.Fprintf(buf, "// Columns returns the list of ColumnCore for use with SelectStmt.\n")
+	fmt.Fprintf(buf, "// Columns returns the list of ColumnCore for use with SelectStmt. Use InsertColumns with Insert.\n")
 	fmt.Fprintf(buf, "func (t *%s) Columns() []column.ColumnCore {\n", colsName)
 	fmt.Fprintf(buf, "\treturn []column.ColumnCore{\n")
 	for _, f := range s.Fields { //nolint:gocritic
@@ -462,10 +481,25 @@ func writeColumnsStruct(buf *bytes.Buffer, s structInfo, withIter bool) { //noli
 	fmt.Fprintf(buf, "\t}\n")
 	fmt.Fprintf(buf, "}\n\n")
 
+	// InsertColumns() method — the columns Write fills, matching InsertQuery
+	fmt.Fprintf(buf, "// InsertColumns returns the list of ColumnCore for use with Insert and InsertQuery, without MATERIALIZED and ALIAS columns.\n")
+	fmt.Fprintf(buf, "func (t *%s) InsertColumns() []column.ColumnCore {\n", colsName)
+	fmt.Fprintf(buf, "\treturn []column.ColumnCore{\n")
+	for _, f := range s.Fields { //nolint:gocritic
+		if !f.ReadOnly {
+			fmt.Fprintf(buf, "\t\tt.%s,\n", f.Name)
+		}
+	}
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "}\n\n")
+
 	// Write() method
 	fmt.Fprintf(buf, "// Write appends a single %s row to all columns.\n", name)
 	fmt.Fprintf(buf, "func (t *%s) Write(m *%s) {\n", colsName, name)
 	for _, f := range s.Fields { //nolint:gocritic
+		if f.ReadOnly {
+			continue
+		}
 		if f.Col.isTuple {
 			// Write sub-columns from struct fields
 			for _, sub := range f.Col.subColumns { //nolint:gocritic
@@ -532,7 +566,7 @@ func writeColumnsStruct(buf *bytes.Buffer, s structInfo, withIter bool) { //noli
 	// InsertQuery() method
 	fmt.Fprintf(buf, "// InsertQuery returns the INSERT INTO query for the given table.\n")
 	fmt.Fprintf(buf, "func (t *%s) InsertQuery(table string) string {\n", colsName)
-	fmt.Fprintf(buf, "\treturn \"INSERT INTO \" + table + \" (%s) VALUES\"\n", columnNamesList(s))
+	fmt.Fprintf(buf, "\treturn \"INSERT INTO \" + table + \" (%s) VALUES\"\n", insertColumnNamesList(s))
 	fmt.Fprintf(buf, "}\n\n")
 
 	// Iter() method (optional)
@@ -556,3 +590,101 @@ func writeColumnsStruct(buf *bytes.Buffer, s structInfo, withIter bool) { //noli
 		fmt.Fprintf(buf, "}\n\n")
 	}
 }
+
+// writeScanRow generates a ScanRow method and a RowTo<Model> function that read a row
+// directly from the typed result columns. The position and type of each column is resolved
+// once per result set; fields whose result column has a different type fall back to Scan.
+func writeScanRow(buf *bytes.Buffer, s structInfo) { //nolint:funlen
+	name := s.Name
+	scanName := lowerFirst(name) + "Scan"
+
+	// Layout struct
+	fmt.Fprintf(buf, "// %s holds the result columns of a query resolved for %s.ScanRow.\n", scanName, name)
+	fmt.Fprintf(buf, "type %s struct {\n", scanName)
+	fmt.Fprintf(buf, "\tidx []int\n")
+	for _, f := range s.Fields { //nolint:gocritic
+		if reader, _ := scanReader(f); reader != "" {
+			fmt.Fprintf(buf, "\tcol%s %s\n", f.Name, reader)
+		}
+	}
+	fmt.Fprintf(buf, "}\n\n")
+
+	fmt.Fprintf(buf, "var %sLayout = chconn.NewRowLayout(func(cols []column.ColumnCore) (*%s, error) {\n", scanName, scanName)
+	fmt.Fprintf(buf, "\tidx, err := chconn.LookupColumns(cols,\n")
+	for _, f := range s.Fields { //nolint:gocritic
+		fmt.Fprintf(buf, "\t\t%q,\n", f.DBName)
+	}
+	fmt.Fprintf(buf, "\t)\n")
+	fmt.Fprintf(buf, "\tif err != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, err\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\ts := &%s{idx: idx}\n", scanName)
+	for i, f := range s.Fields { //nolint:gocritic
+		if reader, _ := scanReader(f); reader != "" {
+			fmt.Fprintf(buf, "\ts.col%s, _ = cols[idx[%d]].(%s)\n", f.Name, i, reader)
+		}
+	}
+	fmt.Fprintf(buf, "\treturn s, nil\n")
+	fmt.Fprintf(buf, "})\n\n")
+
+	// ScanRow() method
+	fmt.Fprintf(buf, "// ScanRow scans the current row of rows into m.\n")
+	fmt.Fprintf(buf, "func (m *%s) ScanRow(rows chconn.CollectableRow) error {\n", name)
+	fmt.Fprintf(buf, "\ts, err := %sLayout.Get(rows)\n", scanName)
+	fmt.Fprintf(buf, "\tif err != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn err\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\trow, err := chconn.CurrentRow(rows)\n")
+	fmt.Fprintf(buf, "\tif err != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn err\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tcols := rows.Columns()\n")
+	for i, f := range s.Fields { //nolint:gocritic
+		if reader, value := scanReader(f); reader != "" {
+			fmt.Fprintf(buf, "\tif s.col%s != nil {\n", f.Name)
+			fmt.Fprintf(buf, "\t\tm.%s = %s\n", f.Name, value)
+			fmt.Fprintf(buf, "\t} else if err = cols[s.idx[%d]].Scan(row, &m.%s); err != nil {\n", i, f.Name)
+		} else {
+			fmt.Fprintf(buf, "\tif err = cols[s.idx[%d]].Scan(row, &m.%s); err != nil {\n", i, f.Name)
+		}
+		fmt.Fprintf(buf, "\t\treturn chconn.ScanArgError{ColumnIndex: s.idx[%d], Err: err}\n", i)
+		fmt.Fprintf(buf, "\t}\n")
+	}
+	fmt.Fprintf(buf, "\treturn nil\n")
+	fmt.Fprintf(buf, "}\n\n")
+
+	// RowTo<Model>() function
+	fmt.Fprintf(buf, "// RowTo%s returns a %s scanned from row. It can be used with CollectRows and QueryIterWith.\n", name, name)
+	fmt.Fprintf(buf, "func RowTo%s(row chconn.CollectableRow) (%s, error) {\n", name, name)
+	fmt.Fprintf(buf, "\tvar m %s\n", name)
+	fmt.Fprintf(buf, "\terr := m.ScanRow(row)\n")
+	fmt.Fprintf(buf, "\treturn m, err\n")
+	fmt.Fprintf(buf, "}\n\n")
+}
+
+// scanReader returns the type ScanRow asserts the result column of f to and the expression
+// that reads the field from it, or "" if the field is always read with Scan. Columns created
+// from a result set are untyped for Map, Tuple and Nested, so those fields always use Scan.
+func scanReader(f fieldInfo) (reader, value string) { //nolint:gocritic
+	col := "s.col" + f.Name
+	switch {
+	case f.Col.isTuple, f.Col.isNested, strings.HasPrefix(f.Col.fieldType, "*column.Map"):
+		return "", ""
+	case f.Col.needsStrictFalse:
+		// The server returns a Date column; read its raw value instead of time.Time.
+		if f.Col.isNullable {
+			return "", ""
+		}
+		dateType := "types.DateTime"
+		if f.GoType == "uint16" {
+			dateType = "types.Date"
+		}
+		return fmt.Sprintf("*column.Date[%s]", dateType), fmt.Sprintf("%s(%s.Base.Row(row))", f.GoType, col)
+	case f.Col.enumBase != "":
+		return fmt.Sprintf("column.RowReader[%s]", f.Col.enumBase), fmt.Sprintf("%s(%s.Row(row))", f.GoType, col)
+	case f.Col.rowMethod == "RowP":
+		return fmt.Sprintf("column.NullableRowReader[%s]", f.GoType), col + ".RowP(row)"
+	default:
+		return fmt.Sprintf("column.RowReader[%s]", f.GoType), col + ".Row(row)"
+	}
+}
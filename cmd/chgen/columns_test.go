@@ -12,7 +12,7 @@ func TestColumnsGenerate_AllTypes(t *testing.T) {
 	tmpDir := t.TempDir()
 	outFile := filepath.Join(tmpDir, "all_types_model_columns_gen.go")
 
-	err := generateColumns("testdata/all_types_model.go", outFile, false)
+	err := generateColumns("testdata/all_types_model.go", outFile, false, false)
 	require.NoError(t, err)
 
 	got, err := os.ReadFile(outFile)
@@ -25,7 +25,7 @@ func TestColumnsGenerate_AllTypesWithIter(t *testing.T) {
 	tmpDir := t.TempDir()
 	outFile := filepath.Join(tmpDir, "all_types_model_columns_gen.go")
 
-	err := generateColumns("testdata/all_types_model.go", outFile, true)
+	err := generateColumns("testdata/all_types_model.go", outFile, true, false)
 	require.NoError(t, err)
 
 	got, err := os.ReadFile(outFile)
@@ -34,11 +34,50 @@ func TestColumnsGenerate_AllTypesWithIter(t *testing.T) {
 	testSnapshot(t, "testdata/all_types_model_columns_iter_gen.go.golden", string(got))
 }
 
+func TestColumnsGenerate_AllTypesWithScan(t *testing.T) {
+	tmpDir := t.TempDir()
+	outFile := filepath.Join(tmpDir, "all_types_model_columns_gen.go")
+
+	err := generateColumns("testdata/all_types_model.go", outFile, false, true)
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+
+	testSnapshot(t, "testdata/all_types_model_columns_scan_gen.go.golden", string(got))
+}
+
+func TestColumnsGenerate_TupleWithScan(t *testing.T) {
+	tmpDir := t.TempDir()
+	outFile := filepath.Join(tmpDir, "tuple_model_columns_gen.go")
+
+	err := generateColumns("testdata/tuple_model.go", outFile, false, true)
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+
+	testSnapshot(t, "testdata/tuple_model_columns_scan_gen.go.golden", string(got))
+}
+
+func TestColumnsGenerate_DefaultKind(t *testing.T) {
+	tmpDir := t.TempDir()
+	outFile := filepath.Join(tmpDir, "default_kind_model_columns_gen.go")
+
+	err := generateColumns("testdata/default_kind_model.go", outFile, false, false)
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+
+	testSnapshot(t, "testdata/default_kind_model_columns_gen.go.golden", string(got))
+}
+
 func TestColumnsGenerate_Tuple(t *testing.T) {
 	tmpDir := t.TempDir()
 	outFile := filepath.Join(tmpDir, "tuple_model_columns_gen.go")
 
-	err := generateColumns("testdata/tuple_model.go", outFile, false)
+	err := generateColumns("testdata/tuple_model.go", outFile, false, false)
 	require.NoError(t, err)
 
 	got, err := os.ReadFile(outFile)
@@ -51,7 +90,7 @@ func TestColumnsGenerate_SkipsUntaggedFields(t *testing.T) {
 	tmpDir := t.TempDir()
 	outFile := filepath.Join(tmpDir, "out.go")
 
-	err := generateColumns("testdata/all_types_model.go", outFile, false)
+	err := generateColumns("testdata/all_types_model.go", outFile, false, false)
 	require.NoError(t, err)
 
 	got, err := os.ReadFile(outFile)
@@ -0,0 +1,708 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ddlTokenKind is the kind of a DDL token.
+type ddlTokenKind int
+
+const (
+	ddlWord        ddlTokenKind = iota // unquoted identifier or keyword
+	ddlQuotedIdent                     // `ident` or "ident"
+	ddlString                          // 'string'
+	ddlNumber                          // numeric literal
+	ddlPunct                           // any other single character
+)
+
+// ddlToken is a single token of a DDL statement. For quoted identifiers and
+// strings, text is the unescaped value. start and end are byte offsets in the source.
+type ddlToken struct {
+	kind       ddlTokenKind
+	text       string
+	start, end int
+}
+
+// is reports whether t is the unquoted keyword kw (case-insensitive).
+func (t ddlToken) is(kw string) bool {
+	return t.kind == ddlWord && strings.EqualFold(t.text, kw)
+}
+
+// isPunct reports whether t is the punctuation character p.
+func (t ddlToken) isPunct(p string) bool {
+	return t.kind == ddlPunct && t.text == p
+}
+
+// tokenizeDDL splits src into tokens, skipping whitespace and comments.
+func tokenizeDDL(src string) ([]ddlToken, error) { //nolint:gocyclo,funlen
+	var toks []ddlToken
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+		case c == '-' && strings.HasPrefix(src[i:], "--"), c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '/' && strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment at offset %d", i)
+			}
+			i += end + 4
+		case c == '\'' || c == '`' || c == '"':
+			text, n, err := readQuoted(src[i:], c)
+			if err != nil {
+				return nil, fmt.Errorf("%w at offset %d", err, i)
+			}
+			kind := ddlQuotedIdent
+			if c == '\'' {
+				kind = ddlString
+			}
+			toks = append(toks, ddlToken{kind: kind, text: text, start: i, end: i + n})
+			i += n
+		case isDigit(c):
+			start := i
+			for i < len(src) && (isWordChar(src[i]) || src[i] == '.') {
+				i++
+			}
+			toks = append(toks, ddlToken{kind: ddlNumber, text: src[start:i], start: start, end: i})
+		case isWordChar(c):
+			start := i
+			for i < len(src) && (isWordChar(src[i]) || src[i] == '$') {
+				i++
+			}
+			toks = append(toks, ddlToken{kind: ddlWord, text: src[start:i], start: start, end: i})
+		default:
+			toks = append(toks, ddlToken{kind: ddlPunct, text: src[i : i+1], start: i, end: i + 1})
+			i++
+		}
+	}
+	return toks, nil
+}
+
+// readQuoted reads a quoted token starting with quote q. Both backslash escapes
+// and doubled quotes are supported. It returns the unescaped value and the
+// length of the token in src.
+func readQuoted(src string, q byte) (string, int, error) {
+	var sb strings.Builder
+	for i := 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			if i+1 < len(src) {
+				i++
+				sb.WriteByte(unescapeByte(src[i]))
+			}
+		case q:
+			if i+1 < len(src) && src[i+1] == q {
+				sb.WriteByte(q)
+				i++
+				continue
+			}
+			return sb.String(), i + 1, nil
+		default:
+			sb.WriteByte(src[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated %c quote", q)
+}
+
+func unescapeByte(c byte) byte {
+	switch c {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	case '0':
+		return 0
+	}
+	return c
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+// ddlTable is a table defined by DDL statements.
+type ddlTable struct {
+	database string // empty if the table name was not qualified
+	name     string
+	columns  []columnSchema
+}
+
+// ddlSchema holds the tables defined by a sequence of DDL statements.
+type ddlSchema struct {
+	tables []*ddlTable
+}
+
+// loadDDL parses the CREATE TABLE and ALTER TABLE statements in path. If path
+// is a directory, all *.sql files in it are applied in lexical order, so a
+// directory of migration files resolves to the final schema.
+func loadDDL(path string) (*ddlSchema, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.sql"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+	}
+
+	schema := &ddlSchema{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading sql file: %w", err)
+		}
+		if err := schema.apply(string(data)); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return schema, nil
+}
+
+// apply parses src and applies its statements to the schema. Statements other
+// than CREATE TABLE, ALTER TABLE, DROP TABLE and RENAME TABLE are ignored.
+func (s *ddlSchema) apply(src string) error {
+	toks, err := tokenizeDDL(src)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range splitTopLevel(toks, ";") {
+		if len(stmt) == 0 {
+			continue
+		}
+		p := &ddlParser{src: src, toks: stmt}
+		var err error
+		switch {
+		case p.acceptKeywords("CREATE"):
+			err = s.create(p)
+		case p.acceptKeywords("ALTER", "TABLE"):
+			err = s.alter(p)
+		case p.acceptKeywords("DROP", "TABLE"):
+			err = s.drop(p)
+		case p.acceptKeywords("RENAME", "TABLE"):
+			err = s.rename(p)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineOf(src, stmt[0].start), err)
+		}
+	}
+	return nil
+}
+
+// lookup returns the index of the table database.name. An empty database
+// matches any database, so unqualified and qualified names can be mixed.
+func (s *ddlSchema) lookup(database, name string) int {
+	for i, t := range s.tables {
+		if t.name == name && (database == "" || t.database == "" || t.database == database) {
+			return i
+		}
+	}
+	return -1
+}
+
+// table returns the table named by ref, which is either "table" or "database.table".
+func (s *ddlSchema) table(ref string) (*ddlTable, error) {
+	database, name, ok := strings.Cut(ref, ".")
+	if !ok {
+		database, name = "", ref
+	}
+	if i := s.lookup(database, name); i >= 0 {
+		return s.tables[i], nil
+	}
+	return nil, fmt.Errorf("table %q not found in sql", ref)
+}
+
+// onlyTable returns the single table of the schema.
+func (s *ddlSchema) onlyTable() (*ddlTable, error) {
+	switch len(s.tables) {
+	case 0:
+		return nil, errors.New("could not find CREATE TABLE statement")
+	case 1:
+		return s.tables[0], nil
+	}
+	names := make([]string, len(s.tables))
+	for i, t := range s.tables {
+		names[i] = t.name
+	}
+	return nil, fmt.Errorf("sql defines %d tables (%s), use --table to choose one", len(names), strings.Join(names, ", "))
+}
+
+func (s *ddlSchema) create(p *ddlParser) error {
+	p.acceptKeywords("OR", "REPLACE")
+	p.acceptKeywords("TEMPORARY")
+	if !p.acceptKeywords("TABLE") {
+		return nil // CREATE VIEW, DATABASE, DICTIONARY, ...
+	}
+	p.acceptKeywords("IF", "NOT", "EXISTS")
+	database, name, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	if p.acceptKeywords("UUID") {
+		p.next()
+	}
+	p.skipOnCluster()
+
+	t := &ddlTable{database: database, name: name}
+	switch {
+	case p.peek().isPunct("("):
+		elems, err := p.parenthesized()
+		if err != nil {
+			return err
+		}
+		for _, elem := range splitTopLevel(elems, ",") {
+			if len(elem) == 0 || isTableElementKeyword(elem[0]) {
+				continue
+			}
+			col, err := (&ddlParser{src: p.src, toks: elem}).columnDef(true)
+			if err != nil {
+				return fmt.Errorf("table %s: %w", name, err)
+			}
+			t.columns = append(t.columns, col)
+		}
+	case p.acceptKeywords("AS"):
+		srcDatabase, srcName, err := p.tableName()
+		if err != nil || p.peek().isPunct("(") || strings.EqualFold(srcName, "SELECT") {
+			fmt.Fprintf(os.Stderr, "warning: skipping table %s: columns of AS SELECT and table functions are not resolved\n", name)
+			return nil //nolint:nilerr
+		}
+		i := s.lookup(srcDatabase, srcName)
+		if i < 0 {
+			return fmt.Errorf("table %s: source table %s not found", name, srcName)
+		}
+		t.columns = append(t.columns, s.tables[i].columns...)
+	default:
+		return fmt.Errorf("table %s: expected column list", name)
+	}
+
+	if i := s.lookup(database, name); i >= 0 {
+		s.tables[i] = t
+	} else {
+		s.tables = append(s.tables, t)
+	}
+	return nil
+}
+
+func (s *ddlSchema) alter(p *ddlParser) error { //nolint:gocyclo
+	database, name, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	p.skipOnCluster()
+	i := s.lookup(database, name)
+	if i < 0 {
+		return fmt.Errorf("ALTER TABLE %s: table not found", name)
+	}
+	t := s.tables[i]
+
+	for _, action := range splitTopLevel(p.rest(), ",") {
+		ap := &ddlParser{src: p.src, toks: action}
+		switch {
+		case ap.acceptKeywords("ADD", "COLUMN"):
+			ifNotExists := ap.acceptKeywords("IF", "NOT", "EXISTS")
+			col, err := ap.columnDef(true)
+			if err != nil {
+				return fmt.Errorf("ALTER TABLE %s: %w", name, err)
+			}
+			if t.column(col.Name) >= 0 {
+				if ifNotExists {
+					continue
+				}
+				return fmt.Errorf("ALTER TABLE %s: column %s already exists", name, col.Name)
+			}
+			pos := len(t.columns)
+			if ap.acceptKeywords("FIRST") {
+				pos = 0
+			} else if ap.acceptKeywords("AFTER") {
+				after := ap.next()
+				if pos = t.column(after.text); pos < 0 {
+					return fmt.Errorf("ALTER TABLE %s: column %s not found", name, after.text)
+				}
+				pos++
+			}
+			t.columns = append(t.columns[:pos], append([]columnSchema{col}, t.columns[pos:]...)...)
+		case ap.acceptKeywords("DROP", "COLUMN"):
+			ap.acceptKeywords("IF", "EXISTS")
+			if j := t.column(ap.next().text); j >= 0 {
+				t.columns = append(t.columns[:j], t.columns[j+1:]...)
+			}
+		case ap.acceptKeywords("MODIFY", "COLUMN"):
+			ap.acceptKeywords("IF", "EXISTS")
+			col, err := ap.columnDef(false)
+			if err != nil {
+				return fmt.Errorf("ALTER TABLE %s: %w", name, err)
+			}
+			if j := t.column(col.Name); j >= 0 {
+				t.columns[j] = mergeColumn(t.columns[j], col, ap.removed)
+			}
+		case ap.acceptKeywords("RENAME", "COLUMN"):
+			ap.acceptKeywords("IF", "EXISTS")
+			from := ap.next().text
+			ap.acceptKeywords("TO")
+			if j := t.column(from); j >= 0 {
+				t.columns[j].Name = ap.next().text
+			}
+		case ap.acceptKeywords("COMMENT", "COLUMN"):
+			ap.acceptKeywords("IF", "EXISTS")
+			if j := t.column(ap.next().text); j >= 0 {
+				t.columns[j].Comment = ap.next().text
+			}
+		}
+	}
+	return nil
+}
+
+func (s *ddlSchema) drop(p *ddlParser) error {
+	p.acceptKeywords("IF", "EXISTS")
+	database, name, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	if i := s.lookup(database, name); i >= 0 {
+		s.tables = append(s.tables[:i], s.tables[i+1:]...)
+	}
+	return nil
+}
+
+func (s *ddlSchema) rename(p *ddlParser) error {
+	for _, pair := range splitTopLevel(p.rest(), ",") {
+		pp := &ddlParser{src: p.src, toks: pair}
+		database, name, err := pp.tableName()
+		if err != nil {
+			return err
+		}
+		if !pp.acceptKeywords("TO") {
+			return errors.New("RENAME TABLE: expected TO")
+		}
+		newDatabase, newName, err := pp.tableName()
+		if err != nil {
+			return err
+		}
+		if i := s.lookup(database, name); i >= 0 {
+			s.tables[i].database, s.tables[i].name = newDatabase, newName
+		}
+	}
+	return nil
+}
+
+// column returns the index of the column named name, or -1.
+func (t *ddlTable) column(name string) int {
+	for i, c := range t.columns {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeColumn applies the properties set by an ALTER TABLE MODIFY COLUMN to col.
+// removed is the property dropped by MODIFY COLUMN ... REMOVE, if any.
+func mergeColumn(col, modify columnSchema, removed string) columnSchema { //nolint:gocritic
+	if modify.Type != "" {
+		col.Type = modify.Type
+	}
+	if modify.DefaultKind != "" {
+		col.DefaultKind, col.DefaultExpr = modify.DefaultKind, modify.DefaultExpr
+	}
+	if modify.Codec != "" {
+		col.Codec = modify.Codec
+	}
+	if modify.Comment != "" {
+		col.Comment = modify.Comment
+	}
+	switch removed {
+	case "DEFAULT", "MATERIALIZED", "ALIAS", "EPHEMERAL":
+		col.DefaultKind, col.DefaultExpr = "", ""
+	case "CODEC":
+		col.Codec = ""
+	case "COMMENT":
+		col.Comment = ""
+	}
+	return col
+}
+
+// isTableElementKeyword reports whether tok starts a table element that is not a column.
+func isTableElementKeyword(tok ddlToken) bool {
+	for _, kw := range []string{"INDEX", "PROJECTION", "CONSTRAINT", "PRIMARY", "STATISTICS"} {
+		if tok.is(kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// typeEndKeywords end the type of a column definition.
+var typeEndKeywords = []string{
+	"NULL", "NOT", "DEFAULT", "MATERIALIZED", "ALIAS", "EPHEMERAL", "COMMENT", "CODEC",
+	"TTL", "STATISTICS", "SETTINGS", "PRIMARY", "AFTER", "FIRST", "REMOVE",
+}
+
+// exprEndKeywords end an expression in a column definition, such as a default or TTL.
+var exprEndKeywords = []string{
+	"COMMENT", "CODEC", "TTL", "STATISTICS", "SETTINGS", "PRIMARY", "AFTER", "FIRST",
+}
+
+// ddlParser is a cursor over the tokens of a single statement.
+type ddlParser struct {
+	src     string
+	toks    []ddlToken
+	pos     int
+	removed string // property named by MODIFY COLUMN ... REMOVE
+}
+
+func (p *ddlParser) peek() ddlToken {
+	if p.pos >= len(p.toks) {
+		return ddlToken{kind: ddlPunct}
+	}
+	return p.toks[p.pos]
+}
+
+func (p *ddlParser) next() ddlToken {
+	t := p.peek()
+	if p.pos < len(p.toks) {
+		p.pos++
+	}
+	return t
+}
+
+func (p *ddlParser) rest() []ddlToken {
+	return p.toks[p.pos:]
+}
+
+// acceptKeywords consumes kws if the next tokens are exactly kws.
+func (p *ddlParser) acceptKeywords(kws ...string) bool {
+	if p.pos+len(kws) > len(p.toks) {
+		return false
+	}
+	for i, kw := range kws {
+		if !p.toks[p.pos+i].is(kw) {
+			return false
+		}
+	}
+	p.pos += len(kws)
+	return true
+}
+
+// tableName parses [database.]name.
+func (p *ddlParser) tableName() (database, name string, err error) {
+	first := p.next()
+	if first.kind != ddlWord && first.kind != ddlQuotedIdent {
+		return "", "", errors.New("expected table name")
+	}
+	if !p.peek().isPunct(".") {
+		return "", first.text, nil
+	}
+	p.next()
+	second := p.next()
+	if second.kind != ddlWord && second.kind != ddlQuotedIdent {
+		return "", "", errors.New("expected table name")
+	}
+	return first.text, second.text, nil
+}
+
+func (p *ddlParser) skipOnCluster() {
+	if p.acceptKeywords("ON", "CLUSTER") {
+		p.next()
+	}
+}
+
+// parenthesized consumes a parenthesized group and returns the tokens inside it.
+func (p *ddlParser) parenthesized() ([]ddlToken, error) {
+	if !p.next().isPunct("(") {
+		return nil, errors.New("expected (")
+	}
+	start := p.pos
+	depth := 1
+	for p.pos < len(p.toks) {
+		t := p.next()
+		switch {
+		case t.isPunct("("):
+			depth++
+		case t.isPunct(")"):
+			depth--
+			if depth == 0 {
+				return p.toks[start : p.pos-1], nil
+			}
+		}
+	}
+	return nil, errors.New("unbalanced parentheses")
+}
+
+// untilKeyword consumes tokens up to the next of kws at depth 0.
+func (p *ddlParser) untilKeyword(kws []string) []ddlToken {
+	start := p.pos
+	depth := 0
+	for p.pos < len(p.toks) {
+		t := p.peek()
+		switch {
+		case t.isPunct("("):
+			depth++
+		case t.isPunct(")"):
+			depth--
+		case depth == 0 && slices.ContainsFunc(kws, t.is):
+			return p.toks[start:p.pos]
+		}
+		p.pos++
+	}
+	return p.toks[start:p.pos]
+}
+
+// text returns the source text spanned by toks.
+func (p *ddlParser) text(toks []ddlToken) string {
+	if len(toks) == 0 {
+		return ""
+	}
+	return p.src[toks[0].start:toks[len(toks)-1].end]
+}
+
+// columnDef parses a column definition. If requireType is false (MODIFY COLUMN),
+// the type may be omitted.
+func (p *ddlParser) columnDef(requireType bool) (columnSchema, error) { //nolint:gocyclo
+	nameTok := p.next()
+	if nameTok.kind != ddlWord && nameTok.kind != ddlQuotedIdent {
+		return columnSchema{}, errors.New("expected column name")
+	}
+	col := columnSchema{Name: nameTok.text}
+	typeToks := p.untilKeyword(typeEndKeywords)
+	nullable := false
+	for p.pos < len(p.toks) {
+		switch {
+		case p.acceptKeywords("NULL"):
+			nullable = true
+		case p.acceptKeywords("NOT", "NULL"):
+		case p.acceptKeywords("DEFAULT"), p.acceptKeywords("MATERIALIZED"),
+			p.acceptKeywords("ALIAS"), p.acceptKeywords("EPHEMERAL"):
+			col.DefaultKind = strings.ToUpper(p.toks[p.pos-1].text)
+			col.DefaultExpr = p.text(p.untilKeyword(exprEndKeywords))
+		case p.acceptKeywords("COMMENT"):
+			col.Comment = p.next().text
+		case p.peek().is("CODEC"):
+			codec := p.next()
+			inner, err := p.parenthesized()
+			if err != nil {
+				return columnSchema{}, fmt.Errorf("column %s: %w", col.Name, err)
+			}
+			col.Codec = codec.text + "(" + p.text(inner) + ")"
+		case p.acceptKeywords("REMOVE"):
+			p.removed = strings.ToUpper(p.next().text)
+		case p.peek().is("AFTER"), p.peek().is("FIRST"):
+			return p.finishColumn(col, typeToks, nullable, requireType)
+		default:
+			// TTL, STATISTICS, SETTINGS, PRIMARY KEY: not needed for the model.
+			p.next()
+			p.untilKeyword(exprEndKeywords)
+		}
+	}
+	return p.finishColumn(col, typeToks, nullable, requireType)
+}
+
+func (p *ddlParser) finishColumn(col columnSchema, typeToks []ddlToken, nullable, requireType bool) (columnSchema, error) { //nolint:gocritic,lll
+	if len(typeToks) == 0 {
+		if requireType {
+			return columnSchema{}, fmt.Errorf("column %s: type is required", col.Name)
+		}
+		return col, nil
+	}
+	col.Type = formatType(typeToks)
+	if nullable && !strings.HasPrefix(col.Type, "Nullable(") {
+		col.Type = "Nullable(" + col.Type + ")"
+	}
+	return col, nil
+}
+
+// typeAliases maps case-insensitive SQL type aliases to ClickHouse type names.
+var typeAliases = map[string]string{
+	"TINYINT":   "Int8",
+	"SMALLINT":  "Int16",
+	"INT":       "Int32",
+	"INTEGER":   "Int32",
+	"BIGINT":    "Int64",
+	"FLOAT":     "Float32",
+	"REAL":      "Float32",
+	"DOUBLE":    "Float64",
+	"BOOLEAN":   "Bool",
+	"BOOL":      "Bool",
+	"VARCHAR":   "String",
+	"CHAR":      "String",
+	"TEXT":      "String",
+	"BLOB":      "String",
+	"DECIMAL":   "Decimal",
+	"TIMESTAMP": "DateTime",
+}
+
+// formatType renders type tokens the way system.columns formats types,
+// e.g. "Map(String, Array(UInt8))" and "Enum8('a' = 1, 'b' = 2)".
+func formatType(toks []ddlToken) string {
+	if first := toks[0]; first.kind == ddlWord {
+		if name, ok := typeAliases[strings.ToUpper(first.text)]; ok {
+			toks = append([]ddlToken{{kind: ddlWord, text: name}}, toks[1:]...)
+			if name == "String" {
+				// VARCHAR(255) and friends: the length is ignored by ClickHouse.
+				toks = toks[:1]
+			}
+		}
+	}
+
+	var sb strings.Builder
+	var prev ddlToken
+	for i, t := range toks {
+		if i > 0 {
+			switch {
+			case prev.isPunct(","), t.isPunct("="), prev.isPunct("="):
+				sb.WriteByte(' ')
+			case prev.kind != ddlPunct && t.kind != ddlPunct:
+				sb.WriteByte(' ')
+			}
+		}
+		if t.kind == ddlString {
+			sb.WriteString("'" + strings.ReplaceAll(strings.ReplaceAll(t.text, `\`, `\\`), "'", `\'`) + "'")
+		} else {
+			sb.WriteString(t.text)
+		}
+		prev = t
+	}
+	return sb.String()
+}
+
+// splitTopLevel splits toks on the punctuation sep outside parentheses.
+func splitTopLevel(toks []ddlToken, sep string) [][]ddlToken {
+	var parts [][]ddlToken
+	depth, start := 0, 0
+	for i, t := range toks {
+		switch {
+		case t.isPunct("("), t.isPunct("["):
+			depth++
+		case t.isPunct(")"), t.isPunct("]"):
+			depth--
+		case depth == 0 && t.isPunct(sep):
+			parts = append(parts, toks[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, toks[start:])
+}
+
+// lineOf returns the 1-based line number of offset in src.
+func lineOf(src string, offset int) int {
+	return strings.Count(src[:offset], "\n") + 1
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDDLTableName(t *testing.T) {
+	tests := []struct {
+		name    string
+		sql     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "simple create table",
+			sql:  "CREATE TABLE events (id UInt64) ENGINE = MergeTree()",
+			want: "events",
+		},
+		{
+			name: "with IF NOT EXISTS",
+			sql:  "CREATE TABLE IF NOT EXISTS events (id UInt64) ENGINE = MergeTree()",
+			want: "events",
+		},
+		{
+			name: "with database prefix",
+			sql:  "CREATE TABLE mydb.events (id UInt64) ENGINE = MergeTree()",
+			want: "events",
+		},
+		{
+			name: "lowercase create table",
+			sql:  "create table my_table (col1 String) ENGINE = Memory()",
+			want: "my_table",
+		},
+		{
+			name: "quoted name and on cluster",
+			sql:  "CREATE OR REPLACE TABLE `my db`.`my table` ON CLUSTER main (col1 String) ENGINE = Memory",
+			want: "my table",
+		},
+		{
+			name:    "no create table",
+			sql:     "SELECT 1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &ddlSchema{}
+			require.NoError(t, s.apply(tt.sql))
+			got, err := s.onlyTable()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.name)
+		})
+	}
+}
+
+func TestDDLColumns(t *testing.T) {
+	const sql = `
+CREATE TABLE t (
+	id UInt64,
+	-- comment with a , comma
+	name String DEFAULT 'a, b' COMMENT 'the ''name''',
+	score Int64 NULL,
+	kind Enum8('a' = 1,'b'=2) CODEC(ZSTD(3)),
+	total Float64 MATERIALIZED price * qty CODEC(Delta, LZ4) COMMENT 'computed',
+	total_alias Float64 ALIAS total,
+	ts DateTime64(3,'UTC') DEFAULT now64() TTL ts + INTERVAL 1 DAY,
+	m Map(String,Array(Tuple(a UInt8, b Nullable(String)))),
+	big BIGINT,
+	txt VARCHAR(255) NOT NULL,
+	flag BOOLEAN,
+	INDEX i1 name TYPE minmax GRANULARITY 1,
+	CONSTRAINT c1 CHECK id > 0,
+	PRIMARY KEY id
+) ENGINE = MergeTree ORDER BY id /* trailing; comment */;
+`
+	s := &ddlSchema{}
+	require.NoError(t, s.apply(sql))
+	tbl, err := s.table("t")
+	require.NoError(t, err)
+	require.Equal(t, []columnSchema{
+		{Name: "id", Type: "UInt64"},
+		{Name: "name", Type: "String", DefaultKind: "DEFAULT", DefaultExpr: "'a, b'", Comment: "the 'name'"},
+		{Name: "score", Type: "Nullable(Int64)"},
+		{Name: "kind", Type: "Enum8('a' = 1, 'b' = 2)", Codec: "CODEC(ZSTD(3))"},
+		{Name: "total", Type: "Float64", DefaultKind: "MATERIALIZED", DefaultExpr: "price * qty", Codec: "CODEC(Delta, LZ4)", Comment: "computed"},
+		{Name: "total_alias", Type: "Float64", DefaultKind: "ALIAS", DefaultExpr: "total"},
+		{Name: "ts", Type: "DateTime64(3, 'UTC')", DefaultKind: "DEFAULT", DefaultExpr: "now64()"},
+		{Name: "m", Type: "Map(String, Array(Tuple(a UInt8, b Nullable(String))))"},
+		{Name: "big", Type: "Int64"},
+		{Name: "txt", Type: "String"},
+		{Name: "flag", Type: "Bool"},
+	}, tbl.columns)
+}
+
+func TestDDLAlter(t *testing.T) {
+	const sql = `
+CREATE TABLE db.t (a UInt8, b String, c String) ENGINE = Memory;
+ALTER TABLE t ADD COLUMN z UInt8 FIRST, ADD COLUMN IF NOT EXISTS a UInt64, ADD COLUMN d Int32 AFTER a;
+ALTER TABLE db.t DROP COLUMN IF EXISTS b, RENAME COLUMN c TO cc, COMMENT COLUMN cc 'renamed';
+ALTER TABLE t MODIFY COLUMN cc LowCardinality(String) DEFAULT 'x', MODIFY COLUMN a COMMENT 'first';
+ALTER TABLE t MODIFY COLUMN cc REMOVE DEFAULT;
+RENAME TABLE t TO t2;
+INSERT INTO t2 VALUES (1, 2, 3, 'x');
+`
+	s := &ddlSchema{}
+	require.NoError(t, s.apply(sql))
+	_, err := s.table("t")
+	require.Error(t, err)
+	tbl, err := s.table("db.t2")
+	require.NoError(t, err)
+	require.Equal(t, []columnSchema{
+		{Name: "z", Type: "UInt8"},
+		{Name: "a", Type: "UInt8", Comment: "first"},
+		{Name: "d", Type: "Int32"},
+		{Name: "cc", Type: "LowCardinality(String)", Comment: "renamed"},
+	}, tbl.columns)
+}
+
+func TestDDLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{
+			name: "column without type",
+			sql:  "CREATE TABLE t (\n  a DEFAULT 1\n) ENGINE = Memory",
+			want: "line 1: table t: column a: type is required",
+		},
+		{
+			name: "alter unknown table",
+			sql:  "SELECT 1;\nALTER TABLE t ADD COLUMN a UInt8",
+			want: "line 2: ALTER TABLE t: table not found",
+		},
+		{
+			name: "unterminated string",
+			sql:  "CREATE TABLE t (a Enum8('a = 1)) ENGINE = Memory",
+			want: "unterminated ' quote at offset 24",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&ddlSchema{}).apply(tt.sql)
+			require.EqualError(t, err, tt.want)
+		})
+	}
+}
@@ -7,7 +7,7 @@ import (
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: chgen <model|columns> [flags]\n")
+		fmt.Fprintf(os.Stderr, "Usage: chgen <model|columns|queries> [flags]\n")
 		os.Exit(1)
 	}
 
@@ -17,8 +17,10 @@ func main() {
 		err = runModel(os.Args[2:])
 	case "columns":
 		err = runColumns(os.Args[2:])
+	case "queries":
+		err = runQueries(os.Args[2:])
 	default:
-		fmt.Fprintf(os.Stderr, "Unknown command: %s\nUsage: chgen <model|columns> [flags]\n", os.Args[1])
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\nUsage: chgen <model|columns|queries> [flags]\n", os.Args[1])
 		os.Exit(1)
 	}
 
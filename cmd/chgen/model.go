@@ -7,9 +7,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 	"unicode"
@@ -30,8 +28,12 @@ type modelConfig struct {
 
 // columnSchema describes one row from system.columns.
 type columnSchema struct {
-	Name string `db:"name"`
-	Type string `db:"type"`
+	Name        string `db:"name"`
+	Type        string `db:"type"`
+	DefaultKind string `db:"default_kind"` // DEFAULT, MATERIALIZED, ALIAS, EPHEMERAL or empty
+	DefaultExpr string `db:"default_expression"`
+	Codec       string `db:"compression_codec"` // e.g. CODEC(ZSTD(1))
+	Comment     string `db:"comment"`
 }
 
 func runModel(args []string) error {
@@ -40,7 +42,7 @@ func runModel(args []string) error {
 	fs.StringVar(&cfg.dsn, "dsn", "", "ClickHouse connection string")
 	fs.StringVar(&cfg.table, "table", "", "Table name")
 	fs.StringVar(&cfg.database, "database", "default", "Database name")
-	fs.StringVar(&cfg.sqlFile, "sql", "", "Path to SQL file with CREATE TABLE")
+	fs.StringVar(&cfg.sqlFile, "sql", "", "Path to SQL file with CREATE TABLE, or a directory of migration files")
 	fs.StringVar(&cfg.out, "out", "", "Output file path")
 	fs.StringVar(&cfg.pkg, "package", "", "Go package name (default: inferred from output dir)")
 	fs.BoolVar(&cfg.timeAsUint, "time-as-uint", false, "Use uint/int types for date/time columns")
@@ -68,7 +70,7 @@ func runModel(args []string) error {
 	if cfg.dsn != "" {
 		columns, err = fetchColumnsFromDSN(cfg.dsn, cfg.database, cfg.table)
 	} else {
-		columns, err = fetchColumnsFromSQL(cfg.sqlFile)
+		columns, err = fetchColumnsFromSQL(cfg.sqlFile, cfg.table)
 	}
 	if err != nil {
 		return fmt.Errorf("fetching columns: %w", err)
@@ -85,7 +87,8 @@ func fetchColumnsFromDSN(dsn, database, table string) ([]columnSchema, error) {
 	}
 	defer conn.Close()
 
-	const query = `SELECT name, type FROM system.columns WHERE database = {db:String} AND table = {tbl:String} ORDER BY position`
+	const query = `SELECT name, type, default_kind, default_expression, compression_codec, comment
+		FROM system.columns WHERE database = {db:String} AND table = {tbl:String} ORDER BY position`
 	cols, err := chconn.QueryAll[columnSchema](
 		context.Background(), conn, query,
 		chconn.StringParameter("db", database),
@@ -97,59 +100,24 @@ func fetchColumnsFromDSN(dsn, database, table string) ([]columnSchema, error) {
 	return cols, nil
 }
 
-// createTableRe matches CREATE TABLE [IF NOT EXISTS] [db.]name
-var createTableRe = regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(?:\w+\.)?(\w+)`)
-
-// extractTableName extracts the table name from a CREATE TABLE statement.
-func extractTableName(sql string) (string, error) {
-	m := createTableRe.FindStringSubmatch(sql)
-	if m == nil {
-		return "", errors.New("could not find CREATE TABLE statement")
-	}
-	return m[1], nil
-}
-
-// fetchColumnsFromSQL runs clickhouse-local with the SQL file content and reads system.columns.
-func fetchColumnsFromSQL(sqlFile string) ([]columnSchema, error) {
-	data, err := os.ReadFile(sqlFile)
-	if err != nil {
-		return nil, fmt.Errorf("reading sql file: %w", err)
-	}
-
-	tableName, err := extractTableName(string(data))
+// fetchColumnsFromSQL parses the CREATE TABLE (and ALTER TABLE) statements in
+// sqlPath, a file or a directory of migration files, and returns the columns of
+// table. If table is empty, the SQL must define exactly one table.
+func fetchColumnsFromSQL(sqlPath, table string) ([]columnSchema, error) {
+	schema, err := loadDDL(sqlPath)
 	if err != nil {
 		return nil, err
 	}
-
-	query := fmt.Sprintf(
-		"%s\nSELECT name, type FROM system.columns WHERE table = '%s' ORDER BY position FORMAT TabSeparated",
-		strings.TrimRight(string(data), "\n\r ;"), tableName,
-	)
-
-	cmd := exec.Command("clickhouse-local", "--multiquery")
-	cmd.Stdin = strings.NewReader(query)
-	out, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("running clickhouse-local: %w", err)
+	var t *ddlTable
+	if table == "" {
+		t, err = schema.onlyTable()
+	} else {
+		t, err = schema.table(table)
 	}
-
-	return parseTabSeparated(string(out))
-}
-
-// parseTabSeparated parses tab-separated output of (name, type) pairs.
-func parseTabSeparated(output string) ([]columnSchema, error) {
-	var cols []columnSchema
-	for line := range strings.SplitSeq(strings.TrimRight(output, "\n"), "\n") {
-		if line == "" {
-			continue
-		}
-		parts := strings.SplitN(line, "\t", 2)
-		if len(parts) != 2 {
-			return nil, fmt.Errorf("unexpected line format: %q", line)
-		}
-		cols = append(cols, columnSchema{Name: parts[0], Type: parts[1]})
+	if err != nil {
+		return nil, err
 	}
-	return cols, nil
+	return t.columns, nil
 }
 
 // toGoName converts a snake_case, camelCase, or mixed name to an exported Go name.
@@ -227,25 +195,36 @@ func generateModel(cfg modelConfig, columns []columnSchema) error { //nolint:goc
 	}
 
 	type fieldDef struct {
-		goName  string
-		goType  string
-		dbName  string
-		chType  string
-		enumDef *enumDef
+		goName      string
+		goType      string
+		dbName      string
+		chType      string
+		defaultKind string
+		comment     string
+		enumDef     *enumDef
 	}
 
 	var fields []fieldDef
 
-	for _, col := range columns {
+	for _, col := range columns { //nolint:gocritic
+		if col.DefaultKind == "EPHEMERAL" {
+			// EPHEMERAL columns are not stored and cannot be selected.
+			continue
+		}
 		info, err := chTypeToGo(col.Type, cfg.timeAsUint)
 		if err != nil {
 			return fmt.Errorf("column %q: %w", col.Name, err)
 		}
 
 		fd := fieldDef{
-			goName: toGoName(col.Name),
-			dbName: col.Name,
-			chType: col.Type,
+			goName:  toGoName(col.Name),
+			dbName:  col.Name,
+			chType:  col.Type,
+			comment: col.Comment,
+		}
+		if col.DefaultKind == "MATERIALIZED" || col.DefaultKind == "ALIAS" {
+			// Computed by the server: read-only, skipped on insert.
+			fd.defaultKind = col.DefaultKind
 		}
 
 		if info.isEnum {
@@ -298,7 +277,16 @@ func generateModel(cfg modelConfig, columns []columnSchema) error { //nolint:goc
 	// Emit struct
 	fmt.Fprintf(&buf, "type %s struct {\n", structName)
 	for _, f := range fields {
-		fmt.Fprintf(&buf, "\t%s %s `db:%q chtype:%q`\n", f.goName, f.goType, f.dbName, f.chType)
+		for line := range strings.SplitSeq(f.comment, "\n") {
+			if line != "" {
+				fmt.Fprintf(&buf, "\t// %s\n", line)
+			}
+		}
+		if f.defaultKind != "" {
+			fmt.Fprintf(&buf, "\t%s %s `db:%q chtype:%q chdefault:%q`\n", f.goName, f.goType, f.dbName, f.chType, f.defaultKind)
+		} else {
+			fmt.Fprintf(&buf, "\t%s %s `db:%q chtype:%q`\n", f.goName, f.goType, f.dbName, f.chType)
+		}
 	}
 	buf.WriteString("}\n")
 
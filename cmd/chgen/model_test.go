@@ -35,53 +35,6 @@ func TestToGoName(t *testing.T) {
 	}
 }
 
-func TestExtractTableName(t *testing.T) {
-	tests := []struct {
-		name    string
-		sql     string
-		want    string
-		wantErr bool
-	}{
-		{
-			name: "simple create table",
-			sql:  "CREATE TABLE events (id UInt64) ENGINE = MergeTree()",
-			want: "events",
-		},
-		{
-			name: "with IF NOT EXISTS",
-			sql:  "CREATE TABLE IF NOT EXISTS events (id UInt64) ENGINE = MergeTree()",
-			want: "events",
-		},
-		{
-			name: "with database prefix",
-			sql:  "CREATE TABLE mydb.events (id UInt64) ENGINE = MergeTree()",
-			want: "events",
-		},
-		{
-			name: "lowercase create table",
-			sql:  "create table my_table (col1 String) ENGINE = Memory()",
-			want: "my_table",
-		},
-		{
-			name:    "no create table",
-			sql:     "SELECT 1",
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := extractTableName(tt.sql)
-			if tt.wantErr {
-				require.Error(t, err)
-				return
-			}
-			require.NoError(t, err)
-			require.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestGenerateModel_Snapshot(t *testing.T) {
 	columns := []columnSchema{
 		{Name: "id", Type: "UInt64"},
@@ -146,3 +99,23 @@ func TestGenerateModel_TimeAsUint(t *testing.T) {
 
 	testSnapshot(t, "testdata/model_time_as_uint.go.golden", string(got))
 }
+
+func TestGenerateModel_FromMigrations(t *testing.T) {
+	columns, err := fetchColumnsFromSQL("testdata/migrations", "events")
+	require.NoError(t, err)
+
+	outFile := filepath.Join(t.TempDir(), "events.go")
+	cfg := modelConfig{
+		out:   outFile,
+		pkg:   "testpkg",
+		table: "events",
+	}
+
+	err = generateModel(cfg, columns)
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+
+	testSnapshot(t, "testdata/model_migrations.go.golden", string(got))
+}
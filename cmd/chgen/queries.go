@@ -0,0 +1,584 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	chconn "github.com/vahid-sohrabloo/chconn/v3"
+	"golang.org/x/tools/imports"
+)
+
+type queriesConfig struct {
+	input  string
+	out    string
+	pkg    string
+	dsn    string
+	schema string
+}
+
+func runQueries(args []string) error {
+	var cfg queriesConfig
+	fs := flag.NewFlagSet("queries", flag.ExitOnError)
+	fs.StringVar(&cfg.input, "input", "", "Annotated SQL file or directory of .sql files")
+	fs.StringVar(&cfg.out, "out", "", "Output file path")
+	fs.StringVar(&cfg.pkg, "package", "", "Go package name (default: inferred from output dir)")
+	fs.StringVar(&cfg.dsn, "dsn", "", "ClickHouse connection string used to resolve result columns")
+	fs.StringVar(&cfg.schema, "schema", "", "SQL file or directory of migration files used to resolve result columns")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if cfg.input == "" {
+		return errors.New("--input is required")
+	}
+	if cfg.out == "" {
+		return errors.New("--out is required")
+	}
+	if cfg.dsn != "" && cfg.schema != "" {
+		return errors.New("--dsn and --schema are mutually exclusive")
+	}
+
+	queries, err := loadQueries(cfg.input)
+	if err != nil {
+		return err
+	}
+
+	var resolver columnResolver
+	switch {
+	case cfg.dsn != "":
+		conn, err := chconn.Connect(context.Background(), cfg.dsn)
+		if err != nil {
+			return fmt.Errorf("connecting: %w", err)
+		}
+		defer conn.Close()
+		resolver = &dsnResolver{conn: conn}
+	case cfg.schema != "":
+		schema, err := loadDDL(cfg.schema)
+		if err != nil {
+			return fmt.Errorf("loading schema: %w", err)
+		}
+		resolver = &schemaResolver{schema: schema}
+	}
+
+	for _, q := range queries {
+		if err := q.resolveColumns(resolver); err != nil {
+			return fmt.Errorf("query %s: %w", q.Name, err)
+		}
+	}
+	return generateQueries(cfg, queries)
+}
+
+// queryParam is a {name:Type} placeholder of a query.
+type queryParam struct {
+	Name   string
+	ChType string
+}
+
+// queryDef is a query read from an annotated SQL file:
+//
+//	-- name: GetEvents :many
+//	-- Any other comment lines are used as the doc comment.
+//	-- result: Event            (optional: existing Go type for the rows)
+//	-- column: total UInt64     (optional: type of a result column)
+//	SELECT ... WHERE id = {id:UInt64}
+type queryDef struct {
+	Name    string
+	Kind    string // one, many or iter
+	Doc     []string
+	Result  string
+	SQL     string
+	Params  []queryParam
+	Columns []columnSchema
+	file    string
+	line    int
+	// columnTypes holds the types declared with "-- column:" annotations.
+	columnTypes map[string]string
+}
+
+var (
+	queryNameRe   = regexp.MustCompile(`^--\s*name:\s*(\w+)\s+:(\w+)\s*$`)
+	queryResultRe = regexp.MustCompile(`^--\s*result:\s*(\S+)\s*$`)
+	queryColumnRe = regexp.MustCompile(`^--\s*column:\s*(\S+)\s+(.+?)\s*$`)
+	queryParamRe  = regexp.MustCompile(`\{\s*(\w+)\s*:\s*([^{}]+?)\s*\}`)
+)
+
+// loadQueries reads the queries of path, a file or a directory of .sql files.
+func loadQueries(path string) ([]*queryDef, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.sql"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+	}
+
+	var queries []*queryDef
+	names := map[string]string{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading sql file: %w", err)
+		}
+		qs, err := parseQueries(file, string(data))
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range qs {
+			if prev, ok := names[q.Name]; ok {
+				return nil, fmt.Errorf("%s:%d: query %s already defined in %s", q.file, q.line, q.Name, prev)
+			}
+			names[q.Name] = q.file
+		}
+		queries = append(queries, qs...)
+	}
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("no annotated queries found in %s", path)
+	}
+	return queries, nil
+}
+
+// parseQueries parses the annotated queries of one SQL file.
+func parseQueries(file, src string) ([]*queryDef, error) { //nolint:gocyclo
+	var queries []*queryDef
+	var cur *queryDef
+	var sql strings.Builder
+	inHeader := false
+
+	finish := func() error {
+		if cur == nil {
+			return nil
+		}
+		cur.SQL = strings.TrimRight(strings.TrimSpace(sql.String()), ";")
+		cur.SQL = strings.TrimSpace(cur.SQL)
+		if cur.SQL == "" {
+			return fmt.Errorf("%s:%d: query %s has no SQL", file, cur.line, cur.Name)
+		}
+		params, err := parseQueryParams(cur.SQL)
+		if err != nil {
+			return fmt.Errorf("%s:%d: query %s: %w", file, cur.line, cur.Name, err)
+		}
+		cur.Params = params
+		queries = append(queries, cur)
+		sql.Reset()
+		return nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(src))
+	scanner.Buffer(nil, len(src)+1)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if m := queryNameRe.FindStringSubmatch(trimmed); m != nil {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			switch m[2] {
+			case "one", "many", "iter":
+			default:
+				return nil, fmt.Errorf("%s:%d: query %s: unknown result kind :%s (want :one, :many or :iter)", file, lineNo, m[1], m[2])
+			}
+			cur = &queryDef{Name: m[1], Kind: m[2], file: file, line: lineNo, columnTypes: map[string]string{}}
+			inHeader = true
+			continue
+		}
+		if cur == nil {
+			continue // text before the first query
+		}
+		if inHeader && strings.HasPrefix(trimmed, "--") {
+			switch m1, m2 := queryResultRe.FindStringSubmatch(trimmed), queryColumnRe.FindStringSubmatch(trimmed); {
+			case m1 != nil:
+				cur.Result = m1[1]
+			case m2 != nil:
+				cur.columnTypes[m2[1]] = m2[2]
+			default:
+				cur.Doc = append(cur.Doc, strings.TrimSpace(strings.TrimPrefix(trimmed, "--")))
+			}
+			continue
+		}
+		if trimmed != "" {
+			inHeader = false
+		}
+		sql.WriteString(line)
+		sql.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
+
+// parseQueryParams returns the distinct {name:Type} placeholders of sql in order of appearance.
+func parseQueryParams(sql string) ([]queryParam, error) {
+	var params []queryParam
+	seen := map[string]string{}
+	for _, m := range queryParamRe.FindAllStringSubmatch(sql, -1) {
+		name, chType := m[1], m[2]
+		if prev, ok := seen[name]; ok {
+			if prev != chType {
+				return nil, fmt.Errorf("parameter %s is used as both %s and %s", name, prev, chType)
+			}
+			continue
+		}
+		seen[name] = chType
+		params = append(params, queryParam{Name: name, ChType: chType})
+	}
+	return params, nil
+}
+
+// columnResolver resolves the result columns of a query.
+type columnResolver interface {
+	resolve(q *queryDef) ([]columnSchema, error)
+}
+
+// resolveColumns fills q.Columns. Queries with a result type don't need their columns.
+func (q *queryDef) resolveColumns(r columnResolver) error {
+	if q.Result != "" {
+		return nil
+	}
+	if r == nil {
+		// Without --dsn or --schema every column must be declared.
+		cols, err := (&schemaResolver{schema: &ddlSchema{}}).resolve(q)
+		if err != nil {
+			return fmt.Errorf("%w (use --dsn or --schema to resolve result columns)", err)
+		}
+		q.Columns = cols
+		return nil
+	}
+	cols, err := r.resolve(q)
+	if err != nil {
+		return err
+	}
+	for i := range cols {
+		if t, ok := q.columnTypes[cols[i].Name]; ok {
+			cols[i].Type = t
+		}
+	}
+	q.Columns = cols
+	return nil
+}
+
+// dsnResolver asks the server to DESCRIBE the query.
+type dsnResolver struct {
+	conn chconn.Conn
+}
+
+func (r *dsnResolver) resolve(q *queryDef) ([]columnSchema, error) {
+	// Placeholders are replaced with a constant of the same type, so the
+	// query can be described without parameter values.
+	sql := queryParamRe.ReplaceAllStringFunc(q.SQL, func(p string) string {
+		m := queryParamRe.FindStringSubmatch(p)
+		return "defaultValueOfTypeName('" + strings.ReplaceAll(strings.ReplaceAll(m[2], `\`, `\\`), "'", `\'`) + "')"
+	})
+	rows, err := r.conn.Query(context.Background(), "DESCRIBE TABLE ("+sql+")")
+	if err != nil {
+		return nil, fmt.Errorf("describing query: %w", err)
+	}
+	defer rows.Close()
+	var cols []columnSchema
+	for rows.Next() {
+		var col columnSchema
+		values := rows.Values()
+		col.Name, _ = values[0].(string)
+		col.Type, _ = values[1].(string)
+		cols = append(cols, col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("describing query: %w", err)
+	}
+	return cols, nil
+}
+
+// schemaResolver infers the result columns from the tables of a schema. Only
+// column references (optionally qualified or aliased) and * are resolved;
+// other expressions need a "-- column: name Type" annotation.
+type schemaResolver struct {
+	schema *ddlSchema
+}
+
+func (r *schemaResolver) resolve(q *queryDef) ([]columnSchema, error) { //nolint:gocyclo
+	toks, err := tokenizeDDL(q.SQL)
+	if err != nil {
+		return nil, err
+	}
+	p := &ddlParser{src: q.SQL, toks: toks}
+	if !p.acceptKeywords("SELECT") {
+		return nil, errors.New("only SELECT queries can be resolved from a schema")
+	}
+	p.acceptKeywords("DISTINCT")
+
+	// The select list ends at the top-level FROM.
+	start, depth := p.pos, 0
+	for ; p.pos < len(p.toks); p.pos++ {
+		t := p.toks[p.pos]
+		if t.isPunct("(") {
+			depth++
+		} else if t.isPunct(")") {
+			depth--
+		} else if depth == 0 && t.is("FROM") {
+			break
+		}
+	}
+	items := splitTopLevel(p.toks[start:p.pos], ",")
+
+	var table *ddlTable
+	if p.acceptKeywords("FROM") && !p.peek().isPunct("(") {
+		database, name, err := p.tableName()
+		if err == nil {
+			if i := r.schema.lookup(database, name); i >= 0 {
+				table = r.schema.tables[i]
+			}
+		}
+	}
+
+	var cols []columnSchema
+	for _, item := range items {
+		if len(item) == 1 && item[0].isPunct("*") {
+			if table == nil {
+				return nil, errors.New("cannot resolve * without a table in the schema")
+			}
+			for _, c := range table.columns { //nolint:gocritic
+				if c.DefaultKind == "" || c.DefaultKind == "DEFAULT" {
+					cols = append(cols, columnSchema{Name: c.Name, Type: c.Type})
+				}
+			}
+			continue
+		}
+
+		expr, name := item, ""
+		if n := len(item); n >= 2 && item[n-2].is("AS") {
+			expr, name = item[:n-2], item[n-1].text
+		}
+		// Column reference: [table.]column
+		var ref string
+		if len(expr) == 1 && (expr[0].kind == ddlWord || expr[0].kind == ddlQuotedIdent) {
+			ref = expr[0].text
+		} else if len(expr) == 3 && expr[1].isPunct(".") {
+			ref = expr[2].text
+		}
+		if name == "" {
+			name = ref
+		}
+		if name == "" {
+			name = p.text(expr)
+		}
+
+		if t, ok := q.columnTypes[name]; ok {
+			cols = append(cols, columnSchema{Name: name, Type: t})
+			continue
+		}
+		if ref != "" && table != nil {
+			if i := table.column(ref); i >= 0 {
+				cols = append(cols, columnSchema{Name: name, Type: table.columns[i].Type})
+				continue
+			}
+		}
+		return nil, fmt.Errorf("cannot resolve the type of result column %q, add a \"-- column: %s Type\" annotation", name, name)
+	}
+	return cols, nil
+}
+
+// paramMapping returns the Go type of a query parameter of ClickHouse type chType
+// and the expression building the chconn.Parameter from the Go variable v.
+func paramMapping(name, chType, v string) (string, string) {
+	inner := unwrapParamType(chType)
+	if strings.HasPrefix(inner, "Array(") && strings.HasSuffix(inner, ")") {
+		elem := unwrapParamType(inner[len("Array(") : len(inner)-1])
+		if goType, ctor := scalarParamCtor(elem); ctor != "" {
+			return "[]" + goType, fmt.Sprintf("chconn.%sSliceParameter(%q, %s%s)", ctor, name, v, precisionArg(elem))
+		}
+		return "string", fmt.Sprintf("chconn.StringParameter(%q, %s)", name, v)
+	}
+
+	if goType, ctor := scalarParamCtor(inner); ctor != "" {
+		return goType, fmt.Sprintf("chconn.%sParameter(%q, %s%s)", ctor, name, v, precisionArg(inner))
+	}
+	return "string", fmt.Sprintf("chconn.StringParameter(%q, %s)", name, v)
+}
+
+// unwrapParamType strips the Nullable and LowCardinality wrappers of a type.
+func unwrapParamType(chType string) string {
+	for _, wrapper := range []string{"LowCardinality(", "Nullable("} {
+		if strings.HasPrefix(chType, wrapper) && strings.HasSuffix(chType, ")") {
+			chType = chType[len(wrapper) : len(chType)-1]
+		}
+	}
+	return chType
+}
+
+// precisionArg returns the precision argument of the DateTime parameter
+// constructors for a DateTime or DateTime64 type, and "" for other types.
+func precisionArg(chType string) string {
+	switch {
+	case strings.HasPrefix(chType, "DateTime64("):
+		digits := chType[len("DateTime64("):]
+		end := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' })
+		if end < 0 {
+			end = len(digits)
+		}
+		if end > 0 {
+			return ", " + digits[:end]
+		}
+		return ", 3"
+	case chType == "DateTime64":
+		// the default precision of DateTime64
+		return ", 3"
+	case chType == "DateTime", strings.HasPrefix(chType, "DateTime("):
+		return ", 0"
+	}
+	return ""
+}
+
+// scalarParamCtor returns the Go type and the chconn Parameter constructor
+// prefix (Int, Uint, Float32, Float64, String, Bool, Date, DateTime) for a
+// scalar ClickHouse type.
+func scalarParamCtor(chType string) (goType, ctor string) {
+	switch chType {
+	case "Int8", "Int16", "Int32", "Int64":
+		return strings.ToLower(chType), "Int"
+	case "UInt8", "UInt16", "UInt32", "UInt64":
+		return "u" + strings.ToLower(chType[1:]), "Uint"
+	case "Float32", "Float64":
+		return strings.ToLower(chType), chType
+	case "String":
+		return "string", "String"
+	case "Bool":
+		return "bool", "Bool"
+	case "Date", "Date32":
+		return "time.Time", "Date"
+	case "DateTime", "DateTime64":
+		return "time.Time", "DateTime"
+	}
+	if strings.HasPrefix(chType, "DateTime(") || strings.HasPrefix(chType, "DateTime64(") {
+		// a Unix timestamp is independent of the server and parameter time zones
+		return "time.Time", "DateTime"
+	}
+	return "", ""
+}
+
+// goParamName returns a Go identifier for the parameter name.
+func goParamName(name string) string {
+	n := lowerFirst(toGoName(name))
+	if n == "" || token.IsKeyword(n) || n == "ctx" || n == "q" {
+		n += "Param"
+	}
+	return n
+}
+
+// generateQueries writes the Go file with the query functions.
+func generateQueries(cfg queriesConfig, queries []*queryDef) error { //nolint:gocritic,funlen,gocyclo
+	pkg := cfg.pkg
+	if pkg == "" {
+		pkg = filepath.Base(filepath.Dir(cfg.out))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by chgen queries; DO NOT EDIT.\n\n")
+	buf.WriteString("package " + pkg + "\n\n")
+
+	for _, q := range queries {
+		sqlConst := lowerFirst(q.Name) + "SQL"
+		fmt.Fprintf(&buf, "const %s = %s\n\n", sqlConst, goRawString(q.SQL))
+
+		// Row type
+		rowType := q.Result
+		if rowType == "" {
+			if len(q.Columns) == 1 {
+				info, err := chTypeToGo(q.Columns[0].Type, false)
+				if err != nil {
+					return fmt.Errorf("query %s: column %q: %w", q.Name, q.Columns[0].Name, err)
+				}
+				rowType = info.goType
+			} else {
+				rowType = q.Name + "Row"
+				fmt.Fprintf(&buf, "// %s is a row returned by %s.\n", rowType, q.Name)
+				fmt.Fprintf(&buf, "type %s struct {\n", rowType)
+				fieldNames := map[string]bool{}
+				for _, col := range q.Columns { //nolint:gocritic
+					info, err := chTypeToGo(col.Type, false)
+					if err != nil {
+						return fmt.Errorf("query %s: column %q: %w", q.Name, col.Name, err)
+					}
+					fieldName := toGoName(col.Name)
+					if fieldName == "" || fieldNames[fieldName] {
+						return fmt.Errorf("query %s: column %q needs an alias", q.Name, col.Name)
+					}
+					fieldNames[fieldName] = true
+					fmt.Fprintf(&buf, "\t%s %s `db:%q`\n", fieldName, info.goType, col.Name)
+				}
+				buf.WriteString("}\n\n")
+			}
+		}
+
+		// Function
+		var args, params []string
+		for _, p := range q.Params {
+			v := goParamName(p.Name)
+			goType, expr := paramMapping(p.Name, p.ChType, v)
+			args = append(args, v+" "+goType)
+			params = append(params, expr)
+		}
+		if len(q.Doc) > 0 {
+			for _, line := range q.Doc {
+				fmt.Fprintf(&buf, "// %s\n", line)
+			}
+		} else {
+			fmt.Fprintf(&buf, "// %s runs the %s query.\n", q.Name, q.Name)
+		}
+		signature := "ctx context.Context, q chconn.Querier"
+		if len(args) > 0 {
+			signature += ", " + strings.Join(args, ", ")
+		}
+		call := "ctx, q, " + sqlConst
+		if len(params) > 0 {
+			call += ",\n\t\t" + strings.Join(params, ",\n\t\t") + ",\n\t"
+		}
+		switch q.Kind {
+		case "one":
+			fmt.Fprintf(&buf, "func %s(%s) (%s, error) {\n", q.Name, signature, rowType)
+			fmt.Fprintf(&buf, "\treturn chconn.QueryOne[%s](%s)\n", rowType, call)
+		case "many":
+			fmt.Fprintf(&buf, "func %s(%s) ([]%s, error) {\n", q.Name, signature, rowType)
+			fmt.Fprintf(&buf, "\treturn chconn.QueryAll[%s](%s)\n", rowType, call)
+		case "iter":
+			fmt.Fprintf(&buf, "func %s(%s) iter.Seq2[%s, error] {\n", q.Name, signature, rowType)
+			fmt.Fprintf(&buf, "\treturn chconn.QueryIter[%s](%s)\n", rowType, call)
+		}
+		buf.WriteString("}\n\n")
+	}
+
+	formatted, err := imports.Process(cfg.out, buf.Bytes(), nil)
+	if err != nil {
+		return fmt.Errorf("formatting output: %w\n%s", err, buf.String())
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.out), 0o755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+	if err := os.WriteFile(cfg.out, formatted, 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("writing output: %w", err)
+	}
+	return nil
+}
+
+// goRawString quotes s as a Go raw string literal, splitting out backquotes.
+func goRawString(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "` + \"`\" + `") + "`"
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueriesGenerate_Snapshot(t *testing.T) {
+	queries, err := loadQueries("testdata/queries")
+	require.NoError(t, err)
+	schema, err := loadDDL("testdata/migrations")
+	require.NoError(t, err)
+	for _, q := range queries {
+		require.NoError(t, q.resolveColumns(&schemaResolver{schema: schema}), q.Name)
+	}
+
+	outFile := filepath.Join(t.TempDir(), "queries_gen.go")
+	require.NoError(t, generateQueries(queriesConfig{out: outFile, pkg: "testpkg"}, queries))
+
+	got, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	testSnapshot(t, "testdata/queries_gen.go.golden", string(got))
+}
+
+func TestParseQueries(t *testing.T) {
+	const src = `-- leading comment
+-- name: A :one
+-- First line.
+-- column: n UInt8
+SELECT {x:UInt8} AS n, {x:UInt8} + {y:String} AS m;
+-- name: B :iter
+SELECT 1
+`
+	queries, err := parseQueries("q.sql", src)
+	require.NoError(t, err)
+	require.Len(t, queries, 2)
+	require.Equal(t, "A", queries[0].Name)
+	require.Equal(t, "one", queries[0].Kind)
+	require.Equal(t, []string{"First line."}, queries[0].Doc)
+	require.Equal(t, map[string]string{"n": "UInt8"}, queries[0].columnTypes)
+	require.Equal(t, "SELECT {x:UInt8} AS n, {x:UInt8} + {y:String} AS m", queries[0].SQL)
+	require.Equal(t, []queryParam{{Name: "x", ChType: "UInt8"}, {Name: "y", ChType: "String"}}, queries[0].Params)
+	require.Equal(t, "SELECT 1", queries[1].SQL)
+}
+
+func TestParseQueriesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{
+			name: "unknown kind",
+			sql:  "-- name: A :all\nSELECT 1",
+			want: "q.sql:1: query A: unknown result kind :all (want :one, :many or :iter)",
+		},
+		{
+			name: "empty query",
+			sql:  "-- name: A :one\n;\n-- name: B :one\nSELECT 1",
+			want: "q.sql:1: query A has no SQL",
+		},
+		{
+			name: "conflicting parameter types",
+			sql:  "-- name: A :one\n\nSELECT {a:UInt8} + {a:Int8}",
+			want: "q.sql:1: query A: parameter a is used as both UInt8 and Int8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseQueries("q.sql", tt.sql)
+			require.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestSchemaResolverErrors(t *testing.T) {
+	schema, err := loadDDL("testdata/migrations")
+	require.NoError(t, err)
+	r := &schemaResolver{schema: schema}
+
+	_, err = r.resolve(&queryDef{SQL: "SELECT count() FROM analytics.events"})
+	require.EqualError(t, err, `cannot resolve the type of result column "count()", add a "-- column: count() Type" annotation`)
+
+	_, err = r.resolve(&queryDef{SQL: "SELECT * FROM other"})
+	require.EqualError(t, err, "cannot resolve * without a table in the schema")
+
+	_, err = r.resolve(&queryDef{SQL: "INSERT INTO t VALUES (1)"})
+	require.EqualError(t, err, "only SELECT queries can be resolved from a schema")
+}
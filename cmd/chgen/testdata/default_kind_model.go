@@ -0,0 +1,12 @@
+package testdata
+
+import "time"
+
+//go:generate go tool chgen columns
+type DefaultKindModel struct {
+	ID          uint64    `db:"id" chtype:"UInt64"`
+	CreatedAt   time.Time `db:"created_at" chtype:"DateTime"`
+	CreatedDate time.Time `db:"created_date" chtype:"Date" chdefault:"MATERIALIZED"`
+	DayName     string    `db:"day_name" chtype:"String" chdefault:"ALIAS"`
+	Name        string    `db:"name" chtype:"String" chdefault:"DEFAULT"`
+}
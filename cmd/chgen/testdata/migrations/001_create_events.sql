@@ -0,0 +1,19 @@
+-- Events table
+CREATE TABLE IF NOT EXISTS analytics.events ON CLUSTER '{cluster}'
+(
+    `id` UInt64 COMMENT 'Event id',
+    name LowCardinality(String) CODEC(ZSTD(1)),
+    source Enum8('web' = 1, 'app' = 2),
+    created_at DateTime64(3, 'UTC') DEFAULT now64(3),
+    created_date Date MATERIALIZED toDate(created_at),
+    price Decimal(18, 4),
+    tags Array(String) DEFAULT [] CODEC(LZ4),
+    INDEX idx_name name TYPE bloom_filter GRANULARITY 4,
+    PROJECTION by_name (SELECT * ORDER BY name)
+)
+ENGINE = ReplicatedMergeTree
+PARTITION BY toYYYYMM(created_at)
+ORDER BY (id, created_at);
+
+CREATE TABLE IF NOT EXISTS analytics.events_buffer AS analytics.events
+ENGINE = Buffer(analytics, events, 16, 10, 100, 10000, 1000000, 10000000, 100000000);
@@ -0,0 +1,8 @@
+ALTER TABLE analytics.events ON CLUSTER '{cluster}'
+    ADD COLUMN IF NOT EXISTS user_id Nullable(UInt64) AFTER id,
+    ADD COLUMN day_name String ALIAS formatDateTime(created_at, '%W'),
+    ADD COLUMN raw String EPHEMERAL,
+    MODIFY COLUMN price Decimal(38, 4) COMMENT 'Price in USD';
+
+/* the buffer table is no longer used */
+DROP TABLE IF EXISTS analytics.events_buffer;
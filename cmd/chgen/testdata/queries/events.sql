@@ -0,0 +1,32 @@
+-- Queries on analytics.events used by the chgen queries tests.
+
+-- name: GetEvent :one
+-- GetEvent returns the event with the given id.
+SELECT * FROM analytics.events WHERE id = {id:UInt64};
+
+-- name: ListEventsByName :many
+SELECT id, e.user_id, name AS event_name, created_at
+FROM analytics.events AS e
+WHERE name IN {names:Array(String)} AND created_at >= {from:DateTime64(3)}
+ORDER BY created_at
+LIMIT {limit:UInt32};
+
+-- name: StreamEventIDs :iter
+SELECT id FROM analytics.events WHERE created_date = {day:Date} AND source = {source:String};
+
+-- name: CountBySource :many
+-- column: total UInt64
+SELECT source, count() AS total
+FROM analytics.events
+WHERE created_at BETWEEN {from:DateTime('UTC')} AND {to:DateTime('UTC')} AND {all:Bool}
+GROUP BY source;
+
+-- name: EventsAt :many
+SELECT id, created_at
+FROM analytics.events
+WHERE created_at IN {times:Array(DateTime64(6, 'UTC'))} OR created_date IN {days:Array(Nullable(Date))}
+    OR has({flags:Array(Bool)}, id % 2 = 0);
+
+-- name: LatestEvent :one
+-- result: Event
+SELECT * FROM analytics.events ORDER BY created_at DESC LIMIT 1;
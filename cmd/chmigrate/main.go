@@ -0,0 +1,163 @@
+// Command chmigrate applies versioned ClickHouse migrations over the native protocol.
+//
+// See the migrate package for the migration file format.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v3"
+	"github.com/vahid-sohrabloo/chconn/v3/migrate"
+)
+
+const usage = "Usage: chmigrate <up|down|status|version|force> [flags] [args]\n"
+
+// varsFlag collects repeated -var name=value flags.
+type varsFlag map[string]string
+
+func (v varsFlag) String() string { return "" }
+
+func (v varsFlag) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("invalid variable %q: want name=value", s)
+	}
+	v[name] = value
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(1)
+	}
+
+	if err := run(os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(command string, args []string) error { //nolint:gocyclo
+	switch command {
+	case "up", "down", "status", "version", "force":
+	default:
+		return fmt.Errorf("unknown command: %s\n%s", command, usage)
+	}
+
+	vars := varsFlag{}
+	opts := &migrate.Options{Vars: vars}
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	dsn := fs.String("dsn", os.Getenv("CHMIGRATE_DSN"), "ClickHouse connection string (default: $CHMIGRATE_DSN)")
+	dir := fs.String("dir", "migrations", "Directory of migration files")
+	timeout := fs.Duration("timeout", 0, "Timeout of the whole run (default: none)")
+	applied := fs.Bool("applied", true, "force: record the version as applied (false: as not applied)")
+	fs.StringVar(&opts.Table, "table", migrate.DefaultTable, "Versions table")
+	fs.StringVar(&opts.Cluster, "cluster", "", "Cluster for {{.OnCluster}} and the versions table")
+	fs.StringVar(&opts.TableEngine, "engine", "", "Engine of the versions table (default: MergeTree or ReplicatedMergeTree with -cluster)")
+	fs.Var(vars, "var", "Template variable name=value, available as {{.Vars.name}} (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dsn == "" {
+		return errors.New("-dsn or $CHMIGRATE_DSN is required")
+	}
+
+	migrations, err := migrate.Load(os.DirFS(*dir))
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	conn, err := chconn.Connect(ctx, *dsn)
+	if err != nil {
+		return fmt.Errorf("connecting: %w", err)
+	}
+	defer conn.Close()
+
+	opts.Logf = func(format string, args ...any) {
+		fmt.Printf(format+"\n", args...)
+	}
+	m := migrate.New(conn, migrations, opts)
+
+	switch command {
+	case "up":
+		if fs.NArg() > 0 {
+			version, err := strconv.ParseUint(fs.Arg(0), 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid version %q", fs.Arg(0))
+			}
+			return m.UpTo(ctx, version)
+		}
+		return m.Up(ctx)
+	case "down":
+		steps := 1
+		if fs.NArg() > 0 {
+			if steps, err = strconv.Atoi(fs.Arg(0)); err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", fs.Arg(0))
+			}
+		}
+		err := m.Down(ctx, steps)
+		if errors.Is(err, migrate.ErrNoChange) {
+			fmt.Println("no migration to revert")
+			return nil
+		}
+		return err
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tUPDATED")
+		for _, s := range status {
+			state, updated := "pending", ""
+			switch {
+			case s.Dirty:
+				state = "dirty"
+			case s.Applied:
+				state = "applied"
+			case !s.Pending:
+				state = "reverted"
+			}
+			if !s.UpdatedAt.IsZero() {
+				updated = s.UpdatedAt.Format(time.DateTime)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, state, updated)
+		}
+		return w.Flush()
+	case "version":
+		version, dirty, err := m.Version(ctx)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			fmt.Printf("%d (dirty)\n", version)
+		} else {
+			fmt.Println(version)
+		}
+		return nil
+	default: // force
+		if fs.NArg() != 1 {
+			return errors.New("force requires a version")
+		}
+		version, err := strconv.ParseUint(fs.Arg(0), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", fs.Arg(0))
+		}
+		return m.Force(ctx, version, *applied)
+	}
+}
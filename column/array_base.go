@@ -89,6 +89,7 @@ func (c *ArrayBase) Remove(n int) {
 	}
 	c.offsetColumn.Remove(n)
 	c.dataColumn.Remove(int(offset))
+	c.offset = offset
 }
 
 // Delete removes rows in the range [start, end)
@@ -121,6 +122,7 @@ func (c *ArrayBase) Delete(start, end int) {
 	for i := start; i < c.offsetColumn.NumRow(); i++ {
 		c.offsetColumn.values[i] -= elementsToRemove
 	}
+	c.offset -= elementsToRemove
 }
 
 func (c *ArrayBase) DeleteFunc(del func(row int) bool) {
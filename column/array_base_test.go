@@ -95,3 +95,20 @@ func BenchmarkArrayBase_DeleteFunc_2D(b *testing.B) {
 		arr.DeleteFunc(func(i int) bool { return i%2 == 0 })
 	}
 }
+
+func TestArrayBase_RemoveDelete_Append(t *testing.T) {
+	arr := column.New[uint32]().Array()
+	arr.Append([]uint32{1, 2})
+	arr.Append([]uint32{3, 4})
+	arr.Append([]uint32{5})
+
+	arr.Remove(2)
+	arr.Append([]uint32{6})
+	assert.EqualValues(t, []uint64{2, 4, 5}, arr.Offsets())
+	assert.Equal(t, []uint32{6}, arr.Row(2))
+
+	arr.Delete(0, 1)
+	arr.Append([]uint32{7, 8})
+	assert.EqualValues(t, []uint64{2, 3, 5}, arr.Offsets())
+	assert.Equal(t, [][]uint32{{3, 4}, {6}, {7, 8}}, arr.Data())
+}
@@ -57,6 +57,8 @@ type Base[T BaseType] struct {
 	isEnum16             bool
 	enumStringMap        map[int16]string
 	sparseData           []T
+	sparseValues         []T
+	sparse               sparseWrite
 	indexRemoveKeepIndex int
 }
 
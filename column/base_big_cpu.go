@@ -23,7 +23,11 @@ func reverseBuffer(s []byte) {
 }
 
 func (c *Base[T]) WriteTo(w io.Writer) (int64, error) {
-	s := helper.ConvertToByte(c.values, c.size)
+	return c.writeValues(w, c.values)
+}
+
+func (c *Base[T]) writeValues(w io.Writer, values []T) (int64, error) {
+	s := helper.ConvertToByte(values, c.size)
 	for i := 0; i < len(s); i += c.size {
 		reverseBuffer(s[i : i+c.size])
 	}
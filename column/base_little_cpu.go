@@ -12,7 +12,11 @@ func (c *Base[T]) readBufferHook() {
 }
 
 func (c *Base[T]) WriteTo(w io.Writer) (int64, error) {
-	s := helper.ConvertToByte(c.values, c.size)
+	return c.writeValues(w, c.values)
+}
+
+func (c *Base[T]) writeValues(w io.Writer, values []T) (int64, error) {
+	s := helper.ConvertToByte(values, c.size)
 	var n int64
 	nw, err := w.Write(s)
 	return int64(nw) + n, err
@@ -74,6 +74,19 @@ type NullableColumn[T any] interface {
 	RowIsNil(row int) bool
 }
 
+// RowReader is implemented by columns that return the value of a single row as T.
+// Unlike [Column], T is the row type, so it is also satisfied by composite columns
+// such as Array(E) (T = []E).
+type RowReader[T any] interface {
+	Row(row int) T
+}
+
+// NullableRowReader is implemented by columns that return the value of a single row as T,
+// where NULL values are represented as nil pointers.
+type NullableRowReader[T any] interface {
+	RowP(row int) T
+}
+
 type column struct {
 	r                *readerwriter.Reader
 	columnHeader     ColumnHeader
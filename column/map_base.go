@@ -134,6 +134,7 @@ func (c *MapBase) Remove(n int) {
 	c.offsetColumn.Remove(n)
 	c.keyColumn.Remove(int(offset))
 	c.valueColumn.Remove(int(offset))
+	c.offset = offset
 }
 
 // Delete removes rows in the range [start, end)
@@ -167,6 +168,7 @@ func (c *MapBase) Delete(start, end int) {
 	for i := start; i < c.offsetColumn.NumRow(); i++ {
 		c.offsetColumn.values[i] -= elementsToRemove
 	}
+	c.offset -= elementsToRemove
 }
 
 func (c *MapBase) DeleteFunc(del func(row int) bool) {
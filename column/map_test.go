@@ -1372,3 +1372,19 @@ func TestMapEmpty(t *testing.T) {
 	assert.Equal(t, []map[string][]uint16{{}, {}}, colArrayLCRead.Data())
 	assert.Equal(t, []map[string][]*uint16{{}, {}}, colLCNullableArrayData)
 }
+
+func TestMapRemoveDeleteAppend(t *testing.T) {
+	m := column.NewMap[string, uint8](column.NewString(), column.New[uint8]())
+	m.Append(map[string]uint8{"a": 1, "b": 2})
+	m.Append(map[string]uint8{"c": 3})
+	m.Append(map[string]uint8{"d": 4})
+
+	m.Remove(1)
+	m.Append(map[string]uint8{"e": 5})
+	assert.Equal(t, []uint64{2, 3}, m.Offsets())
+
+	m.Delete(0, 1)
+	m.Append(map[string]uint8{"f": 6})
+	assert.Equal(t, []uint64{1, 2}, m.Offsets())
+	assert.Equal(t, []map[string]uint8{{"e": 5}, {"f": 6}}, m.Data())
+}
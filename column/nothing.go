@@ -29,6 +29,11 @@ func NewNothing() *Nothing {
 	}
 }
 
+// UseSparse always reports false: Nothing columns are never sent with sparse serialization.
+func (c *Nothing) UseSparse(float64) bool {
+	return false
+}
+
 // Data get all the nullable  data in current block as a slice of pointer.
 //
 // NOTE: it always return slice of nil
@@ -0,0 +1,163 @@
+package column
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/vahid-sohrabloo/chconn/v3/internal/helper"
+)
+
+const sparseEndOfGranuleFlag uint64 = 1 << 62
+
+// SparseColumn is implemented by columns that can be sent with sparse serialization on insert.
+//
+// With sparse serialization only the rows that don't have the default value of the type (zero,
+// empty string) are sent, together with their positions. It reduces the size of columns where
+// most of the rows are default values.
+type SparseColumn interface {
+	ColumnCore
+	// UseSparse reports whether the current data should be sent with sparse serialization.
+	// defaultRatio is the ratio used if SetSparse was not called on the column. A defaultRatio
+	// of zero disables sparse serialization.
+	UseSparse(defaultRatio float64) bool
+	// WriteSparseTo writes the current data with sparse serialization.
+	WriteSparseTo(w io.Writer) (int64, error)
+}
+
+// sparseWrite holds the sparse serialization option of a column.
+type sparseWrite struct {
+	ratio   float64
+	set     bool
+	offsets []byte
+}
+
+func (s *sparseWrite) setRatio(ratio float64) {
+	s.ratio = ratio
+	s.set = true
+}
+
+// use reports whether numDefault default rows out of numRow are enough to send the column sparse.
+// numDefault is only called when it is needed.
+func (s *sparseWrite) use(defaultRatio float64, numRow int, numDefault func() int) bool {
+	ratio := defaultRatio
+	if s.set {
+		ratio = s.ratio
+	} else if ratio <= 0 {
+		return false
+	}
+	if numRow == 0 || ratio > 1 {
+		return false
+	}
+	return float64(numDefault()) >= ratio*float64(numRow)
+}
+
+// appendOffsets encodes the positions of the non-default rows: for each of them the number of
+// default rows since the previous one, then the number of trailing default rows with the end
+// of granule flag.
+func (s *sparseWrite) appendOffsets(numRow int, isDefault func(row int) bool) []byte {
+	s.offsets = s.offsets[:0]
+	start := 0
+	for i := range numRow {
+		if isDefault(i) {
+			continue
+		}
+		s.offsets = binary.AppendUvarint(s.offsets, uint64(i-start))
+		start = i + 1
+	}
+	return binary.AppendUvarint(s.offsets, uint64(numRow-start)|sparseEndOfGranuleFlag)
+}
+
+// SetSparse sets when the column is sent with sparse serialization on insert: when the ratio of
+// rows with the default value is at least ratio. A ratio of 0 always sends the column sparse and
+// a ratio above 1 never does. It overrides Config.SparseRatio of the connection.
+//
+// The server must support sparse serialization (ClickHouse 22.1 or later), otherwise the
+// column is sent dense.
+func (c *Base[T]) SetSparse(ratio float64) {
+	c.sparse.setRatio(ratio)
+}
+
+func (c *Base[T]) isDefaultAt(row int) bool {
+	for _, b := range helper.ConvertToByte(c.values[row:row+1], c.size) {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// UseSparse reports whether the current data should be sent with sparse serialization.
+// Enum columns are always sent dense: the server fills the rows left out with the default of
+// the type, its smallest value, which is not the zero value the rows are compared with.
+func (c *Base[T]) UseSparse(defaultRatio float64) bool {
+	if c.isEnum8 || c.isEnum16 || helper.IsEnum8(c.columnHeader.ChType) || helper.IsEnum16(c.columnHeader.ChType) {
+		return false
+	}
+	return c.sparse.use(defaultRatio, c.numRow, func() int {
+		n := 0
+		for i := range c.numRow {
+			if c.isDefaultAt(i) {
+				n++
+			}
+		}
+		return n
+	})
+}
+
+// WriteSparseTo writes the current data with sparse serialization.
+func (c *Base[T]) WriteSparseTo(w io.Writer) (int64, error) {
+	offsets := c.sparse.appendOffsets(c.numRow, c.isDefaultAt)
+	nw, err := w.Write(offsets)
+	if err != nil {
+		return int64(nw), err
+	}
+	c.sparseValues = c.sparseValues[:0]
+	for i, v := range c.values {
+		if !c.isDefaultAt(i) {
+			c.sparseValues = append(c.sparseValues, v)
+		}
+	}
+	n, err := c.writeValues(w, c.sparseValues)
+	return int64(nw) + n, err
+}
+
+// SetSparse sets when the column is sent with sparse serialization on insert: when the ratio of
+// empty strings is at least ratio. A ratio of 0 always sends the column sparse and a ratio above
+// 1 never does. It overrides Config.SparseRatio of the connection.
+//
+// The server must support sparse serialization (ClickHouse 22.1 or later), otherwise the
+// column is sent dense.
+func (c *StringBase[T]) SetSparse(ratio float64) {
+	c.sparse.setRatio(ratio)
+}
+
+func (c *StringBase[T]) isDefaultAt(row int) bool {
+	return c.pos[row].start == c.pos[row].end
+}
+
+// UseSparse reports whether the current data should be sent with sparse serialization.
+func (c *StringBase[T]) UseSparse(defaultRatio float64) bool {
+	return c.sparse.use(defaultRatio, c.numRow, func() int {
+		n := 0
+		for i := range c.numRow {
+			if c.isDefaultAt(i) {
+				n++
+			}
+		}
+		return n
+	})
+}
+
+// WriteSparseTo writes the current data with sparse serialization.
+func (c *StringBase[T]) WriteSparseTo(w io.Writer) (int64, error) {
+	buf := c.sparse.appendOffsets(c.numRow, c.isDefaultAt)
+	for _, p := range c.pos {
+		if p.start != p.end {
+			buf = binary.AppendUvarint(buf, uint64(p.end-p.start))
+			buf = append(buf, c.vals[p.start:p.end]...)
+		}
+	}
+	c.sparse.offsets = buf
+	nw, err := w.Write(buf)
+	return int64(nw), err
+}
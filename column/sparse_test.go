@@ -1,6 +1,7 @@
 package column_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/vahid-sohrabloo/chconn/v3"
 	"github.com/vahid-sohrabloo/chconn/v3/column"
+	"github.com/vahid-sohrabloo/chconn/v3/internal/readerwriter"
 )
 
 func TestSparseColumn(
@@ -55,7 +57,12 @@ func TestSparseColumn(
 	colString.SetWriteBufferSize(10)
 
 	rows := 10
-	for range 2 {
+	for round := range 2 {
+		if round == 1 {
+			// the second block is sent with sparse serialization (6 of 10 rows are default values)
+			col.SetSparse(0.5)
+			colString.SetSparse(0.5)
+		}
 		for i := range rows {
 			val := int32(0)
 			valString := ""
@@ -113,3 +120,103 @@ func TestSparseColumn(
 		assert.Equal(t, colStringInsert, colStringData)
 	}
 }
+
+func TestSparseWriteRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	input := []int32{0, 0, 5, 0, 7, 0, 0}
+	col := column.New[int32]()
+	col.AppendMulti(input...)
+	colString := column.NewString()
+	for _, v := range input {
+		if v != 0 {
+			colString.Append(fmt.Sprint(v))
+		} else {
+			colString.Append("")
+		}
+	}
+
+	assert.False(t, col.UseSparse(0))
+	assert.True(t, col.UseSparse(0.7))
+	assert.False(t, col.UseSparse(0.8))
+	col.SetSparse(2)
+	assert.False(t, col.UseSparse(0.5))
+	col.SetSparse(0)
+	assert.True(t, col.UseSparse(0))
+
+	var buf bytes.Buffer
+	_, err := col.WriteSparseTo(&buf)
+	require.NoError(t, err)
+	// offsets: 2 defaults before 5, 1 before 7, 2 trailing with the end of granule flag
+	assert.Equal(t, []byte{2, 1, 0x82, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x40}, buf.Bytes()[:11])
+	assert.Equal(t, []byte{5, 0, 0, 0, 7, 0, 0, 0}, buf.Bytes()[11:])
+
+	colRead := column.New[int32]()
+	require.NoError(t, colRead.SetColumnHeader(column.ColumnHeader{ChType: []byte("Int32"), IsSparse: true}))
+	require.NoError(t, colRead.ReadHeader(readerwriter.NewReader(&buf), nil))
+	require.NoError(t, colRead.ReadRaw(len(input)))
+	assert.Equal(t, input, colRead.Data())
+
+	buf.Reset()
+	_, err = colString.WriteSparseTo(&buf)
+	require.NoError(t, err)
+	colStringRead := column.NewString()
+	require.NoError(t, colStringRead.SetColumnHeader(column.ColumnHeader{ChType: []byte("String"), IsSparse: true}))
+	require.NoError(t, colStringRead.ReadHeader(readerwriter.NewReader(&buf), nil))
+	require.NoError(t, colStringRead.ReadRaw(len(input)))
+	assert.Equal(t, colString.Data(), colStringRead.Data())
+}
+
+func TestSparseEnumSentDense(t *testing.T) {
+	t.Parallel()
+
+	col := column.New[int8]()
+	require.NoError(t, col.SetColumnHeader(column.ColumnHeader{ChType: []byte("Enum8('a' = -1, 'b' = 0)")}))
+	col.AppendMulti(0, 0, 0, -1)
+	col.SetSparse(0)
+	assert.False(t, col.UseSparse(0))
+
+	col16 := column.New[int16]()
+	require.NoError(t, col16.SetColumnHeader(column.ColumnHeader{ChType: []byte("Enum16('a' = -1, 'b' = 0)")}))
+	col16.SetSparse(0)
+	assert.False(t, col16.UseSparse(0))
+
+	nothing := column.NewNothing()
+	nothing.SetSparse(0)
+	assert.False(t, nothing.UseSparse(0))
+}
+
+func TestSparseEnumInsert(t *testing.T) {
+	tableName := "sparse_enum"
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := chconn.Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), fmt.Sprintf(`DROP TABLE IF EXISTS test_%s`, tableName))
+	require.NoError(t, err)
+	err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE test_%s (
+			e Enum8('a' = -1, 'b' = 0)
+		) ENGINE = MergeTree
+		ORDER BY tuple()
+		SETTINGS ratio_of_defaults_for_sparse_serialization = 0.1`, tableName))
+	require.NoError(t, err)
+
+	// every 'b' row has the zero value, but 'a' is the default of the type
+	input := []int8{0, 0, 0, -1, 0}
+	col := column.New[int8]()
+	col.AppendMulti(input...)
+	col.SetSparse(0)
+	err = conn.Insert(context.Background(), fmt.Sprintf(`INSERT INTO test_%s (e) VALUES`, tableName), col)
+	require.NoError(t, err)
+
+	colRead := column.New[int8]()
+	selectStmt, err := conn.Select(context.Background(), fmt.Sprintf(`SELECT e FROM test_%s`, tableName), colRead)
+	require.NoError(t, err)
+	var got []int8
+	for selectStmt.Next() {
+		got = colRead.Read(got)
+	}
+	require.NoError(t, selectStmt.Err())
+	assert.Equal(t, input, got)
+}
@@ -24,6 +24,7 @@ type StringBase[T ~string] struct {
 	vals                 []byte
 	pos                  []stringPos
 	sparseDataPos        []stringPos
+	sparse               sparseWrite
 	indexRemoveKeepIndex int
 }
 
@@ -109,6 +109,7 @@ func (c *Tuple) Row(row int) any {
 		for _, col := range c.columns {
 			ret[string(col.Name())] = col.RowAny(row)
 		}
+		return ret
 	}
 	ret := make([]any, len(c.columns))
 	for i, col := range c.columns {
@@ -0,0 +1,36 @@
+package column_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+func TestNamedTupleElementNames(t *testing.T) {
+	t.Parallel()
+
+	chType := []byte("Tuple(ab Int8, `c d` String)")
+	col, err := column.ColumnByType(chType, 0, false, false, "")
+	require.NoError(t, err)
+	require.NoError(t, col.SetColumnHeader(column.ColumnHeader{ChType: chType}))
+	require.NoError(t, col.AppendAny([]any{int8(1), "x"}))
+
+	assert.Equal(t, map[string]any{"ab": int8(1), "c d": "x"}, col.RowAny(0))
+	assert.Equal(t, `{"ab":1,"c d":"x"}`, string(col.ToJSON(0, false, nil)))
+}
+
+func TestEnum16LargeValues(t *testing.T) {
+	t.Parallel()
+
+	chType := []byte("Enum16('a' = -1000, 'b' = 1, 'c' = 1000)")
+	col, err := column.ColumnByType(chType, 0, false, false, "")
+	require.NoError(t, err)
+	require.NoError(t, col.SetColumnHeader(column.ColumnHeader{ChType: chType}))
+	require.NoError(t, col.AppendAny(int16(1000)))
+	require.NoError(t, col.AppendAny(int16(-1000)))
+
+	assert.Equal(t, `"c"`, string(col.ToJSON(0, false, nil)))
+	assert.Equal(t, `"a"`, string(col.ToJSON(1, false, nil)))
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"log/slog"
 	"maps"
 	"math"
 	"net"
@@ -31,6 +32,31 @@ const (
 	CompressChecksum CompressMethod = 0x02
 	CompressLZ4      CompressMethod = 0x82
 	CompressZSTD     CompressMethod = 0x90
+	CompressLZ4HC    CompressMethod = 0x83 // high-compression LZ4 encoder; the blocks are sent as LZ4
+)
+
+// Compression is a compression method and level. Level 0 uses the default level of the method:
+// 1 to 22 for ZSTD (default 3) and 1 to 9 for LZ4HC (default 9). LZ4 and CompressChecksum ignore
+// the level.
+//
+// The pure Go ZSTD encoder has four speeds, not 22 levels, so ZSTD levels are grouped: 1 and 2
+// use the fastest speed, 0 and 3 to 5 the default speed, 6 to 9 the better-compression speed and
+// 10 and above the best-compression speed. Levels in one group compress identically.
+type Compression struct {
+	Method CompressMethod
+	Level  int
+}
+
+// ChunkedMode is the preference for the chunked packet framing of one direction of the native
+// protocol. The values are the same as the proto_caps settings of the ClickHouse server.
+type ChunkedMode string
+
+// Possible chunked modes. The optional modes follow the server if it requires a framing.
+const (
+	Chunked            ChunkedMode = "chunked"
+	NotChunked         ChunkedMode = "notchunked"
+	ChunkedOptional    ChunkedMode = "chunked_optional"
+	NotChunkedOptional ChunkedMode = "notchunked_optional"
 )
 
 // AfterConnectFunc is called after a new connection is established. It can be used
@@ -47,6 +73,9 @@ type GetSSLPasswordFunc func(ctx context.Context) string
 // should be closed. Return true to close the connection (default behavior), or false to keep it open.
 type OnErrorFunc func(conn Conn, err error) bool
 
+// OnQueryDoneFunc is called when a query is finished.
+type OnQueryDoneFunc func(conn Conn, done *QueryDone)
+
 // Config is the settings used to establish a connection to a ClickHouse server. It must be created by [ParseConfig]. A
 // manually initialized Config will cause ConnectConfig to panic.
 type Config struct {
@@ -62,9 +91,20 @@ type Config struct {
 	LookupFunc        LookupFunc // e.g. net.Resolver.LookupHost
 	ReaderFunc        ReaderFunc // e.g. bufio.Reader
 	Compress          CompressMethod
+	CompressLevel     int // level of Compress, see Compression; 0 uses the default level
 	QuotaKey          string
 	WriterFunc        WriterFunc
 	MinReadBufferSize int
+	// Auth authenticates the connection: PasswordAuth (used if nil), SSHKeyAuth or JWTAuth.
+	Auth Authenticator
+	// SparseRatio enables sparse serialization on insert for the columns that support it (see
+	// column.SparseColumn): a column is sent sparse when the ratio of its rows with the default
+	// value is at least SparseRatio. Zero disables it. Columns can override it with SetSparse.
+	SparseRatio float64
+	// ChunkedSend and ChunkedRecv are the preferences for chunked packets sent to and received from
+	// the server. Default NotChunkedOptional: packets are not chunked unless the server requires it.
+	ChunkedSend ChunkedMode
+	ChunkedRecv ChunkedMode
 	// Run-time parameters to set on connection as session default values
 	RuntimeParams map[string]string
 
@@ -84,6 +124,17 @@ type Config struct {
 	// If nil, the connection is always closed on server errors.
 	OnError OnErrorFunc
 
+	// OnQueryDone is called when a query (select, exec or insert) is finished, successfully or not. It can be used
+	// to record metrics of the queries.
+	OnQueryDone OnQueryDoneFunc
+
+	// Tracer traces the queries. It can also implement InsertTracer, ConnectTracer and chpool.AcquireTracer.
+	Tracer Tracer
+
+	// Logger receives leveled, structured events of the connection lifecycle, connect retries and protocol
+	// anomalies. If nil, nothing is logged.
+	Logger *slog.Logger
+
 	createdByParseConfig bool // Used to enforce created by ParseConfig rule.
 
 	// Original connection string that was parsed into config.
@@ -186,6 +237,21 @@ func NetworkAddress(host string, port uint16) (network, address string) {
 //	     in the "checksum" chconn checks the checksum and not use any compress method.
 //		quota_key
 //			the quota key.
+//		proto_send_chunked, proto_recv_chunked
+//			chunked packet framing for each direction: "chunked", "notchunked", "chunked_optional" or
+//			"notchunked_optional" (default).
+//		ssh_key
+//			path of a private key file to authenticate the user with SSH key authentication instead of a password.
+//			The file is loaded by the loader of RegisterSSHKeyLoader; import the sshkey package for one.
+//		ssh_key_passphrase
+//			passphrase of an encrypted ssh_key.
+//		jwt
+//			JSON Web Token to authenticate with JWT authentication (ClickHouse Cloud).
+//		jwt_file
+//			path of a file with the JSON Web Token. It is read again for each new connection.
+//		sparse_ratio
+//			ratio of default values from which columns are sent with sparse serialization on insert.
+//			Default 0 (disabled).
 func ParseConfig(connString string) (*Config, error) {
 	var parseConfigOptions ParseConfigOptions
 	return ParseConfigWithOptions(connString, parseConfigOptions)
@@ -239,10 +305,44 @@ func ParseConfigWithOptions(connString string, options ParseConfigOptions) (*Con
 		config.Compress = CompressLZ4
 	case "zstd":
 		config.Compress = CompressZSTD
+	case "lz4hc":
+		config.Compress = CompressLZ4HC
+	}
+
+	if compressLevel, present := settings["compress_level"]; present {
+		config.CompressLevel, err = strconv.Atoi(compressLevel)
+		if err != nil || config.CompressLevel < 0 {
+			return nil, &parseConfigError{connString: connString, msg: "invalid compress_level", err: err}
+		}
 	}
 
 	config.QuotaKey = settings["quota_key"]
 
+	for key, mode := range map[string]*ChunkedMode{
+		"proto_send_chunked": &config.ChunkedSend,
+		"proto_recv_chunked": &config.ChunkedRecv,
+	} {
+		switch v := ChunkedMode(settings[key]); v {
+		case "":
+		case Chunked, NotChunked, ChunkedOptional, NotChunkedOptional:
+			*mode = v
+		default:
+			return nil, &parseConfigError{connString: connString, msg: "invalid " + key}
+		}
+	}
+
+	config.Auth, err = parseAuthSettings(settings)
+	if err != nil {
+		return nil, &parseConfigError{connString: connString, msg: "invalid authentication", err: err}
+	}
+
+	if sparseRatio, present := settings["sparse_ratio"]; present {
+		config.SparseRatio, err = strconv.ParseFloat(sparseRatio, 64)
+		if err != nil || config.SparseRatio < 0 {
+			return nil, &parseConfigError{connString: connString, msg: "invalid sparse_ratio", err: err}
+		}
+	}
+
 	if connectTimeoutSetting, present := settings["connect_timeout"]; present {
 		connectTimeout, err := parseConnectTimeoutSetting(connectTimeoutSetting)
 		if err != nil {
@@ -273,7 +373,15 @@ func ParseConfigWithOptions(connString string, options ParseConfigOptions) (*Con
 		"sslpassword":          {},
 		"sslsni":               {},
 		"compress":             {},
+		"compress_level":       {},
 		"quota_key":            {},
+		"sparse_ratio":         {},
+		"proto_send_chunked":   {},
+		"proto_recv_chunked":   {},
+		"ssh_key":              {},
+		"ssh_key_passphrase":   {},
+		"jwt":                  {},
+		"jwt_file":             {},
 	}
 
 	for k, v := range settings {
@@ -690,6 +798,38 @@ func makeDefaultResolver() *net.Resolver {
 	return net.DefaultResolver
 }
 
+// parseAuthSettings returns the Authenticator selected by the ssh_key, jwt and jwt_file settings,
+// or nil for password authentication.
+func parseAuthSettings(settings map[string]string) (Authenticator, error) {
+	sshKey, jwt, jwtFile := settings["ssh_key"], settings["jwt"], settings["jwt_file"]
+	set := 0
+	for _, v := range []string{sshKey, jwt, jwtFile} {
+		if v != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return nil, errors.New("only one of ssh_key, jwt and jwt_file can be set")
+	}
+
+	switch {
+	case sshKey != "":
+		if sshKeyLoader == nil {
+			return nil, errors.New("ssh_key needs an SSH key loader: import github.com/vahid-sohrabloo/chconn/v3/sshkey")
+		}
+		signer, err := sshKeyLoader(sshKey, settings["ssh_key_passphrase"])
+		if err != nil {
+			return nil, err
+		}
+		return &SSHKeyAuth{Signer: signer}, nil
+	case jwt != "":
+		return StaticJWTAuth(jwt), nil
+	case jwtFile != "":
+		return JWTAuthFromFile(jwtFile), nil
+	}
+	return nil, nil
+}
+
 func parseConnectTimeoutSetting(s string) (time.Duration, error) {
 	timeout, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
@@ -501,6 +501,50 @@ func TestParseConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "sparse ratio",
+			connString: "user=vahid host=foo dbname=mydb sslmode=disable sparse_ratio=0.9",
+			config: &Config{
+				User:          "vahid",
+				Host:          "foo",
+				Port:          9000,
+				Database:      "mydb",
+				ClientName:    defaultClientName,
+				TLSConfig:     nil,
+				RuntimeParams: map[string]string{},
+				SparseRatio:   0.9,
+			},
+		},
+		{
+			name:       "compress level",
+			connString: "user=vahid host=foo dbname=mydb sslmode=disable compress=lz4hc compress_level=6",
+			config: &Config{
+				User:          "vahid",
+				Host:          "foo",
+				Port:          9000,
+				Database:      "mydb",
+				Compress:      CompressLZ4HC,
+				CompressLevel: 6,
+				ClientName:    defaultClientName,
+				TLSConfig:     nil,
+				RuntimeParams: map[string]string{},
+			},
+		},
+		{
+			name:       "chunked packets",
+			connString: "clickhouse://vahid@foo/mydb?sslmode=disable&proto_send_chunked=chunked&proto_recv_chunked=chunked_optional",
+			config: &Config{
+				User:          "vahid",
+				Host:          "foo",
+				Port:          9000,
+				Database:      "mydb",
+				ClientName:    defaultClientName,
+				TLSConfig:     nil,
+				RuntimeParams: map[string]string{},
+				ChunkedSend:   Chunked,
+				ChunkedRecv:   ChunkedOptional,
+			},
+		},
 	}
 
 	for i, tt := range test {
@@ -583,6 +627,9 @@ func assertConfigsEqual(t *testing.T, expected, actual *Config, testName string)
 	assert.Equalf(t, expected.ConnectTimeout, actual.ConnectTimeout, "%s - ConnectTimeout", testName)
 	assert.Equalf(t, expected.ClientName, actual.ClientName, "%s - Client Name", testName)
 	assert.Equalf(t, expected.RuntimeParams, actual.RuntimeParams, "%s - RuntimeParams", testName)
+	assert.Equalf(t, expected.SparseRatio, actual.SparseRatio, "%s - SparseRatio", testName)
+	assert.Equalf(t, expected.ChunkedSend, actual.ChunkedSend, "%s - ChunkedSend", testName)
+	assert.Equalf(t, expected.ChunkedRecv, actual.ChunkedRecv, "%s - ChunkedRecv", testName)
 
 	// Can't test function equality, so just test that they are set or not.
 	assert.Equalf(t, expected.ValidateConnect == nil, actual.ValidateConnect == nil, "%s - ValidateConnect", testName)
@@ -792,6 +839,26 @@ func TestParseConfigError(t *testing.T) {
 			name:       "negative connect_timeout",
 			connString: "connect_timeout=-100",
 			err:        "cannot parse `connect_timeout=-100`: invalid connect_timeout (negative timeout)",
+		}, {
+			name:       "invalid sparse_ratio",
+			connString: "sparse_ratio=-1",
+			err:        "cannot parse `sparse_ratio=-1`: invalid sparse_ratio",
+		}, {
+			name:       "invalid compress_level",
+			connString: "compress_level=-1",
+			err:        "cannot parse `compress_level=-1`: invalid compress_level",
+		}, {
+			name:       "invalid proto_send_chunked",
+			connString: "proto_send_chunked=yes",
+			err:        "cannot parse `proto_send_chunked=yes`: invalid proto_send_chunked",
+		}, {
+			name:       "ssh_key without a loader",
+			connString: "ssh_key=invalid_address",
+			err:        "cannot parse `ssh_key=invalid_address`: invalid authentication (ssh_key needs an SSH key loader: import github.com/vahid-sohrabloo/chconn/v3/sshkey)", //nolint:lll //can't change line length
+		}, {
+			name:       "both jwt and ssh_key",
+			connString: "jwt=token ssh_key=invalid_address",
+			err:        "cannot parse `jwt=xxxxx ssh_key=invalid_address`: invalid authentication (only one of ssh_key, jwt and jwt_file can be set)", //nolint:lll //can't change line length
 		}, {
 			name:       "negative sslmode",
 			connString: "sslmode=invalid",
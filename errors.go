@@ -245,19 +245,29 @@ func (e *writeError) Unwrap() error {
 	return e.err
 }
 
+// secretSettings are the connection string settings whose values redactPW masks.
+const secretSettings = `password|jwt|ssh_key_passphrase`
+
+var (
+	quotedSecretDSN = regexp.MustCompile(`\b(` + secretSettings + `)\s*=\s*'(?:[^'\\]|\\.)*'`)
+	plainSecretDSN  = regexp.MustCompile(`\b(` + secretSettings + `)\s*=\s*(?:[^\s\\]|\\.)*`)
+	secretQuery     = regexp.MustCompile(`(^|[?&])(` + secretSettings + `)=[^&]*`)
+	brokenURL       = regexp.MustCompile(`:[^:@]+?@`)
+)
+
+// redactPW masks the password, jwt and ssh_key_passphrase values of a connection string in
+// keyword/value or URL form, including the password of the URL user info.
 func redactPW(connString string) string {
 	if strings.HasPrefix(connString, "clickhouse://") {
 		if u, err := url.Parse(connString); err == nil {
 			return redactURL(u)
 		}
+		connString = secretQuery.ReplaceAllString(connString, "${1}${2}=xxxxx")
+	} else {
+		connString = quotedSecretDSN.ReplaceAllString(connString, "${1}=xxxxx")
+		connString = plainSecretDSN.ReplaceAllString(connString, "${1}=xxxxx")
 	}
-	quotedDSN := regexp.MustCompile(`password='[^']*'`)
-	connString = quotedDSN.ReplaceAllLiteralString(connString, "password=xxxxx")
-	plainDSN := regexp.MustCompile(`password=[^ ]*`)
-	connString = plainDSN.ReplaceAllLiteralString(connString, "password=xxxxx")
-	brokenURL := regexp.MustCompile(`:[^:@]+?@`)
-	connString = brokenURL.ReplaceAllLiteralString(connString, ":xxxxxx@")
-	return connString
+	return brokenURL.ReplaceAllLiteralString(connString, ":xxxxxx@")
 }
 
 func redactURL(u *url.URL) string {
@@ -267,6 +277,7 @@ func redactURL(u *url.URL) string {
 	if _, pwSet := u.User.Password(); pwSet {
 		u.User = url.UserPassword(u.User.Username(), "xxxxx")
 	}
+	u.RawQuery = secretQuery.ReplaceAllString(u.RawQuery, "${1}${2}=xxxxx")
 	return u.String()
 }
 
@@ -286,6 +297,26 @@ func (e *InsertError) Unwrap() error {
 	return e.err
 }
 
+// ScriptError represents an error of a statement of a script run by ExecScript
+type ScriptError struct {
+	// Statement is the index of the failed statement in the script.
+	Statement int
+	// Line is the line of the script the failed statement starts on, starting from 1.
+	Line  int
+	Query string
+	Err   error
+}
+
+// Error return string error
+func (e *ScriptError) Error() string {
+	return fmt.Sprintf("script: statement %d (line %d): %v", e.Statement+1, e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ScriptError) Unwrap() error {
+	return e.Err
+}
+
 // ColumnNumberReadError represents an error when read more or less column
 type ColumnNumberReadError struct {
 	Read      int
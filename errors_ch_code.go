@@ -1,5 +1,7 @@
 package chconn
 
+//go:generate go run golang.org/x/tools/cmd/stringer@v0.48.0 -type=ChErrorType -linecomment -output=errors_ch_code_string.go
+
 // ChErrorType represents a ClickHouse server error code.
 // See the ClickHouse source code for the full list of error codes.
 type ChErrorType int32
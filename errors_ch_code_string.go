@@ -0,0 +1,1227 @@
+// Code generated by "stringer -type=ChErrorType -linecomment -output=errors_ch_code_string.go"; DO NOT EDIT.
+
+package chconn
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[ChErrorOk-0]
+	_ = x[ChErrorUnsupportedMethod-1]
+	_ = x[ChErrorUnsupportedParameter-2]
+	_ = x[ChErrorUnexpectedEndOfFile-3]
+	_ = x[ChErrorExpectedEndOfFile-4]
+	_ = x[ChErrorCannotParseText-6]
+	_ = x[ChErrorIncorrectNumberOfColumns-7]
+	_ = x[ChErrorThereIsNoColumn-8]
+	_ = x[ChErrorSizesOfColumnsDoesntMatch-9]
+	_ = x[ChErrorNotFoundColumnInBlock-10]
+	_ = x[ChErrorPositionOutOfBound-11]
+	_ = x[ChErrorParameterOutOfBound-12]
+	_ = x[ChErrorSizesOfColumnsInTupleDoesntMatch-13]
+	_ = x[ChErrorDuplicateColumn-15]
+	_ = x[ChErrorNoSuchColumnInTable-16]
+	_ = x[ChErrorDelimiterInStringLiteralDoesntMatch-17]
+	_ = x[ChErrorCannotInsertElementIntoConstantColumn-18]
+	_ = x[ChErrorSizeOfFixedStringDoesntMatch-19]
+	_ = x[ChErrorNumberOfColumnsDoesntMatch-20]
+	_ = x[ChErrorCannotReadAllDataFromTabSeparatedInput-21]
+	_ = x[ChErrorCannotParseAllValueFromTabSeparatedInput-22]
+	_ = x[ChErrorCannotReadFromIstream-23]
+	_ = x[ChErrorCannotWriteToOstream-24]
+	_ = x[ChErrorCannotParseEscapeSequence-25]
+	_ = x[ChErrorCannotParseQuotedString-26]
+	_ = x[ChErrorCannotParseInputAssertionFailed-27]
+	_ = x[ChErrorCannotPrintFloatOrDoubleNumber-28]
+	_ = x[ChErrorCannotPrintInteger-29]
+	_ = x[ChErrorCannotReadSizeOfCompressedChunk-30]
+	_ = x[ChErrorCannotReadCompressedChunk-31]
+	_ = x[ChErrorAttemptToReadAfterEOF-32]
+	_ = x[ChErrorCannotReadAllData-33]
+	_ = x[ChErrorTooManyArgumentsForFunction-34]
+	_ = x[ChErrorTooFewArgumentsForFunction-35]
+	_ = x[ChErrorBadArguments-36]
+	_ = x[ChErrorUnknownElementInAst-37]
+	_ = x[ChErrorCannotParseDate-38]
+	_ = x[ChErrorTooLargeSizeCompressed-39]
+	_ = x[ChErrorChecksumDoesntMatch-40]
+	_ = x[ChErrorCannotParseDatetime-41]
+	_ = x[ChErrorNumberOfArgumentsDoesntMatch-42]
+	_ = x[ChErrorIllegalTypeOfArgument-43]
+	_ = x[ChErrorIllegalColumn-44]
+	_ = x[ChErrorIllegalNumberOfResultColumns-45]
+	_ = x[ChErrorUnknownFunction-46]
+	_ = x[ChErrorUnknownIdentifier-47]
+	_ = x[ChErrorNotImplemented-48]
+	_ = x[ChErrorLogicalError-49]
+	_ = x[ChErrorUnknownType-50]
+	_ = x[ChErrorEmptyListOfColumnsQueried-51]
+	_ = x[ChErrorColumnQueriedMoreThanOnce-52]
+	_ = x[ChErrorTypeMismatch-53]
+	_ = x[ChErrorStorageDoesntAllowParameters-54]
+	_ = x[ChErrorStorageRequiresParameter-55]
+	_ = x[ChErrorUnknownStorage-56]
+	_ = x[ChErrorTableAlreadyExists-57]
+	_ = x[ChErrorTableMetadataAlreadyExists-58]
+	_ = x[ChErrorIllegalTypeOfColumnForFilter-59]
+	_ = x[ChErrorUnknownTable-60]
+	_ = x[ChErrorOnlyFilterColumnInBlock-61]
+	_ = x[ChErrorSyntaxError-62]
+	_ = x[ChErrorUnknownAggregateFunction-63]
+	_ = x[ChErrorCannotReadAggregateFunctionFromText-64]
+	_ = x[ChErrorCannotWriteAggregateFunctionAsText-65]
+	_ = x[ChErrorNotAColumn-66]
+	_ = x[ChErrorIllegalKeyOfAggregation-67]
+	_ = x[ChErrorCannotGetSizeOfField-68]
+	_ = x[ChErrorArgumentOutOfBound-69]
+	_ = x[ChErrorCannotConvertType-70]
+	_ = x[ChErrorCannotWriteAfterEndOfBuffer-71]
+	_ = x[ChErrorCannotParseNumber-72]
+	_ = x[ChErrorUnknownFormat-73]
+	_ = x[ChErrorCannotReadFromFileDescriptor-74]
+	_ = x[ChErrorCannotWriteToFileDescriptor-75]
+	_ = x[ChErrorCannotOpenFile-76]
+	_ = x[ChErrorCannotCloseFile-77]
+	_ = x[ChErrorUnknownTypeOfQuery-78]
+	_ = x[ChErrorIncorrectFileName-79]
+	_ = x[ChErrorIncorrectQuery-80]
+	_ = x[ChErrorUnknownDatabase-81]
+	_ = x[ChErrorDatabaseAlreadyExists-82]
+	_ = x[ChErrorDirectoryDoesntExist-83]
+	_ = x[ChErrorDirectoryAlreadyExists-84]
+	_ = x[ChErrorFormatIsNotSuitableForInput-85]
+	_ = x[ChErrorReceivedErrorFromRemoteIoServer-86]
+	_ = x[ChErrorCannotSeekThroughFile-87]
+	_ = x[ChErrorCannotTruncateFile-88]
+	_ = x[ChErrorUnknownCompressionMethod-89]
+	_ = x[ChErrorEmptyListOfColumnsPassed-90]
+	_ = x[ChErrorSizesOfMarksFilesAreInconsistent-91]
+	_ = x[ChErrorEmptyDataPassed-92]
+	_ = x[ChErrorUnknownAggregatedDataVariant-93]
+	_ = x[ChErrorCannotMergeDifferentAggregatedDataVariants-94]
+	_ = x[ChErrorCannotReadFromSocket-95]
+	_ = x[ChErrorCannotWriteToSocket-96]
+	_ = x[ChErrorCannotReadAllDataFromChunkedInput-97]
+	_ = x[ChErrorCannotWriteToEmptyBlockOutputStream-98]
+	_ = x[ChErrorUnknownPacketFromClient-99]
+	_ = x[ChErrorUnknownPacketFromServer-100]
+	_ = x[ChErrorUnexpectedPacketFromClient-101]
+	_ = x[ChErrorUnexpectedPacketFromServer-102]
+	_ = x[ChErrorReceivedDataForWrongQueryID-103]
+	_ = x[ChErrorTooSmallBufferSize-104]
+	_ = x[ChErrorCannotReadHistory-105]
+	_ = x[ChErrorCannotAppendHistory-106]
+	_ = x[ChErrorFileDoesntExist-107]
+	_ = x[ChErrorNoDataToInsert-108]
+	_ = x[ChErrorCannotBlockSignal-109]
+	_ = x[ChErrorCannotUnblockSignal-110]
+	_ = x[ChErrorCannotManipulateSigset-111]
+	_ = x[ChErrorCannotWaitForSignal-112]
+	_ = x[ChErrorThereIsNoSession-113]
+	_ = x[ChErrorCannotClockGettime-114]
+	_ = x[ChErrorUnknownSetting-115]
+	_ = x[ChErrorThereIsNoDefaultValue-116]
+	_ = x[ChErrorIncorrectData-117]
+	_ = x[ChErrorEngineRequired-119]
+	_ = x[ChErrorCannotInsertValueOfDifferentSizeIntoTuple-120]
+	_ = x[ChErrorUnsupportedJoinKeys-121]
+	_ = x[ChErrorIncompatibleColumns-122]
+	_ = x[ChErrorUnknownTypeOfAstNode-123]
+	_ = x[ChErrorIncorrectElementOfSet-124]
+	_ = x[ChErrorIncorrectResultOfScalarSubquery-125]
+	_ = x[ChErrorCannotGetReturnType-126]
+	_ = x[ChErrorIllegalIndex-127]
+	_ = x[ChErrorTooLargeArraySize-128]
+	_ = x[ChErrorFunctionIsSpecial-129]
+	_ = x[ChErrorCannotReadArrayFromText-130]
+	_ = x[ChErrorTooLargeStringSize-131]
+	_ = x[ChErrorAggregateFunctionDoesntAllowParameters-133]
+	_ = x[ChErrorParametersToAggregateFunctionsMustBeLiterals-134]
+	_ = x[ChErrorZeroArrayOrTupleIndex-135]
+	_ = x[ChErrorUnknownElementInConfig-137]
+	_ = x[ChErrorExcessiveElementInConfig-138]
+	_ = x[ChErrorNoElementsInConfig-139]
+	_ = x[ChErrorAllRequestedColumnsAreMissing-140]
+	_ = x[ChErrorSamplingNotSupported-141]
+	_ = x[ChErrorNotFoundNode-142]
+	_ = x[ChErrorFoundMoreThanOneNode-143]
+	_ = x[ChErrorFirstDateIsBiggerThanLastDate-144]
+	_ = x[ChErrorUnknownOverflowMode-145]
+	_ = x[ChErrorQuerySectionDoesntMakeSense-146]
+	_ = x[ChErrorNotFoundFunctionElementForAggregate-147]
+	_ = x[ChErrorNotFoundRelationElementForCondition-148]
+	_ = x[ChErrorNotFoundRhsElementForCondition-149]
+	_ = x[ChErrorEmptyListOfAttributesPassed-150]
+	_ = x[ChErrorIndexOfColumnInSortClauseIsOutOfRange-151]
+	_ = x[ChErrorUnknownDirectionOfSorting-152]
+	_ = x[ChErrorIllegalDivision-153]
+	_ = x[ChErrorAggregateFunctionNotApplicable-154]
+	_ = x[ChErrorUnknownRelation-155]
+	_ = x[ChErrorDictionariesWasNotLoaded-156]
+	_ = x[ChErrorIllegalOverflowMode-157]
+	_ = x[ChErrorTooManyRows-158]
+	_ = x[ChErrorTimeoutExceeded-159]
+	_ = x[ChErrorTooSlow-160]
+	_ = x[ChErrorTooManyColumns-161]
+	_ = x[ChErrorTooDeepSubqueries-162]
+	_ = x[ChErrorTooDeepPipeline-163]
+	_ = x[ChErrorReadonly-164]
+	_ = x[ChErrorTooManyTemporaryColumns-165]
+	_ = x[ChErrorTooManyTemporaryNonConstColumns-166]
+	_ = x[ChErrorTooDeepAst-167]
+	_ = x[ChErrorTooBigAst-168]
+	_ = x[ChErrorBadTypeOfField-169]
+	_ = x[ChErrorBadGet-170]
+	_ = x[ChErrorCannotCreateDirectory-172]
+	_ = x[ChErrorCannotAllocateMemory-173]
+	_ = x[ChErrorCyclicAliases-174]
+	_ = x[ChErrorChunkNotFound-176]
+	_ = x[ChErrorDuplicateChunkName-177]
+	_ = x[ChErrorMultipleAliasesForExpression-178]
+	_ = x[ChErrorMultipleExpressionsForAlias-179]
+	_ = x[ChErrorThereIsNoProfile-180]
+	_ = x[ChErrorIllegalFinal-181]
+	_ = x[ChErrorIllegalPrewhere-182]
+	_ = x[ChErrorUnexpectedExpression-183]
+	_ = x[ChErrorIllegalAggregation-184]
+	_ = x[ChErrorUnsupportedMyisamBlockType-185]
+	_ = x[ChErrorUnsupportedCollationLocale-186]
+	_ = x[ChErrorCollationComparisonFailed-187]
+	_ = x[ChErrorUnknownAction-188]
+	_ = x[ChErrorTableMustNotBeCreatedManually-189]
+	_ = x[ChErrorSizesOfArraysDoesntMatch-190]
+	_ = x[ChErrorSetSizeLimitExceeded-191]
+	_ = x[ChErrorUnknownUser-192]
+	_ = x[ChErrorWrongPassword-193]
+	_ = x[ChErrorRequiredPassword-194]
+	_ = x[ChErrorIPAddressNotAllowed-195]
+	_ = x[ChErrorUnknownAddressPatternType-196]
+	_ = x[ChErrorServerRevisionIsTooOld-197]
+	_ = x[ChErrorDNSError-198]
+	_ = x[ChErrorUnknownQuota-199]
+	_ = x[ChErrorQuotaDoesntAllowKeys-200]
+	_ = x[ChErrorQuotaExpired-201]
+	_ = x[ChErrorTooManySimultaneousQueries-202]
+	_ = x[ChErrorNoFreeConnection-203]
+	_ = x[ChErrorCannotFsync-204]
+	_ = x[ChErrorNestedTypeTooDeep-205]
+	_ = x[ChErrorAliasRequired-206]
+	_ = x[ChErrorAmbiguousIdentifier-207]
+	_ = x[ChErrorEmptyNestedTable-208]
+	_ = x[ChErrorSocketTimeout-209]
+	_ = x[ChErrorNetworkError-210]
+	_ = x[ChErrorEmptyQuery-211]
+	_ = x[ChErrorUnknownLoadBalancing-212]
+	_ = x[ChErrorUnknownTotalsMode-213]
+	_ = x[ChErrorCannotStatvfs-214]
+	_ = x[ChErrorNotAnAggregate-215]
+	_ = x[ChErrorQueryWithSameIDIsAlreadyRunning-216]
+	_ = x[ChErrorClientHasConnectedToWrongPort-217]
+	_ = x[ChErrorTableIsDropped-218]
+	_ = x[ChErrorDatabaseNotEmpty-219]
+	_ = x[ChErrorDuplicateInterserverIoEndpoint-220]
+	_ = x[ChErrorNoSuchInterserverIoEndpoint-221]
+	_ = x[ChErrorAddingReplicaToNonEmptyTable-222]
+	_ = x[ChErrorUnexpectedAstStructure-223]
+	_ = x[ChErrorReplicaIsAlreadyActive-224]
+	_ = x[ChErrorNoZookeeper-225]
+	_ = x[ChErrorNoFileInDataPart-226]
+	_ = x[ChErrorUnexpectedFileInDataPart-227]
+	_ = x[ChErrorBadSizeOfFileInDataPart-228]
+	_ = x[ChErrorQueryIsTooLarge-229]
+	_ = x[ChErrorNotFoundExpectedDataPart-230]
+	_ = x[ChErrorTooManyUnexpectedDataParts-231]
+	_ = x[ChErrorNoSuchDataPart-232]
+	_ = x[ChErrorBadDataPartName-233]
+	_ = x[ChErrorNoReplicaHasPart-234]
+	_ = x[ChErrorDuplicateDataPart-235]
+	_ = x[ChErrorAborted-236]
+	_ = x[ChErrorNoReplicaNameGiven-237]
+	_ = x[ChErrorFormatVersionTooOld-238]
+	_ = x[ChErrorCannotMunmap-239]
+	_ = x[ChErrorCannotMremap-240]
+	_ = x[ChErrorMemoryLimitExceeded-241]
+	_ = x[ChErrorTableIsReadOnly-242]
+	_ = x[ChErrorNotEnoughSpace-243]
+	_ = x[ChErrorUnexpectedZookeeperError-244]
+	_ = x[ChErrorCorruptedData-246]
+	_ = x[ChErrorIncorrectMark-247]
+	_ = x[ChErrorInvalidPartitionValue-248]
+	_ = x[ChErrorNotEnoughBlockNumbers-250]
+	_ = x[ChErrorNoSuchReplica-251]
+	_ = x[ChErrorTooManyParts-252]
+	_ = x[ChErrorReplicaIsAlreadyExist-253]
+	_ = x[ChErrorNoActiveReplicas-254]
+	_ = x[ChErrorTooManyRetriesToFetchParts-255]
+	_ = x[ChErrorPartitionAlreadyExists-256]
+	_ = x[ChErrorPartitionDoesntExist-257]
+	_ = x[ChErrorUnionAllResultStructuresMismatch-258]
+	_ = x[ChErrorClientOutputFormatSpecified-260]
+	_ = x[ChErrorUnknownBlockInfoField-261]
+	_ = x[ChErrorBadCollation-262]
+	_ = x[ChErrorCannotCompileCode-263]
+	_ = x[ChErrorIncompatibleTypeOfJoin-264]
+	_ = x[ChErrorNoAvailableReplica-265]
+	_ = x[ChErrorMismatchReplicasDataSources-266]
+	_ = x[ChErrorStorageDoesntSupportParallelReplicas-267]
+	_ = x[ChErrorCpuidError-268]
+	_ = x[ChErrorInfiniteLoop-269]
+	_ = x[ChErrorCannotCompress-270]
+	_ = x[ChErrorCannotDecompress-271]
+	_ = x[ChErrorCannotIoSubmit-272]
+	_ = x[ChErrorCannotIoGetevents-273]
+	_ = x[ChErrorAioReadError-274]
+	_ = x[ChErrorAioWriteError-275]
+	_ = x[ChErrorIndexNotUsed-277]
+	_ = x[ChErrorAllConnectionTriesFailed-279]
+	_ = x[ChErrorNoAvailableData-280]
+	_ = x[ChErrorDictionaryIsEmpty-281]
+	_ = x[ChErrorIncorrectIndex-282]
+	_ = x[ChErrorUnknownDistributedProductMode-283]
+	_ = x[ChErrorWrongGlobalSubquery-284]
+	_ = x[ChErrorTooFewLiveReplicas-285]
+	_ = x[ChErrorUnsatisfiedQuorumForPreviousWrite-286]
+	_ = x[ChErrorUnknownFormatVersion-287]
+	_ = x[ChErrorDistributedInJoinSubqueryDenied-288]
+	_ = x[ChErrorReplicaIsNotInQuorum-289]
+	_ = x[ChErrorLimitExceeded-290]
+	_ = x[ChErrorDatabaseAccessDenied-291]
+	_ = x[ChErrorMongodbCannotAuthenticate-293]
+	_ = x[ChErrorInvalidBlockExtraInfo-294]
+	_ = x[ChErrorReceivedEmptyData-295]
+	_ = x[ChErrorNoRemoteShardFound-296]
+	_ = x[ChErrorShardHasNoConnections-297]
+	_ = x[ChErrorCannotPipe-298]
+	_ = x[ChErrorCannotFork-299]
+	_ = x[ChErrorCannotDlsym-300]
+	_ = x[ChErrorCannotCreateChildProcess-301]
+	_ = x[ChErrorChildWasNotExitedNormally-302]
+	_ = x[ChErrorCannotSelect-303]
+	_ = x[ChErrorCannotWaitpid-304]
+	_ = x[ChErrorTableWasNotDropped-305]
+	_ = x[ChErrorTooDeepRecursion-306]
+	_ = x[ChErrorTooManyBytes-307]
+	_ = x[ChErrorUnexpectedNodeInZookeeper-308]
+	_ = x[ChErrorFunctionCannotHaveParameters-309]
+	_ = x[ChErrorInvalidShardWeight-317]
+	_ = x[ChErrorInvalidConfigParameter-318]
+	_ = x[ChErrorUnknownStatusOfInsert-319]
+	_ = x[ChErrorValueIsOutOfRangeOfDataType-321]
+	_ = x[ChErrorBarrierTimeout-335]
+	_ = x[ChErrorUnknownDatabaseEngine-336]
+	_ = x[ChErrorDdlGuardIsActive-337]
+	_ = x[ChErrorUnfinished-341]
+	_ = x[ChErrorMetadataMismatch-342]
+	_ = x[ChErrorSupportIsDisabled-344]
+	_ = x[ChErrorTableDiffersTooMuch-345]
+	_ = x[ChErrorCannotConvertCharset-346]
+	_ = x[ChErrorCannotLoadConfig-347]
+	_ = x[ChErrorCannotInsertNullInOrdinaryColumn-349]
+	_ = x[ChErrorIncompatibleSourceTables-350]
+	_ = x[ChErrorAmbiguousTableName-351]
+	_ = x[ChErrorAmbiguousColumnName-352]
+	_ = x[ChErrorIndexOfPositionalArgumentIsOutOfRange-353]
+	_ = x[ChErrorZlibInflateFailed-354]
+	_ = x[ChErrorZlibDeflateFailed-355]
+	_ = x[ChErrorBadLambda-356]
+	_ = x[ChErrorReservedIdentifierName-357]
+	_ = x[ChErrorIntoOutfileNotAllowed-358]
+	_ = x[ChErrorTableSizeExceedsMaxDropSizeLimit-359]
+	_ = x[ChErrorCannotCreateCharsetConverter-360]
+	_ = x[ChErrorSeekPositionOutOfBound-361]
+	_ = x[ChErrorCurrentWriteBufferIsExhausted-362]
+	_ = x[ChErrorCannotCreateIoBuffer-363]
+	_ = x[ChErrorReceivedErrorTooManyRequests-364]
+	_ = x[ChErrorSizesOfNestedColumnsAreInconsistent-366]
+	_ = x[ChErrorTooManyFetches-367]
+	_ = x[ChErrorAllReplicasAreStale-369]
+	_ = x[ChErrorDataTypeCannotBeUsedInTables-370]
+	_ = x[ChErrorInconsistentClusterDefinition-371]
+	_ = x[ChErrorSessionNotFound-372]
+	_ = x[ChErrorSessionIsLocked-373]
+	_ = x[ChErrorInvalidSessionTimeout-374]
+	_ = x[ChErrorCannotDlopen-375]
+	_ = x[ChErrorCannotParseUUID-376]
+	_ = x[ChErrorIllegalSyntaxForDataType-377]
+	_ = x[ChErrorDataTypeCannotHaveArguments-378]
+	_ = x[ChErrorUnknownStatusOfDistributedDdlTask-379]
+	_ = x[ChErrorCannotKill-380]
+	_ = x[ChErrorHTTPLengthRequired-381]
+	_ = x[ChErrorCannotLoadCatboostModel-382]
+	_ = x[ChErrorCannotApplyCatboostModel-383]
+	_ = x[ChErrorPartIsTemporarilyLocked-384]
+	_ = x[ChErrorMultipleStreamsRequired-385]
+	_ = x[ChErrorNoCommonType-386]
+	_ = x[ChErrorDictionaryAlreadyExists-387]
+	_ = x[ChErrorCannotAssignOptimize-388]
+	_ = x[ChErrorInsertWasDeduplicated-389]
+	_ = x[ChErrorCannotGetCreateTableQuery-390]
+	_ = x[ChErrorExternalLibraryError-391]
+	_ = x[ChErrorQueryIsProhibited-392]
+	_ = x[ChErrorThereIsNoQuery-393]
+	_ = x[ChErrorQueryWasCancelled-394]
+	_ = x[ChErrorFunctionThrowIfValueIsNonZero-395]
+	_ = x[ChErrorTooManyRowsOrBytes-396]
+	_ = x[ChErrorQueryIsNotSupportedInMaterializedView-397]
+	_ = x[ChErrorUnknownMutationCommand-398]
+	_ = x[ChErrorFormatIsNotSuitableForOutput-399]
+	_ = x[ChErrorCannotStat-400]
+	_ = x[ChErrorFeatureIsNotEnabledAtBuildTime-401]
+	_ = x[ChErrorCannotIosetup-402]
+	_ = x[ChErrorInvalidJoinOnExpression-403]
+	_ = x[ChErrorBadOdbcConnectionString-404]
+	_ = x[ChErrorPartitionSizeExceedsMaxDropSizeLimit-405]
+	_ = x[ChErrorTopAndLimitTogether-406]
+	_ = x[ChErrorDecimalOverflow-407]
+	_ = x[ChErrorBadRequestParameter-408]
+	_ = x[ChErrorExternalExecutableNotFound-409]
+	_ = x[ChErrorExternalServerIsNotResponding-410]
+	_ = x[ChErrorPthreadError-411]
+	_ = x[ChErrorNetlinkError-412]
+	_ = x[ChErrorCannotSetSignalHandler-413]
+	_ = x[ChErrorAllReplicasLost-415]
+	_ = x[ChErrorReplicaStatusChanged-416]
+	_ = x[ChErrorExpectedAllOrAny-417]
+	_ = x[ChErrorUnknownJoin-418]
+	_ = x[ChErrorMultipleAssignmentsToColumn-419]
+	_ = x[ChErrorCannotUpdateColumn-420]
+	_ = x[ChErrorCannotAddDifferentAggregateStates-421]
+	_ = x[ChErrorUnsupportedURIScheme-422]
+	_ = x[ChErrorCannotGettimeofday-423]
+	_ = x[ChErrorCannotLink-424]
+	_ = x[ChErrorSystemError-425]
+	_ = x[ChErrorCannotCompileRegexp-427]
+	_ = x[ChErrorUnknownLogLevel-428]
+	_ = x[ChErrorFailedToGetpwuid-429]
+	_ = x[ChErrorMismatchingUsersForProcessAndData-430]
+	_ = x[ChErrorIllegalSyntaxForCodecType-431]
+	_ = x[ChErrorUnknownCodec-432]
+	_ = x[ChErrorIllegalCodecParameter-433]
+	_ = x[ChErrorCannotParseProtobufSchema-434]
+	_ = x[ChErrorNoColumnSerializedToRequiredProtobufField-435]
+	_ = x[ChErrorProtobufBadCast-436]
+	_ = x[ChErrorProtobufFieldNotRepeated-437]
+	_ = x[ChErrorDataTypeCannotBePromoted-438]
+	_ = x[ChErrorCannotScheduleTask-439]
+	_ = x[ChErrorInvalidLimitExpression-440]
+	_ = x[ChErrorCannotParseDomainValueFromString-441]
+	_ = x[ChErrorBadDatabaseForTemporaryTable-442]
+	_ = x[ChErrorNoColumnsSerializedToProtobufFields-443]
+	_ = x[ChErrorUnknownProtobufFormat-444]
+	_ = x[ChErrorCannotMprotect-445]
+	_ = x[ChErrorFunctionNotAllowed-446]
+	_ = x[ChErrorHyperscanCannotScanText-447]
+	_ = x[ChErrorBrotliReadFailed-448]
+	_ = x[ChErrorBrotliWriteFailed-449]
+	_ = x[ChErrorBadTTLExpression-450]
+	_ = x[ChErrorBadTTLFile-451]
+	_ = x[ChErrorSettingConstraintViolation-452]
+	_ = x[ChErrorMysqlClientInsufficientCapabilities-453]
+	_ = x[ChErrorOpensslError-454]
+	_ = x[ChErrorSuspiciousTypeForLowCardinality-455]
+	_ = x[ChErrorUnknownQueryParameter-456]
+	_ = x[ChErrorBadQueryParameter-457]
+	_ = x[ChErrorCannotUnlink-458]
+	_ = x[ChErrorCannotSetThreadPriority-459]
+	_ = x[ChErrorCannotCreateTimer-460]
+	_ = x[ChErrorCannotSetTimerPeriod-461]
+	_ = x[ChErrorCannotDeleteTimer-462]
+	_ = x[ChErrorCannotFcntl-463]
+	_ = x[ChErrorCannotParseElf-464]
+	_ = x[ChErrorCannotParseDwarf-465]
+	_ = x[ChErrorInsecurePath-466]
+	_ = x[ChErrorCannotParseBool-467]
+	_ = x[ChErrorCannotPthreadAttr-468]
+	_ = x[ChErrorViolatedConstraint-469]
+	_ = x[ChErrorQueryIsNotSupportedInLiveView-470]
+	_ = x[ChErrorInvalidSettingValue-471]
+	_ = x[ChErrorReadonlySetting-472]
+	_ = x[ChErrorDeadlockAvoided-473]
+	_ = x[ChErrorInvalidTemplateFormat-474]
+	_ = x[ChErrorInvalidWithFillExpression-475]
+	_ = x[ChErrorWithTiesWithoutOrderBy-476]
+	_ = x[ChErrorInvalidUsageOfInput-477]
+	_ = x[ChErrorUnknownPolicy-478]
+	_ = x[ChErrorUnknownDisk-479]
+	_ = x[ChErrorUnknownProtocol-480]
+	_ = x[ChErrorPathAccessDenied-481]
+	_ = x[ChErrorDictionaryAccessDenied-482]
+	_ = x[ChErrorTooManyRedirects-483]
+	_ = x[ChErrorInternalRedisError-484]
+	_ = x[ChErrorScalarAlreadyExists-485]
+	_ = x[ChErrorCannotGetCreateDictionaryQuery-487]
+	_ = x[ChErrorUnknownDictionary-488]
+	_ = x[ChErrorIncorrectDictionaryDefinition-489]
+	_ = x[ChErrorCannotFormatDatetime-490]
+	_ = x[ChErrorUnacceptableURL-491]
+	_ = x[ChErrorAccessEntityNotFound-492]
+	_ = x[ChErrorAccessEntityAlreadyExists-493]
+	_ = x[ChErrorAccessEntityFoundDuplicates-494]
+	_ = x[ChErrorAccessStorageReadonly-495]
+	_ = x[ChErrorQuotaRequiresClientKey-496]
+	_ = x[ChErrorAccessDenied-497]
+	_ = x[ChErrorLimitByWithTiesIsNotSupported-498]
+	_ = x[ChErrorS3Error-499]
+	_ = x[ChErrorAzureBlobStorageError-500]
+	_ = x[ChErrorCannotCreateDatabase-501]
+	_ = x[ChErrorCannotSigqueue-502]
+	_ = x[ChErrorAggregateFunctionThrow-503]
+	_ = x[ChErrorFileAlreadyExists-504]
+	_ = x[ChErrorCannotDeleteDirectory-505]
+	_ = x[ChErrorUnexpectedErrorCode-506]
+	_ = x[ChErrorUnableToSkipUnusedShards-507]
+	_ = x[ChErrorUnknownAccessType-508]
+	_ = x[ChErrorInvalidGrant-509]
+	_ = x[ChErrorCacheDictionaryUpdateFail-510]
+	_ = x[ChErrorUnknownRole-511]
+	_ = x[ChErrorSetNonGrantedRole-512]
+	_ = x[ChErrorUnknownPartType-513]
+	_ = x[ChErrorAccessStorageForInsertionNotFound-514]
+	_ = x[ChErrorIncorrectAccessEntityDefinition-515]
+	_ = x[ChErrorAuthenticationFailed-516]
+	_ = x[ChErrorCannotAssignAlter-517]
+	_ = x[ChErrorCannotCommitOffset-518]
+	_ = x[ChErrorNoRemoteShardAvailable-519]
+	_ = x[ChErrorCannotDetachDictionaryAsTable-520]
+	_ = x[ChErrorAtomicRenameFail-521]
+	_ = x[ChErrorUnknownRowPolicy-523]
+	_ = x[ChErrorAlterOfColumnIsForbidden-524]
+	_ = x[ChErrorIncorrectDiskIndex-525]
+	_ = x[ChErrorNoSuitableFunctionImplementation-527]
+	_ = x[ChErrorCassandraInternalError-528]
+	_ = x[ChErrorNotALeader-529]
+	_ = x[ChErrorCannotConnectRabbitmq-530]
+	_ = x[ChErrorCannotFstat-531]
+	_ = x[ChErrorLdapError-532]
+	_ = x[ChErrorInconsistentReservations-533]
+	_ = x[ChErrorNoReservationsProvided-534]
+	_ = x[ChErrorUnknownRaidType-535]
+	_ = x[ChErrorCannotRestoreFromFieldDump-536]
+	_ = x[ChErrorIllegalMysqlVariable-537]
+	_ = x[ChErrorMysqlSyntaxError-538]
+	_ = x[ChErrorCannotBindRabbitmqExchange-539]
+	_ = x[ChErrorCannotDeclareRabbitmqExchange-540]
+	_ = x[ChErrorCannotCreateRabbitmqQueueBinding-541]
+	_ = x[ChErrorCannotRemoveRabbitmqExchange-542]
+	_ = x[ChErrorUnknownMysqlDatatypesSupportLevel-543]
+	_ = x[ChErrorRowAndRowsTogether-544]
+	_ = x[ChErrorFirstAndNextTogether-545]
+	_ = x[ChErrorNoRowDelimiter-546]
+	_ = x[ChErrorInvalidRaidType-547]
+	_ = x[ChErrorUnknownVolume-548]
+	_ = x[ChErrorDataTypeCannotBeUsedInKey-549]
+	_ = x[ChErrorConditionalTreeParentNotFound-550]
+	_ = x[ChErrorIllegalProjectionManipulator-551]
+	_ = x[ChErrorUnrecognizedArguments-552]
+	_ = x[ChErrorLzmaStreamEncoderFailed-553]
+	_ = x[ChErrorLzmaStreamDecoderFailed-554]
+	_ = x[ChErrorRocksdbError-555]
+	_ = x[ChErrorSyncMysqlUserAccessErro-556]
+	_ = x[ChErrorUnknownUnion-557]
+	_ = x[ChErrorExpectedAllOrDistinct-558]
+	_ = x[ChErrorInvalidGrpcQueryInfo-559]
+	_ = x[ChErrorZstdEncoderFailed-560]
+	_ = x[ChErrorZstdDecoderFailed-561]
+	_ = x[ChErrorTldListNotFound-562]
+	_ = x[ChErrorCannotReadMapFromText-563]
+	_ = x[ChErrorInterserverSchemeDoesntMatch-564]
+	_ = x[ChErrorTooManyPartitions-565]
+	_ = x[ChErrorCannotRmdir-566]
+	_ = x[ChErrorDuplicatedPartUuids-567]
+	_ = x[ChErrorRaftError-568]
+	_ = x[ChErrorMultipleColumnsSerializedToSameProtobufField-569]
+	_ = x[ChErrorDataTypeIncompatibleWithProtobufField-570]
+	_ = x[ChErrorDatabaseReplicationFailed-571]
+	_ = x[ChErrorTooManyQueryPlanOptimizations-572]
+	_ = x[ChErrorEpollError-573]
+	_ = x[ChErrorDistributedTooManyPendingBytes-574]
+	_ = x[ChErrorUnknownSnapshot-575]
+	_ = x[ChErrorKerberosError-576]
+	_ = x[ChErrorInvalidShardID-577]
+	_ = x[ChErrorInvalidFormatInsertQueryWithData-578]
+	_ = x[ChErrorIncorrectPartType-579]
+	_ = x[ChErrorCannotSetRoundingMode-580]
+	_ = x[ChErrorTooLargeDistributedDepth-581]
+	_ = x[ChErrorNoSuchProjectionInTable-582]
+	_ = x[ChErrorIllegalProjection-583]
+	_ = x[ChErrorProjectionNotUsed-584]
+	_ = x[ChErrorCannotParseYaml-585]
+	_ = x[ChErrorCannotCreateFile-586]
+	_ = x[ChErrorConcurrentAccessNotSupported-587]
+	_ = x[ChErrorDistributedBrokenBatchInfo-588]
+	_ = x[ChErrorDistributedBrokenBatchFiles-589]
+	_ = x[ChErrorCannotSysconf-590]
+	_ = x[ChErrorSqliteEngineError-591]
+	_ = x[ChErrorDataEncryptionError-592]
+	_ = x[ChErrorZeroCopyReplicationError-593]
+	_ = x[ChErrorBzip2StreamDecoderFailed-594]
+	_ = x[ChErrorBzip2StreamEncoderFailed-595]
+	_ = x[ChErrorIntersectOrExceptResultStructuresMismatch-596]
+	_ = x[ChErrorNoSuchErrorCode-597]
+	_ = x[ChErrorBackupAlreadyExists-598]
+	_ = x[ChErrorBackupNotFound-599]
+	_ = x[ChErrorBackupVersionNotSupported-600]
+	_ = x[ChErrorBackupDamaged-601]
+	_ = x[ChErrorNoBaseBackup-602]
+	_ = x[ChErrorWrongBaseBackup-603]
+	_ = x[ChErrorBackupEntryAlreadyExists-604]
+	_ = x[ChErrorBackupEntryNotFound-605]
+	_ = x[ChErrorBackupIsEmpty-606]
+	_ = x[ChErrorBackupElementDuplicate-607]
+	_ = x[ChErrorCannotRestoreTable-608]
+	_ = x[ChErrorFunctionAlreadyExists-609]
+	_ = x[ChErrorCannotDropFunction-610]
+	_ = x[ChErrorCannotCreateRecursiveFunction-611]
+	_ = x[ChErrorObjectAlreadyStoredOnDisk-612]
+	_ = x[ChErrorObjectWasNotStoredOnDisk-613]
+	_ = x[ChErrorPostgresqlConnectionFailure-614]
+	_ = x[ChErrorCannotAdvise-615]
+	_ = x[ChErrorUnknownReadMethod-616]
+	_ = x[ChErrorLz4EncoderFailed-617]
+	_ = x[ChErrorLz4DecoderFailed-618]
+	_ = x[ChErrorPostgresqlReplicationInternalError-619]
+	_ = x[ChErrorQueryNotAllowed-620]
+	_ = x[ChErrorCannotNormalizeString-621]
+	_ = x[ChErrorCannotParseCapnProtoSchema-622]
+	_ = x[ChErrorCapnProtoBadCast-623]
+	_ = x[ChErrorBadFileType-624]
+	_ = x[ChErrorIoSetupError-625]
+	_ = x[ChErrorCannotSkipUnknownField-626]
+	_ = x[ChErrorBackupEngineNotFound-627]
+	_ = x[ChErrorOffsetFetchWithoutOrderBy-628]
+	_ = x[ChErrorHTTPRangeNotSatisfiable-629]
+	_ = x[ChErrorHaveDependentObjects-630]
+	_ = x[ChErrorUnknownFileSize-631]
+	_ = x[ChErrorUnexpectedDataAfterParsedValue-632]
+	_ = x[ChErrorQueryIsNotSupportedInWindowView-633]
+	_ = x[ChErrorMongodbError-634]
+	_ = x[ChErrorCannotPoll-635]
+	_ = x[ChErrorCannotExtractTableStructure-636]
+	_ = x[ChErrorInvalidTableOverride-637]
+	_ = x[ChErrorSnappyUncompressFailed-638]
+	_ = x[ChErrorSnappyCompressFailed-639]
+	_ = x[ChErrorNoHivemetastore-640]
+	_ = x[ChErrorCannotAppendToFile-641]
+	_ = x[ChErrorCannotPackArchive-642]
+	_ = x[ChErrorCannotUnpackArchive-643]
+	_ = x[ChErrorKeeperException-999]
+	_ = x[ChErrorPocoException-1000]
+	_ = x[ChErrorStdException-1001]
+	_ = x[ChErrorUnknownException-1002]
+}
+
+const _ChErrorType_name = "OKUNSUPPORTED_METHODUNSUPPORTED_PARAMETERUNEXPECTED_END_OF_FILEEXPECTED_END_OF_FILECANNOT_PARSE_TEXTINCORRECT_NUMBER_OF_COLUMNSTHERE_IS_NO_COLUMNSIZES_OF_COLUMNS_DOESNT_MATCHNOT_FOUND_COLUMN_IN_BLOCKPOSITION_OUT_OF_BOUNDPARAMETER_OUT_OF_BOUNDSIZES_OF_COLUMNS_IN_TUPLE_DOESNT_MATCHDUPLICATE_COLUMNNO_SUCH_COLUMN_IN_TABLEDELIMITER_IN_STRING_LITERAL_DOESNT_MATCHCANNOT_INSERT_ELEMENT_INTO_CONSTANT_COLUMNSIZE_OF_FIXED_STRING_DOESNT_MATCHNUMBER_OF_COLUMNS_DOESNT_MATCHCANNOT_READ_ALL_DATA_FROM_TAB_SEPARATED_INPUTCANNOT_PARSE_ALL_VALUE_FROM_TAB_SEPARATED_INPUTCANNOT_READ_FROM_ISTREAMCANNOT_WRITE_TO_OSTREAMCANNOT_PARSE_ESCAPE_SEQUENCECANNOT_PARSE_QUOTED_STRINGCANNOT_PARSE_INPUT_ASSERTION_FAILEDCANNOT_PRINT_FLOAT_OR_DOUBLE_NUMBERCANNOT_PRINT_INTEGERCANNOT_READ_SIZE_OF_COMPRESSED_CHUNKCANNOT_READ_COMPRESSED_CHUNKATTEMPT_TO_READ_AFTER_EOFCANNOT_READ_ALL_DATATOO_MANY_ARGUMENTS_FOR_FUNCTIONTOO_FEW_ARGUMENTS_FOR_FUNCTIONBAD_ARGUMENTSUNKNOWN_ELEMENT_IN_ASTCANNOT_PARSE_DATETOO_LARGE_SIZE_COMPRESSEDCHECKSUM_DOESNT_MATCHCANNOT_PARSE_DATETIMENUMBER_OF_ARGUMENTS_DOESNT_MATCHILLEGAL_TYPE_OF_ARGUMENTILLEGAL_COLUMNILLEGAL_NUMBER_OF_RESULT_COLUMNSUNKNOWN_FUNCTIONUNKNOWN_IDENTIFIERNOT_IMPLEMENTEDLOGICAL_ERRORUNKNOWN_TYPEEMPTY_LIST_OF_COLUMNS_QUERIEDCOLUMN_QUERIED_MORE_THAN_ONCETYPE_MISMATCHSTORAGE_DOESNT_ALLOW_PARAMETERSSTORAGE_REQUIRES_PARAMETERUNKNOWN_STORAGETABLE_ALREADY_EXISTSTABLE_METADATA_ALREADY_EXISTSILLEGAL_TYPE_OF_COLUMN_FOR_FILTERUNKNOWN_TABLEONLY_FILTER_COLUMN_IN_BLOCKSYNTAX_ERRORUNKNOWN_AGGREGATE_FUNCTIONCANNOT_READ_AGGREGATE_FUNCTION_FROM_TEXTCANNOT_WRITE_AGGREGATE_FUNCTION_AS_TEXTNOT_A_COLUMNILLEGAL_KEY_OF_AGGREGATIONCANNOT_GET_SIZE_OF_FIELDARGUMENT_OUT_OF_BOUNDCANNOT_CONVERT_TYPECANNOT_WRITE_AFTER_END_OF_BUFFERCANNOT_PARSE_NUMBERUNKNOWN_FORMATCANNOT_READ_FROM_FILE_DESCRIPTORCANNOT_WRITE_TO_FILE_DESCRIPTORCANNOT_OPEN_FILECANNOT_CLOSE_FILEUNKNOWN_TYPE_OF_QUERYINCORRECT_FILE_NAMEINCORRECT_QUERYUNKNOWN_DATABASEDATABASE_ALREADY_EXISTSDIRECTORY_DOESNT_EXISTDIRECTORY_ALREADY_EXISTSFORMAT_IS_NOT_SUITABLE_FOR_INPUTRECEIVED_ERROR_FROM_REMOTE_IO_SERVERCANNOT_SEEK_THROUGH_FILECANNOT_TRUNCATE_FILEUNKNOWN_COMPRESSION_METHODEMPTY_LIST_OF_COLUMNS_PASSEDSIZES_OF_MARKS_FILES_ARE_INCONSISTENTEMPTY_DATA_PASSEDUNKNOWN_AGGREGATED_DATA_VARIANTCANNOT_MERGE_DIFFERENT_AGGREGATED_DATA_VARIANTSCANNOT_READ_FROM_SOCKETCANNOT_WRITE_TO_SOCKETCANNOT_READ_ALL_DATA_FROM_CHUNKED_INPUTCANNOT_WRITE_TO_EMPTY_BLOCK_OUTPUT_STREAMUNKNOWN_PACKET_FROM_CLIENTUNKNOWN_PACKET_FROM_SERVERUNEXPECTED_PACKET_FROM_CLIENTUNEXPECTED_PACKET_FROM_SERVERRECEIVED_DATA_FOR_WRONG_QUERY_IDTOO_SMALL_BUFFER_SIZECANNOT_READ_HISTORYCANNOT_APPEND_HISTORYFILE_DOESNT_EXISTNO_DATA_TO_INSERTCANNOT_BLOCK_SIGNALCANNOT_UNBLOCK_SIGNALCANNOT_MANIPULATE_SIGSETCANNOT_WAIT_FOR_SIGNALTHERE_IS_NO_SESSIONCANNOT_CLOCK_GETTIMEUNKNOWN_SETTINGTHERE_IS_NO_DEFAULT_VALUEINCORRECT_DATAENGINE_REQUIREDCANNOT_INSERT_VALUE_OF_DIFFERENT_SIZE_INTO_TUPLEUNSUPPORTED_JOIN_KEYSINCOMPATIBLE_COLUMNSUNKNOWN_TYPE_OF_AST_NODEINCORRECT_ELEMENT_OF_SETINCORRECT_RESULT_OF_SCALAR_SUBQUERYCANNOT_GET_RETURN_TYPEILLEGAL_INDEXTOO_LARGE_ARRAY_SIZEFUNCTION_IS_SPECIALCANNOT_READ_ARRAY_FROM_TEXTTOO_LARGE_STRING_SIZEAGGREGATE_FUNCTION_DOESNT_ALLOW_PARAMETERSPARAMETERS_TO_AGGREGATE_FUNCTIONS_MUST_BE_LITERALSZERO_ARRAY_OR_TUPLE_INDEXUNKNOWN_ELEMENT_IN_CONFIGEXCESSIVE_ELEMENT_IN_CONFIGNO_ELEMENTS_IN_CONFIGALL_REQUESTED_COLUMNS_ARE_MISSINGSAMPLING_NOT_SUPPORTEDNOT_FOUND_NODEFOUND_MORE_THAN_ONE_NODEFIRST_DATE_IS_BIGGER_THAN_LAST_DATEUNKNOWN_OVERFLOW_MODEQUERY_SECTION_DOESNT_MAKE_SENSENOT_FOUND_FUNCTION_ELEMENT_FOR_AGGREGATENOT_FOUND_RELATION_ELEMENT_FOR_CONDITIONNOT_FOUND_RHS_ELEMENT_FOR_CONDITIONEMPTY_LIST_OF_ATTRIBUTES_PASSEDINDEX_OF_COLUMN_IN_SORT_CLAUSE_IS_OUT_OF_RANGEUNKNOWN_DIRECTION_OF_SORTINGILLEGAL_DIVISIONAGGREGATE_FUNCTION_NOT_APPLICABLEUNKNOWN_RELATIONDICTIONARIES_WAS_NOT_LOADEDILLEGAL_OVERFLOW_MODETOO_MANY_ROWSTIMEOUT_EXCEEDEDTOO_SLOWTOO_MANY_COLUMNSTOO_DEEP_SUBQUERIESTOO_DEEP_PIPELINEREADONLYTOO_MANY_TEMPORARY_COLUMNSTOO_MANY_TEMPORARY_NON_CONST_COLUMNSTOO_DEEP_ASTTOO_BIG_ASTBAD_TYPE_OF_FIELDBAD_GETCANNOT_CREATE_DIRECTORYCANNOT_ALLOCATE_MEMORYCYCLIC_ALIASESCHUNK_NOT_FOUNDDUPLICATE_CHUNK_NAMEMULTIPLE_ALIASES_FOR_EXPRESSIONMULTIPLE_EXPRESSIONS_FOR_ALIASTHERE_IS_NO_PROFILEILLEGAL_FINALILLEGAL_PREWHEREUNEXPECTED_EXPRESSIONILLEGAL_AGGREGATIONUNSUPPORTED_MYISAM_BLOCK_TYPEUNSUPPORTED_COLLATION_LOCALECOLLATION_COMPARISON_FAILEDUNKNOWN_ACTIONTABLE_MUST_NOT_BE_CREATED_MANUALLYSIZES_OF_ARRAYS_DOESNT_MATCHSET_SIZE_LIMIT_EXCEEDEDUNKNOWN_USERWRONG_PASSWORDREQUIRED_PASSWORDIP_ADDRESS_NOT_ALLOWEDUNKNOWN_ADDRESS_PATTERN_TYPESERVER_REVISION_IS_TOO_OLDDNS_ERRORUNKNOWN_QUOTAQUOTA_DOESNT_ALLOW_KEYSQUOTA_EXPIREDTOO_MANY_SIMULTANEOUS_QUERIESNO_FREE_CONNECTIONCANNOT_FSYNCNESTED_TYPE_TOO_DEEPALIAS_REQUIREDAMBIGUOUS_IDENTIFIEREMPTY_NESTED_TABLESOCKET_TIMEOUTNETWORK_ERROREMPTY_QUERYUNKNOWN_LOAD_BALANCINGUNKNOWN_TOTALS_MODECANNOT_STATVFSNOT_AN_AGGREGATEQUERY_WITH_SAME_ID_IS_ALREADY_RUNNINGCLIENT_HAS_CONNECTED_TO_WRONG_PORTTABLE_IS_DROPPEDDATABASE_NOT_EMPTYDUPLICATE_INTERSERVER_IO_ENDPOINTNO_SUCH_INTERSERVER_IO_ENDPOINTADDING_REPLICA_TO_NON_EMPTY_TABLEUNEXPECTED_AST_STRUCTUREREPLICA_IS_ALREADY_ACTIVENO_ZOOKEEPERNO_FILE_IN_DATA_PARTUNEXPECTED_FILE_IN_DATA_PARTBAD_SIZE_OF_FILE_IN_DATA_PARTQUERY_IS_TOO_LARGENOT_FOUND_EXPECTED_DATA_PARTTOO_MANY_UNEXPECTED_DATA_PARTSNO_SUCH_DATA_PARTBAD_DATA_PART_NAMENO_REPLICA_HAS_PARTDUPLICATE_DATA_PARTABORTEDNO_REPLICA_NAME_GIVENFORMAT_VERSION_TOO_OLDCANNOT_MUNMAPCANNOT_MREMAPMEMORY_LIMIT_EXCEEDEDTABLE_IS_READ_ONLYNOT_ENOUGH_SPACEUNEXPECTED_ZOOKEEPER_ERRORCORRUPTED_DATAINCORRECT_MARKINVALID_PARTITION_VALUENOT_ENOUGH_BLOCK_NUMBERSNO_SUCH_REPLICATOO_MANY_PARTSREPLICA_IS_ALREADY_EXISTNO_ACTIVE_REPLICASTOO_MANY_RETRIES_TO_FETCH_PARTSPARTITION_ALREADY_EXISTSPARTITION_DOESNT_EXISTUNION_ALL_RESULT_STRUCTURES_MISMATCHCLIENT_OUTPUT_FORMAT_SPECIFIEDUNKNOWN_BLOCK_INFO_FIELDBAD_COLLATIONCANNOT_COMPILE_CODEINCOMPATIBLE_TYPE_OF_JOINNO_AVAILABLE_REPLICAMISMATCH_REPLICAS_DATA_SOURCESSTORAGE_DOESNT_SUPPORT_PARALLEL_REPLICASCPUID_ERRORINFINITE_LOOPCANNOT_COMPRESSCANNOT_DECOMPRESSCANNOT_IO_SUBMITCANNOT_IO_GETEVENTSAIO_READ_ERRORAIO_WRITE_ERRORINDEX_NOT_USEDALL_CONNECTION_TRIES_FAILEDNO_AVAILABLE_DATADICTIONARY_IS_EMPTYINCORRECT_INDEXUNKNOWN_DISTRIBUTED_PRODUCT_MODEWRONG_GLOBAL_SUBQUERYTOO_FEW_LIVE_REPLICASUNSATISFIED_QUORUM_FOR_PREVIOUS_WRITEUNKNOWN_FORMAT_VERSIONDISTRIBUTED_IN_JOIN_SUBQUERY_DENIEDREPLICA_IS_NOT_IN_QUORUMLIMIT_EXCEEDEDDATABASE_ACCESS_DENIEDMONGODB_CANNOT_AUTHENTICATEINVALID_BLOCK_EXTRA_INFORECEIVED_EMPTY_DATANO_REMOTE_SHARD_FOUNDSHARD_HAS_NO_CONNECTIONSCANNOT_PIPECANNOT_FORKCANNOT_DLSYMCANNOT_CREATE_CHILD_PROCESSCHILD_WAS_NOT_EXITED_NORMALLYCANNOT_SELECTCANNOT_WAITPIDTABLE_WAS_NOT_DROPPEDTOO_DEEP_RECURSIONTOO_MANY_BYTESUNEXPECTED_NODE_IN_ZOOKEEPERFUNCTION_CANNOT_HAVE_PARAMETERSINVALID_SHARD_WEIGHTINVALID_CONFIG_PARAMETERUNKNOWN_STATUS_OF_INSERTVALUE_IS_OUT_OF_RANGE_OF_DATA_TYPEBARRIER_TIMEOUTUNKNOWN_DATABASE_ENGINEDDL_GUARD_IS_ACTIVEUNFINISHEDMETADATA_MISMATCHSUPPORT_IS_DISABLEDTABLE_DIFFERS_TOO_MUCHCANNOT_CONVERT_CHARSETCANNOT_LOAD_CONFIGCANNOT_INSERT_NULL_IN_ORDINARY_COLUMNINCOMPATIBLE_SOURCE_TABLESAMBIGUOUS_TABLE_NAMEAMBIGUOUS_COLUMN_NAMEINDEX_OF_POSITIONAL_ARGUMENT_IS_OUT_OF_RANGEZLIB_INFLATE_FAILEDZLIB_DEFLATE_FAILEDBAD_LAMBDARESERVED_IDENTIFIER_NAMEINTO_OUTFILE_NOT_ALLOWEDTABLE_SIZE_EXCEEDS_MAX_DROP_SIZE_LIMITCANNOT_CREATE_CHARSET_CONVERTERSEEK_POSITION_OUT_OF_BOUNDCURRENT_WRITE_BUFFER_IS_EXHAUSTEDCANNOT_CREATE_IO_BUFFERRECEIVED_ERROR_TOO_MANY_REQUESTSSIZES_OF_NESTED_COLUMNS_ARE_INCONSISTENTTOO_MANY_FETCHESALL_REPLICAS_ARE_STALEDATA_TYPE_CANNOT_BE_USED_IN_TABLESINCONSISTENT_CLUSTER_DEFINITIONSESSION_NOT_FOUNDSESSION_IS_LOCKEDINVALID_SESSION_TIMEOUTCANNOT_DLOPENCANNOT_PARSE_UUIDILLEGAL_SYNTAX_FOR_DATA_TYPEDATA_TYPE_CANNOT_HAVE_ARGUMENTSUNKNOWN_STATUS_OF_DISTRIBUTED_DDL_TASKCANNOT_KILLHTTP_LENGTH_REQUIREDCANNOT_LOAD_CATBOOST_MODELCANNOT_APPLY_CATBOOST_MODELPART_IS_TEMPORARILY_LOCKEDMULTIPLE_STREAMS_REQUIREDNO_COMMON_TYPEDICTIONARY_ALREADY_EXISTSCANNOT_ASSIGN_OPTIMIZEINSERT_WAS_DEDUPLICATEDCANNOT_GET_CREATE_TABLE_QUERYEXTERNAL_LIBRARY_ERRORQUERY_IS_PROHIBITEDTHERE_IS_NO_QUERYQUERY_WAS_CANCELEDFUNCTION_THROW_IF_VALUE_IS_NON_ZEROTOO_MANY_ROWS_OR_BYTESQUERY_IS_NOT_SUPPORTED_IN_MATERIALIZED_VIEWUNKNOWN_MUTATION_COMMANDFORMAT_IS_NOT_SUITABLE_FOR_OUTPUTCANNOT_STATFEATURE_IS_NOT_ENABLED_AT_BUILD_TIMECANNOT_IOSETUPINVALID_JOIN_ON_EXPRESSIONBAD_ODBC_CONNECTION_STRINGPARTITION_SIZE_EXCEEDS_MAX_DROP_SIZE_LIMITTOP_AND_LIMIT_TOGETHERDECIMAL_OVERFLOWBAD_REQUEST_PARAMETEREXTERNAL_EXECUTABLE_NOT_FOUNDEXTERNAL_SERVER_IS_NOT_RESPONDINGPTHREAD_ERRORNETLINK_ERRORCANNOT_SET_SIGNAL_HANDLERALL_REPLICAS_LOSTREPLICA_STATUS_CHANGEDEXPECTED_ALL_OR_ANYUNKNOWN_JOINMULTIPLE_ASSIGNMENTS_TO_COLUMNCANNOT_UPDATE_COLUMNCANNOT_ADD_DIFFERENT_AGGREGATE_STATESUNSUPPORTED_URI_SCHEMECANNOT_GETTIMEOFDAYCANNOT_LINKSYSTEM_ERRORCANNOT_COMPILE_REGEXPUNKNOWN_LOG_LEVELFAILED_TO_GETPWUIDMISMATCHING_USERS_FOR_PROCESS_AND_DATAILLEGAL_SYNTAX_FOR_CODEC_TYPEUNKNOWN_CODECILLEGAL_CODEC_PARAMETERCANNOT_PARSE_PROTOBUF_SCHEMANO_COLUMN_SERIALIZED_TO_REQUIRED_PROTOBUF_FIELDPROTOBUF_BAD_CASTPROTOBUF_FIELD_NOT_REPEATEDDATA_TYPE_CANNOT_BE_PROMOTEDCANNOT_SCHEDULE_TASKINVALID_LIMIT_EXPRESSIONCANNOT_PARSE_DOMAIN_VALUE_FROM_STRINGBAD_DATABASE_FOR_TEMPORARY_TABLENO_COLUMNS_SERIALIZED_TO_PROTOBUF_FIELDSUNKNOWN_PROTOBUF_FORMATCANNOT_MPROTECTFUNCTION_NOT_ALLOWEDHYPERSCAN_CANNOT_SCAN_TEXTBROTLI_READ_FAILEDBROTLI_WRITE_FAILEDBAD_TTL_EXPRESSIONBAD_TTL_FILESETTING_CONSTRAINT_VIOLATIONMYSQL_CLIENT_INSUFFICIENT_CAPABILITIESOPENSSL_ERRORSUSPICIOUS_TYPE_FOR_LOW_CARDINALITYUNKNOWN_QUERY_PARAMETERBAD_QUERY_PARAMETERCANNOT_UNLINKCANNOT_SET_THREAD_PRIORITYCANNOT_CREATE_TIMERCANNOT_SET_TIMER_PERIODCANNOT_DELETE_TIMERCANNOT_FCNTLCANNOT_PARSE_ELFCANNOT_PARSE_DWARFINSECURE_PATHCANNOT_PARSE_BOOLCANNOT_PTHREAD_ATTRVIOLATED_CONSTRAINTQUERY_IS_NOT_SUPPORTED_IN_LIVE_VIEWINVALID_SETTING_VALUEREADONLY_SETTINGDEADLOCK_AVOIDEDINVALID_TEMPLATE_FORMATINVALID_WITH_FILL_EXPRESSIONWITH_TIES_WITHOUT_ORDER_BYINVALID_USAGE_OF_INPUTUNKNOWN_POLICYUNKNOWN_DISKUNKNOWN_PROTOCOLPATH_ACCESS_DENIEDDICTIONARY_ACCESS_DENIEDTOO_MANY_REDIRECTSINTERNAL_REDIS_ERRORSCALAR_ALREADY_EXISTSCANNOT_GET_CREATE_DICTIONARY_QUERYUNKNOWN_DICTIONARYINCORRECT_DICTIONARY_DEFINITIONCANNOT_FORMAT_DATETIMEUNACCEPTABLE_URLACCESS_ENTITY_NOT_FOUNDACCESS_ENTITY_ALREADY_EXISTSACCESS_ENTITY_FOUND_DUPLICATESACCESS_STORAGE_READONLYQUOTA_REQUIRES_CLIENT_KEYACCESS_DENIEDLIMIT_BY_WITH_TIES_IS_NOT_SUPPORTEDS3_ERRORAZURE_BLOB_STORAGE_ERRORCANNOT_CREATE_DATABASECANNOT_SIGQUEUEAGGREGATE_FUNCTION_THROWFILE_ALREADY_EXISTSCANNOT_DELETE_DIRECTORYUNEXPECTED_ERROR_CODEUNABLE_TO_SKIP_UNUSED_SHARDSUNKNOWN_ACCESS_TYPEINVALID_GRANTCACHE_DICTIONARY_UPDATE_FAILUNKNOWN_ROLESET_NON_GRANTED_ROLEUNKNOWN_PART_TYPEACCESS_STORAGE_FOR_INSERTION_NOT_FOUNDINCORRECT_ACCESS_ENTITY_DEFINITIONAUTHENTICATION_FAILEDCANNOT_ASSIGN_ALTERCANNOT_COMMIT_OFFSETNO_REMOTE_SHARD_AVAILABLECANNOT_DETACH_DICTIONARY_AS_TABLEATOMIC_RENAME_FAILUNKNOWN_ROW_POLICYALTER_OF_COLUMN_IS_FORBIDDENINCORRECT_DISK_INDEXNO_SUITABLE_FUNCTION_IMPLEMENTATIONCASSANDRA_INTERNAL_ERRORNOT_A_LEADERCANNOT_CONNECT_RABBITMQCANNOT_FSTATLDAP_ERRORINCONSISTENT_RESERVATIONSNO_RESERVATIONS_PROVIDEDUNKNOWN_RAID_TYPECANNOT_RESTORE_FROM_FIELD_DUMPILLEGAL_MYSQL_VARIABLEMYSQL_SYNTAX_ERRORCANNOT_BIND_RABBITMQ_EXCHANGECANNOT_DECLARE_RABBITMQ_EXCHANGECANNOT_CREATE_RABBITMQ_QUEUE_BINDINGCANNOT_REMOVE_RABBITMQ_EXCHANGEUNKNOWN_MYSQL_DATATYPES_SUPPORT_LEVELROW_AND_ROWS_TOGETHERFIRST_AND_NEXT_TOGETHERNO_ROW_DELIMITERINVALID_RAID_TYPEUNKNOWN_VOLUMEDATA_TYPE_CANNOT_BE_USED_IN_KEYCONDITIONAL_TREE_PARENT_NOT_FOUNDILLEGAL_PROJECTION_MANIPULATORUNRECOGNIZED_ARGUMENTSLZMA_STREAM_ENCODER_FAILEDLZMA_STREAM_DECODER_FAILEDROCKSDB_ERRORSYNC_MYSQL_USER_ACCESS_ERROUNKNOWN_UNIONEXPECTED_ALL_OR_DISTINCTINVALID_GRPC_QUERY_INFOZSTD_ENCODER_FAILEDZSTD_DECODER_FAILEDTLD_LIST_NOT_FOUNDCANNOT_READ_MAP_FROM_TEXTINTERSERVER_SCHEME_DOESNT_MATCHTOO_MANY_PARTITIONSCANNOT_RMDIRDUPLICATED_PART_UUIDSRAFT_ERRORMULTIPLE_COLUMNS_SERIALIZED_TO_SAME_PROTOBUF_FIELDDATA_TYPE_INCOMPATIBLE_WITH_PROTOBUF_FIELDDATABASE_REPLICATION_FAILEDTOO_MANY_QUERY_PLAN_OPTIMIZATIONSEPOLL_ERRORDISTRIBUTED_TOO_MANY_PENDING_BYTESUNKNOWN_SNAPSHOTKERBEROS_ERRORINVALID_SHARD_IDINVALID_FORMAT_INSERT_QUERY_WITH_DATAINCORRECT_PART_TYPECANNOT_SET_ROUNDING_MODETOO_LARGE_DISTRIBUTED_DEPTHNO_SUCH_PROJECTION_IN_TABLEILLEGAL_PROJECTIONPROJECTION_NOT_USEDCANNOT_PARSE_YAMLCANNOT_CREATE_FILECONCURRENT_ACCESS_NOT_SUPPORTEDDISTRIBUTED_BROKEN_BATCH_INFODISTRIBUTED_BROKEN_BATCH_FILESCANNOT_SYSCONFSQLITE_ENGINE_ERRORDATA_ENCRYPTION_ERRORZERO_COPY_REPLICATION_ERRORBZIP2_STREAM_DECODER_FAILEDBZIP2_STREAM_ENCODER_FAILEDINTERSECT_OR_EXCEPT_RESULT_STRUCTURES_MISMATCHNO_SUCH_ERROR_CODEBACKUP_ALREADY_EXISTSBACKUP_NOT_FOUNDBACKUP_VERSION_NOT_SUPPORTEDBACKUP_DAMAGEDNO_BASE_BACKUPWRONG_BASE_BACKUPBACKUP_ENTRY_ALREADY_EXISTSBACKUP_ENTRY_NOT_FOUNDBACKUP_IS_EMPTYBACKUP_ELEMENT_DUPLICATECANNOT_RESTORE_TABLEFUNCTION_ALREADY_EXISTSCANNOT_DROP_FUNCTIONCANNOT_CREATE_RECURSIVE_FUNCTIONOBJECT_ALREADY_STORED_ON_DISKOBJECT_WAS_NOT_STORED_ON_DISKPOSTGRESQL_CONNECTION_FAILURECANNOT_ADVISEUNKNOWN_READ_METHODLZ4_ENCODER_FAILEDLZ4_DECODER_FAILEDPOSTGRESQL_REPLICATION_INTERNAL_ERRORQUERY_NOT_ALLOWEDCANNOT_NORMALIZE_STRINGCANNOT_PARSE_CAPN_PROTO_SCHEMACAPN_PROTO_BAD_CASTBAD_FILE_TYPEIO_SETUP_ERRORCANNOT_SKIP_UNKNOWN_FIELDBACKUP_ENGINE_NOT_FOUNDOFFSET_FETCH_WITHOUT_ORDER_BYHTTP_RANGE_NOT_SATISFIABLEHAVE_DEPENDENT_OBJECTSUNKNOWN_FILE_SIZEUNEXPECTED_DATA_AFTER_PARSED_VALUEQUERY_IS_NOT_SUPPORTED_IN_WINDOW_VIEWMONGODB_ERRORCANNOT_POLLCANNOT_EXTRACT_TABLE_STRUCTUREINVALID_TABLE_OVERRIDESNAPPY_UNCOMPRESS_FAILEDSNAPPY_COMPRESS_FAILEDNO_HIVEMETASTORECANNOT_APPEND_TO_FILECANNOT_PACK_ARCHIVECANNOT_UNPACK_ARCHIVEKEEPER_EXCEPTIONPOCO_EXCEPTIONSTD_EXCEPTIONUNKNOWN_EXCEPTION"
+
+var _ChErrorType_map = map[ChErrorType]string{
+	0:    _ChErrorType_name[0:2],
+	1:    _ChErrorType_name[2:20],
+	2:    _ChErrorType_name[20:41],
+	3:    _ChErrorType_name[41:63],
+	4:    _ChErrorType_name[63:83],
+	6:    _ChErrorType_name[83:100],
+	7:    _ChErrorType_name[100:127],
+	8:    _ChErrorType_name[127:145],
+	9:    _ChErrorType_name[145:174],
+	10:   _ChErrorType_name[174:199],
+	11:   _ChErrorType_name[199:220],
+	12:   _ChErrorType_name[220:242],
+	13:   _ChErrorType_name[242:280],
+	15:   _ChErrorType_name[280:296],
+	16:   _ChErrorType_name[296:319],
+	17:   _ChErrorType_name[319:359],
+	18:   _ChErrorType_name[359:401],
+	19:   _ChErrorType_name[401:434],
+	20:   _ChErrorType_name[434:464],
+	21:   _ChErrorType_name[464:509],
+	22:   _ChErrorType_name[509:556],
+	23:   _ChErrorType_name[556:580],
+	24:   _ChErrorType_name[580:603],
+	25:   _ChErrorType_name[603:631],
+	26:   _ChErrorType_name[631:657],
+	27:   _ChErrorType_name[657:692],
+	28:   _ChErrorType_name[692:727],
+	29:   _ChErrorType_name[727:747],
+	30:   _ChErrorType_name[747:783],
+	31:   _ChErrorType_name[783:811],
+	32:   _ChErrorType_name[811:836],
+	33:   _ChErrorType_name[836:856],
+	34:   _ChErrorType_name[856:887],
+	35:   _ChErrorType_name[887:917],
+	36:   _ChErrorType_name[917:930],
+	37:   _ChErrorType_name[930:952],
+	38:   _ChErrorType_name[952:969],
+	39:   _ChErrorType_name[969:994],
+	40:   _ChErrorType_name[994:1015],
+	41:   _ChErrorType_name[1015:1036],
+	42:   _ChErrorType_name[1036:1068],
+	43:   _ChErrorType_name[1068:1092],
+	44:   _ChErrorType_name[1092:1106],
+	45:   _ChErrorType_name[1106:1138],
+	46:   _ChErrorType_name[1138:1154],
+	47:   _ChErrorType_name[1154:1172],
+	48:   _ChErrorType_name[1172:1187],
+	49:   _ChErrorType_name[1187:1200],
+	50:   _ChErrorType_name[1200:1212],
+	51:   _ChErrorType_name[1212:1241],
+	52:   _ChErrorType_name[1241:1270],
+	53:   _ChErrorType_name[1270:1283],
+	54:   _ChErrorType_name[1283:1314],
+	55:   _ChErrorType_name[1314:1340],
+	56:   _ChErrorType_name[1340:1355],
+	57:   _ChErrorType_name[1355:1375],
+	58:   _ChErrorType_name[1375:1404],
+	59:   _ChErrorType_name[1404:1437],
+	60:   _ChErrorType_name[1437:1450],
+	61:   _ChErrorType_name[1450:1477],
+	62:   _ChErrorType_name[1477:1489],
+	63:   _ChErrorType_name[1489:1515],
+	64:   _ChErrorType_name[1515:1555],
+	65:   _ChErrorType_name[1555:1594],
+	66:   _ChErrorType_name[1594:1606],
+	67:   _ChErrorType_name[1606:1632],
+	68:   _ChErrorType_name[1632:1656],
+	69:   _ChErrorType_name[1656:1677],
+	70:   _ChErrorType_name[1677:1696],
+	71:   _ChErrorType_name[1696:1728],
+	72:   _ChErrorType_name[1728:1747],
+	73:   _ChErrorType_name[1747:1761],
+	74:   _ChErrorType_name[1761:1793],
+	75:   _ChErrorType_name[1793:1824],
+	76:   _ChErrorType_name[1824:1840],
+	77:   _ChErrorType_name[1840:1857],
+	78:   _ChErrorType_name[1857:1878],
+	79:   _ChErrorType_name[1878:1897],
+	80:   _ChErrorType_name[1897:1912],
+	81:   _ChErrorType_name[1912:1928],
+	82:   _ChErrorType_name[1928:1951],
+	83:   _ChErrorType_name[1951:1973],
+	84:   _ChErrorType_name[1973:1997],
+	85:   _ChErrorType_name[1997:2029],
+	86:   _ChErrorType_name[2029:2065],
+	87:   _ChErrorType_name[2065:2089],
+	88:   _ChErrorType_name[2089:2109],
+	89:   _ChErrorType_name[2109:2135],
+	90:   _ChErrorType_name[2135:2163],
+	91:   _ChErrorType_name[2163:2200],
+	92:   _ChErrorType_name[2200:2217],
+	93:   _ChErrorType_name[2217:2248],
+	94:   _ChErrorType_name[2248:2295],
+	95:   _ChErrorType_name[2295:2318],
+	96:   _ChErrorType_name[2318:2340],
+	97:   _ChErrorType_name[2340:2379],
+	98:   _ChErrorType_name[2379:2420],
+	99:   _ChErrorType_name[2420:2446],
+	100:  _ChErrorType_name[2446:2472],
+	101:  _ChErrorType_name[2472:2501],
+	102:  _ChErrorType_name[2501:2530],
+	103:  _ChErrorType_name[2530:2562],
+	104:  _ChErrorType_name[2562:2583],
+	105:  _ChErrorType_name[2583:2602],
+	106:  _ChErrorType_name[2602:2623],
+	107:  _ChErrorType_name[2623:2640],
+	108:  _ChErrorType_name[2640:2657],
+	109:  _ChErrorType_name[2657:2676],
+	110:  _ChErrorType_name[2676:2697],
+	111:  _ChErrorType_name[2697:2721],
+	112:  _ChErrorType_name[2721:2743],
+	113:  _ChErrorType_name[2743:2762],
+	114:  _ChErrorType_name[2762:2782],
+	115:  _ChErrorType_name[2782:2797],
+	116:  _ChErrorType_name[2797:2822],
+	117:  _ChErrorType_name[2822:2836],
+	119:  _ChErrorType_name[2836:2851],
+	120:  _ChErrorType_name[2851:2899],
+	121:  _ChErrorType_name[2899:2920],
+	122:  _ChErrorType_name[2920:2940],
+	123:  _ChErrorType_name[2940:2964],
+	124:  _ChErrorType_name[2964:2988],
+	125:  _ChErrorType_name[2988:3023],
+	126:  _ChErrorType_name[3023:3045],
+	127:  _ChErrorType_name[3045:3058],
+	128:  _ChErrorType_name[3058:3078],
+	129:  _ChErrorType_name[3078:3097],
+	130:  _ChErrorType_name[3097:3124],
+	131:  _ChErrorType_name[3124:3145],
+	133:  _ChErrorType_name[3145:3187],
+	134:  _ChErrorType_name[3187:3237],
+	135:  _ChErrorType_name[3237:3262],
+	137:  _ChErrorType_name[3262:3287],
+	138:  _ChErrorType_name[3287:3314],
+	139:  _ChErrorType_name[3314:3335],
+	140:  _ChErrorType_name[3335:3368],
+	141:  _ChErrorType_name[3368:3390],
+	142:  _ChErrorType_name[3390:3404],
+	143:  _ChErrorType_name[3404:3428],
+	144:  _ChErrorType_name[3428:3463],
+	145:  _ChErrorType_name[3463:3484],
+	146:  _ChErrorType_name[3484:3515],
+	147:  _ChErrorType_name[3515:3555],
+	148:  _ChErrorType_name[3555:3595],
+	149:  _ChErrorType_name[3595:3630],
+	150:  _ChErrorType_name[3630:3661],
+	151:  _ChErrorType_name[3661:3707],
+	152:  _ChErrorType_name[3707:3735],
+	153:  _ChErrorType_name[3735:3751],
+	154:  _ChErrorType_name[3751:3784],
+	155:  _ChErrorType_name[3784:3800],
+	156:  _ChErrorType_name[3800:3827],
+	157:  _ChErrorType_name[3827:3848],
+	158:  _ChErrorType_name[3848:3861],
+	159:  _ChErrorType_name[3861:3877],
+	160:  _ChErrorType_name[3877:3885],
+	161:  _ChErrorType_name[3885:3901],
+	162:  _ChErrorType_name[3901:3920],
+	163:  _ChErrorType_name[3920:3937],
+	164:  _ChErrorType_name[3937:3945],
+	165:  _ChErrorType_name[3945:3971],
+	166:  _ChErrorType_name[3971:4007],
+	167:  _ChErrorType_name[4007:4019],
+	168:  _ChErrorType_name[4019:4030],
+	169:  _ChErrorType_name[4030:4047],
+	170:  _ChErrorType_name[4047:4054],
+	172:  _ChErrorType_name[4054:4077],
+	173:  _ChErrorType_name[4077:4099],
+	174:  _ChErrorType_name[4099:4113],
+	176:  _ChErrorType_name[4113:4128],
+	177:  _ChErrorType_name[4128:4148],
+	178:  _ChErrorType_name[4148:4179],
+	179:  _ChErrorType_name[4179:4209],
+	180:  _ChErrorType_name[4209:4228],
+	181:  _ChErrorType_name[4228:4241],
+	182:  _ChErrorType_name[4241:4257],
+	183:  _ChErrorType_name[4257:4278],
+	184:  _ChErrorType_name[4278:4297],
+	185:  _ChErrorType_name[4297:4326],
+	186:  _ChErrorType_name[4326:4354],
+	187:  _ChErrorType_name[4354:4381],
+	188:  _ChErrorType_name[4381:4395],
+	189:  _ChErrorType_name[4395:4429],
+	190:  _ChErrorType_name[4429:4457],
+	191:  _ChErrorType_name[4457:4480],
+	192:  _ChErrorType_name[4480:4492],
+	193:  _ChErrorType_name[4492:4506],
+	194:  _ChErrorType_name[4506:4523],
+	195:  _ChErrorType_name[4523:4545],
+	196:  _ChErrorType_name[4545:4573],
+	197:  _ChErrorType_name[4573:4599],
+	198:  _ChErrorType_name[4599:4608],
+	199:  _ChErrorType_name[4608:4621],
+	200:  _ChErrorType_name[4621:4644],
+	201:  _ChErrorType_name[4644:4657],
+	202:  _ChErrorType_name[4657:4686],
+	203:  _ChErrorType_name[4686:4704],
+	204:  _ChErrorType_name[4704:4716],
+	205:  _ChErrorType_name[4716:4736],
+	206:  _ChErrorType_name[4736:4750],
+	207:  _ChErrorType_name[4750:4770],
+	208:  _ChErrorType_name[4770:4788],
+	209:  _ChErrorType_name[4788:4802],
+	210:  _ChErrorType_name[4802:4815],
+	211:  _ChErrorType_name[4815:4826],
+	212:  _ChErrorType_name[4826:4848],
+	213:  _ChErrorType_name[4848:4867],
+	214:  _ChErrorType_name[4867:4881],
+	215:  _ChErrorType_name[4881:4897],
+	216:  _ChErrorType_name[4897:4934],
+	217:  _ChErrorType_name[4934:4968],
+	218:  _ChErrorType_name[4968:4984],
+	219:  _ChErrorType_name[4984:5002],
+	220:  _ChErrorType_name[5002:5035],
+	221:  _ChErrorType_name[5035:5066],
+	222:  _ChErrorType_name[5066:5099],
+	223:  _ChErrorType_name[5099:5123],
+	224:  _ChErrorType_name[5123:5148],
+	225:  _ChErrorType_name[5148:5160],
+	226:  _ChErrorType_name[5160:5180],
+	227:  _ChErrorType_name[5180:5208],
+	228:  _ChErrorType_name[5208:5237],
+	229:  _ChErrorType_name[5237:5255],
+	230:  _ChErrorType_name[5255:5283],
+	231:  _ChErrorType_name[5283:5313],
+	232:  _ChErrorType_name[5313:5330],
+	233:  _ChErrorType_name[5330:5348],
+	234:  _ChErrorType_name[5348:5367],
+	235:  _ChErrorType_name[5367:5386],
+	236:  _ChErrorType_name[5386:5393],
+	237:  _ChErrorType_name[5393:5414],
+	238:  _ChErrorType_name[5414:5436],
+	239:  _ChErrorType_name[5436:5449],
+	240:  _ChErrorType_name[5449:5462],
+	241:  _ChErrorType_name[5462:5483],
+	242:  _ChErrorType_name[5483:5501],
+	243:  _ChErrorType_name[5501:5517],
+	244:  _ChErrorType_name[5517:5543],
+	246:  _ChErrorType_name[5543:5557],
+	247:  _ChErrorType_name[5557:5571],
+	248:  _ChErrorType_name[5571:5594],
+	250:  _ChErrorType_name[5594:5618],
+	251:  _ChErrorType_name[5618:5633],
+	252:  _ChErrorType_name[5633:5647],
+	253:  _ChErrorType_name[5647:5671],
+	254:  _ChErrorType_name[5671:5689],
+	255:  _ChErrorType_name[5689:5720],
+	256:  _ChErrorType_name[5720:5744],
+	257:  _ChErrorType_name[5744:5766],
+	258:  _ChErrorType_name[5766:5802],
+	260:  _ChErrorType_name[5802:5832],
+	261:  _ChErrorType_name[5832:5856],
+	262:  _ChErrorType_name[5856:5869],
+	263:  _ChErrorType_name[5869:5888],
+	264:  _ChErrorType_name[5888:5913],
+	265:  _ChErrorType_name[5913:5933],
+	266:  _ChErrorType_name[5933:5963],
+	267:  _ChErrorType_name[5963:6003],
+	268:  _ChErrorType_name[6003:6014],
+	269:  _ChErrorType_name[6014:6027],
+	270:  _ChErrorType_name[6027:6042],
+	271:  _ChErrorType_name[6042:6059],
+	272:  _ChErrorType_name[6059:6075],
+	273:  _ChErrorType_name[6075:6094],
+	274:  _ChErrorType_name[6094:6108],
+	275:  _ChErrorType_name[6108:6123],
+	277:  _ChErrorType_name[6123:6137],
+	279:  _ChErrorType_name[6137:6164],
+	280:  _ChErrorType_name[6164:6181],
+	281:  _ChErrorType_name[6181:6200],
+	282:  _ChErrorType_name[6200:6215],
+	283:  _ChErrorType_name[6215:6247],
+	284:  _ChErrorType_name[6247:6268],
+	285:  _ChErrorType_name[6268:6289],
+	286:  _ChErrorType_name[6289:6326],
+	287:  _ChErrorType_name[6326:6348],
+	288:  _ChErrorType_name[6348:6383],
+	289:  _ChErrorType_name[6383:6407],
+	290:  _ChErrorType_name[6407:6421],
+	291:  _ChErrorType_name[6421:6443],
+	293:  _ChErrorType_name[6443:6470],
+	294:  _ChErrorType_name[6470:6494],
+	295:  _ChErrorType_name[6494:6513],
+	296:  _ChErrorType_name[6513:6534],
+	297:  _ChErrorType_name[6534:6558],
+	298:  _ChErrorType_name[6558:6569],
+	299:  _ChErrorType_name[6569:6580],
+	300:  _ChErrorType_name[6580:6592],
+	301:  _ChErrorType_name[6592:6619],
+	302:  _ChErrorType_name[6619:6648],
+	303:  _ChErrorType_name[6648:6661],
+	304:  _ChErrorType_name[6661:6675],
+	305:  _ChErrorType_name[6675:6696],
+	306:  _ChErrorType_name[6696:6714],
+	307:  _ChErrorType_name[6714:6728],
+	308:  _ChErrorType_name[6728:6756],
+	309:  _ChErrorType_name[6756:6787],
+	317:  _ChErrorType_name[6787:6807],
+	318:  _ChErrorType_name[6807:6831],
+	319:  _ChErrorType_name[6831:6855],
+	321:  _ChErrorType_name[6855:6889],
+	335:  _ChErrorType_name[6889:6904],
+	336:  _ChErrorType_name[6904:6927],
+	337:  _ChErrorType_name[6927:6946],
+	341:  _ChErrorType_name[6946:6956],
+	342:  _ChErrorType_name[6956:6973],
+	344:  _ChErrorType_name[6973:6992],
+	345:  _ChErrorType_name[6992:7014],
+	346:  _ChErrorType_name[7014:7036],
+	347:  _ChErrorType_name[7036:7054],
+	349:  _ChErrorType_name[7054:7091],
+	350:  _ChErrorType_name[7091:7117],
+	351:  _ChErrorType_name[7117:7137],
+	352:  _ChErrorType_name[7137:7158],
+	353:  _ChErrorType_name[7158:7202],
+	354:  _ChErrorType_name[7202:7221],
+	355:  _ChErrorType_name[7221:7240],
+	356:  _ChErrorType_name[7240:7250],
+	357:  _ChErrorType_name[7250:7274],
+	358:  _ChErrorType_name[7274:7298],
+	359:  _ChErrorType_name[7298:7336],
+	360:  _ChErrorType_name[7336:7367],
+	361:  _ChErrorType_name[7367:7393],
+	362:  _ChErrorType_name[7393:7426],
+	363:  _ChErrorType_name[7426:7449],
+	364:  _ChErrorType_name[7449:7481],
+	366:  _ChErrorType_name[7481:7521],
+	367:  _ChErrorType_name[7521:7537],
+	369:  _ChErrorType_name[7537:7559],
+	370:  _ChErrorType_name[7559:7593],
+	371:  _ChErrorType_name[7593:7624],
+	372:  _ChErrorType_name[7624:7641],
+	373:  _ChErrorType_name[7641:7658],
+	374:  _ChErrorType_name[7658:7681],
+	375:  _ChErrorType_name[7681:7694],
+	376:  _ChErrorType_name[7694:7711],
+	377:  _ChErrorType_name[7711:7739],
+	378:  _ChErrorType_name[7739:7770],
+	379:  _ChErrorType_name[7770:7808],
+	380:  _ChErrorType_name[7808:7819],
+	381:  _ChErrorType_name[7819:7839],
+	382:  _ChErrorType_name[7839:7865],
+	383:  _ChErrorType_name[7865:7892],
+	384:  _ChErrorType_name[7892:7918],
+	385:  _ChErrorType_name[7918:7943],
+	386:  _ChErrorType_name[7943:7957],
+	387:  _ChErrorType_name[7957:7982],
+	388:  _ChErrorType_name[7982:8004],
+	389:  _ChErrorType_name[8004:8027],
+	390:  _ChErrorType_name[8027:8056],
+	391:  _ChErrorType_name[8056:8078],
+	392:  _ChErrorType_name[8078:8097],
+	393:  _ChErrorType_name[8097:8114],
+	394:  _ChErrorType_name[8114:8132],
+	395:  _ChErrorType_name[8132:8167],
+	396:  _ChErrorType_name[8167:8189],
+	397:  _ChErrorType_name[8189:8232],
+	398:  _ChErrorType_name[8232:8256],
+	399:  _ChErrorType_name[8256:8289],
+	400:  _ChErrorType_name[8289:8300],
+	401:  _ChErrorType_name[8300:8336],
+	402:  _ChErrorType_name[8336:8350],
+	403:  _ChErrorType_name[8350:8376],
+	404:  _ChErrorType_name[8376:8402],
+	405:  _ChErrorType_name[8402:8444],
+	406:  _ChErrorType_name[8444:8466],
+	407:  _ChErrorType_name[8466:8482],
+	408:  _ChErrorType_name[8482:8503],
+	409:  _ChErrorType_name[8503:8532],
+	410:  _ChErrorType_name[8532:8565],
+	411:  _ChErrorType_name[8565:8578],
+	412:  _ChErrorType_name[8578:8591],
+	413:  _ChErrorType_name[8591:8616],
+	415:  _ChErrorType_name[8616:8633],
+	416:  _ChErrorType_name[8633:8655],
+	417:  _ChErrorType_name[8655:8674],
+	418:  _ChErrorType_name[8674:8686],
+	419:  _ChErrorType_name[8686:8716],
+	420:  _ChErrorType_name[8716:8736],
+	421:  _ChErrorType_name[8736:8773],
+	422:  _ChErrorType_name[8773:8795],
+	423:  _ChErrorType_name[8795:8814],
+	424:  _ChErrorType_name[8814:8825],
+	425:  _ChErrorType_name[8825:8837],
+	427:  _ChErrorType_name[8837:8858],
+	428:  _ChErrorType_name[8858:8875],
+	429:  _ChErrorType_name[8875:8893],
+	430:  _ChErrorType_name[8893:8931],
+	431:  _ChErrorType_name[8931:8960],
+	432:  _ChErrorType_name[8960:8973],
+	433:  _ChErrorType_name[8973:8996],
+	434:  _ChErrorType_name[8996:9024],
+	435:  _ChErrorType_name[9024:9071],
+	436:  _ChErrorType_name[9071:9088],
+	437:  _ChErrorType_name[9088:9115],
+	438:  _ChErrorType_name[9115:9143],
+	439:  _ChErrorType_name[9143:9163],
+	440:  _ChErrorType_name[9163:9187],
+	441:  _ChErrorType_name[9187:9224],
+	442:  _ChErrorType_name[9224:9256],
+	443:  _ChErrorType_name[9256:9296],
+	444:  _ChErrorType_name[9296:9319],
+	445:  _ChErrorType_name[9319:9334],
+	446:  _ChErrorType_name[9334:9354],
+	447:  _ChErrorType_name[9354:9380],
+	448:  _ChErrorType_name[9380:9398],
+	449:  _ChErrorType_name[9398:9417],
+	450:  _ChErrorType_name[9417:9435],
+	451:  _ChErrorType_name[9435:9447],
+	452:  _ChErrorType_name[9447:9475],
+	453:  _ChErrorType_name[9475:9513],
+	454:  _ChErrorType_name[9513:9526],
+	455:  _ChErrorType_name[9526:9561],
+	456:  _ChErrorType_name[9561:9584],
+	457:  _ChErrorType_name[9584:9603],
+	458:  _ChErrorType_name[9603:9616],
+	459:  _ChErrorType_name[9616:9642],
+	460:  _ChErrorType_name[9642:9661],
+	461:  _ChErrorType_name[9661:9684],
+	462:  _ChErrorType_name[9684:9703],
+	463:  _ChErrorType_name[9703:9715],
+	464:  _ChErrorType_name[9715:9731],
+	465:  _ChErrorType_name[9731:9749],
+	466:  _ChErrorType_name[9749:9762],
+	467:  _ChErrorType_name[9762:9779],
+	468:  _ChErrorType_name[9779:9798],
+	469:  _ChErrorType_name[9798:9817],
+	470:  _ChErrorType_name[9817:9852],
+	471:  _ChErrorType_name[9852:9873],
+	472:  _ChErrorType_name[9873:9889],
+	473:  _ChErrorType_name[9889:9905],
+	474:  _ChErrorType_name[9905:9928],
+	475:  _ChErrorType_name[9928:9956],
+	476:  _ChErrorType_name[9956:9982],
+	477:  _ChErrorType_name[9982:10004],
+	478:  _ChErrorType_name[10004:10018],
+	479:  _ChErrorType_name[10018:10030],
+	480:  _ChErrorType_name[10030:10046],
+	481:  _ChErrorType_name[10046:10064],
+	482:  _ChErrorType_name[10064:10088],
+	483:  _ChErrorType_name[10088:10106],
+	484:  _ChErrorType_name[10106:10126],
+	485:  _ChErrorType_name[10126:10147],
+	487:  _ChErrorType_name[10147:10181],
+	488:  _ChErrorType_name[10181:10199],
+	489:  _ChErrorType_name[10199:10230],
+	490:  _ChErrorType_name[10230:10252],
+	491:  _ChErrorType_name[10252:10268],
+	492:  _ChErrorType_name[10268:10291],
+	493:  _ChErrorType_name[10291:10319],
+	494:  _ChErrorType_name[10319:10349],
+	495:  _ChErrorType_name[10349:10372],
+	496:  _ChErrorType_name[10372:10397],
+	497:  _ChErrorType_name[10397:10410],
+	498:  _ChErrorType_name[10410:10445],
+	499:  _ChErrorType_name[10445:10453],
+	500:  _ChErrorType_name[10453:10477],
+	501:  _ChErrorType_name[10477:10499],
+	502:  _ChErrorType_name[10499:10514],
+	503:  _ChErrorType_name[10514:10538],
+	504:  _ChErrorType_name[10538:10557],
+	505:  _ChErrorType_name[10557:10580],
+	506:  _ChErrorType_name[10580:10601],
+	507:  _ChErrorType_name[10601:10629],
+	508:  _ChErrorType_name[10629:10648],
+	509:  _ChErrorType_name[10648:10661],
+	510:  _ChErrorType_name[10661:10689],
+	511:  _ChErrorType_name[10689:10701],
+	512:  _ChErrorType_name[10701:10721],
+	513:  _ChErrorType_name[10721:10738],
+	514:  _ChErrorType_name[10738:10776],
+	515:  _ChErrorType_name[10776:10810],
+	516:  _ChErrorType_name[10810:10831],
+	517:  _ChErrorType_name[10831:10850],
+	518:  _ChErrorType_name[10850:10870],
+	519:  _ChErrorType_name[10870:10895],
+	520:  _ChErrorType_name[10895:10928],
+	521:  _ChErrorType_name[10928:10946],
+	523:  _ChErrorType_name[10946:10964],
+	524:  _ChErrorType_name[10964:10992],
+	525:  _ChErrorType_name[10992:11012],
+	527:  _ChErrorType_name[11012:11047],
+	528:  _ChErrorType_name[11047:11071],
+	529:  _ChErrorType_name[11071:11083],
+	530:  _ChErrorType_name[11083:11106],
+	531:  _ChErrorType_name[11106:11118],
+	532:  _ChErrorType_name[11118:11128],
+	533:  _ChErrorType_name[11128:11153],
+	534:  _ChErrorType_name[11153:11177],
+	535:  _ChErrorType_name[11177:11194],
+	536:  _ChErrorType_name[11194:11224],
+	537:  _ChErrorType_name[11224:11246],
+	538:  _ChErrorType_name[11246:11264],
+	539:  _ChErrorType_name[11264:11293],
+	540:  _ChErrorType_name[11293:11325],
+	541:  _ChErrorType_name[11325:11361],
+	542:  _ChErrorType_name[11361:11392],
+	543:  _ChErrorType_name[11392:11429],
+	544:  _ChErrorType_name[11429:11450],
+	545:  _ChErrorType_name[11450:11473],
+	546:  _ChErrorType_name[11473:11489],
+	547:  _ChErrorType_name[11489:11506],
+	548:  _ChErrorType_name[11506:11520],
+	549:  _ChErrorType_name[11520:11551],
+	550:  _ChErrorType_name[11551:11584],
+	551:  _ChErrorType_name[11584:11614],
+	552:  _ChErrorType_name[11614:11636],
+	553:  _ChErrorType_name[11636:11662],
+	554:  _ChErrorType_name[11662:11688],
+	555:  _ChErrorType_name[11688:11701],
+	556:  _ChErrorType_name[11701:11728],
+	557:  _ChErrorType_name[11728:11741],
+	558:  _ChErrorType_name[11741:11765],
+	559:  _ChErrorType_name[11765:11788],
+	560:  _ChErrorType_name[11788:11807],
+	561:  _ChErrorType_name[11807:11826],
+	562:  _ChErrorType_name[11826:11844],
+	563:  _ChErrorType_name[11844:11869],
+	564:  _ChErrorType_name[11869:11900],
+	565:  _ChErrorType_name[11900:11919],
+	566:  _ChErrorType_name[11919:11931],
+	567:  _ChErrorType_name[11931:11952],
+	568:  _ChErrorType_name[11952:11962],
+	569:  _ChErrorType_name[11962:12012],
+	570:  _ChErrorType_name[12012:12054],
+	571:  _ChErrorType_name[12054:12081],
+	572:  _ChErrorType_name[12081:12114],
+	573:  _ChErrorType_name[12114:12125],
+	574:  _ChErrorType_name[12125:12159],
+	575:  _ChErrorType_name[12159:12175],
+	576:  _ChErrorType_name[12175:12189],
+	577:  _ChErrorType_name[12189:12205],
+	578:  _ChErrorType_name[12205:12242],
+	579:  _ChErrorType_name[12242:12261],
+	580:  _ChErrorType_name[12261:12285],
+	581:  _ChErrorType_name[12285:12312],
+	582:  _ChErrorType_name[12312:12339],
+	583:  _ChErrorType_name[12339:12357],
+	584:  _ChErrorType_name[12357:12376],
+	585:  _ChErrorType_name[12376:12393],
+	586:  _ChErrorType_name[12393:12411],
+	587:  _ChErrorType_name[12411:12442],
+	588:  _ChErrorType_name[12442:12471],
+	589:  _ChErrorType_name[12471:12501],
+	590:  _ChErrorType_name[12501:12515],
+	591:  _ChErrorType_name[12515:12534],
+	592:  _ChErrorType_name[12534:12555],
+	593:  _ChErrorType_name[12555:12582],
+	594:  _ChErrorType_name[12582:12609],
+	595:  _ChErrorType_name[12609:12636],
+	596:  _ChErrorType_name[12636:12682],
+	597:  _ChErrorType_name[12682:12700],
+	598:  _ChErrorType_name[12700:12721],
+	599:  _ChErrorType_name[12721:12737],
+	600:  _ChErrorType_name[12737:12765],
+	601:  _ChErrorType_name[12765:12779],
+	602:  _ChErrorType_name[12779:12793],
+	603:  _ChErrorType_name[12793:12810],
+	604:  _ChErrorType_name[12810:12837],
+	605:  _ChErrorType_name[12837:12859],
+	606:  _ChErrorType_name[12859:12874],
+	607:  _ChErrorType_name[12874:12898],
+	608:  _ChErrorType_name[12898:12918],
+	609:  _ChErrorType_name[12918:12941],
+	610:  _ChErrorType_name[12941:12961],
+	611:  _ChErrorType_name[12961:12993],
+	612:  _ChErrorType_name[12993:13022],
+	613:  _ChErrorType_name[13022:13051],
+	614:  _ChErrorType_name[13051:13080],
+	615:  _ChErrorType_name[13080:13093],
+	616:  _ChErrorType_name[13093:13112],
+	617:  _ChErrorType_name[13112:13130],
+	618:  _ChErrorType_name[13130:13148],
+	619:  _ChErrorType_name[13148:13185],
+	620:  _ChErrorType_name[13185:13202],
+	621:  _ChErrorType_name[13202:13225],
+	622:  _ChErrorType_name[13225:13255],
+	623:  _ChErrorType_name[13255:13274],
+	624:  _ChErrorType_name[13274:13287],
+	625:  _ChErrorType_name[13287:13301],
+	626:  _ChErrorType_name[13301:13326],
+	627:  _ChErrorType_name[13326:13349],
+	628:  _ChErrorType_name[13349:13378],
+	629:  _ChErrorType_name[13378:13404],
+	630:  _ChErrorType_name[13404:13426],
+	631:  _ChErrorType_name[13426:13443],
+	632:  _ChErrorType_name[13443:13477],
+	633:  _ChErrorType_name[13477:13514],
+	634:  _ChErrorType_name[13514:13527],
+	635:  _ChErrorType_name[13527:13538],
+	636:  _ChErrorType_name[13538:13568],
+	637:  _ChErrorType_name[13568:13590],
+	638:  _ChErrorType_name[13590:13614],
+	639:  _ChErrorType_name[13614:13636],
+	640:  _ChErrorType_name[13636:13652],
+	641:  _ChErrorType_name[13652:13673],
+	642:  _ChErrorType_name[13673:13692],
+	643:  _ChErrorType_name[13692:13713],
+	999:  _ChErrorType_name[13713:13729],
+	1000: _ChErrorType_name[13729:13743],
+	1001: _ChErrorType_name[13743:13756],
+	1002: _ChErrorType_name[13756:13773],
+}
+
+func (i ChErrorType) String() string {
+	if str, ok := _ChErrorType_map[i]; ok {
+		return str
+	}
+	return "ChErrorType(" + strconv.FormatInt(int64(i), 10) + ")"
+}
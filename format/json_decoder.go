@@ -0,0 +1,1042 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+	"github.com/vahid-sohrabloo/chconn/v3/internal/helper"
+	"github.com/vahid-sohrabloo/chconn/v3/types"
+)
+
+// JSONDecoder decodes JSONEachRow (one object per row) or JSONCompactEachRow (one array per row)
+// input and appends the values directly to columns, for example the columns of an
+// [chconn.InsertStmt] or a [NativeWriter] schema.
+//
+// Values are converted with the rules of each column type:
+//   - numbers are read from JSON numbers or strings; 64 bit and larger integers keep full precision
+//   - Decimal is read from a string or a number and truncated to the scale of the type
+//   - Date and Date32 are read from "2006-01-02" strings or a number of days since the epoch
+//   - DateTime and DateTime64 are read from RFC 3339 strings, "2006-01-02 15:04:05[.fraction]" strings
+//     or (fractional) seconds since the epoch
+//   - Enum is read from the name or the value
+//   - UUID, IPv4 and IPv6 are read from their text form
+//   - Array from arrays, Map from objects, and Tuple from arrays or objects by element name
+//
+// Missing fields and null values for non-nullable columns are appended as the default value of the type.
+type JSONDecoder struct {
+	dec     *json.Decoder
+	columns []column.ColumnCore
+	fields  []jsonField
+	index   map[string]int
+	seen    []bool
+	compact bool
+	row     int
+	depth   int
+
+	loc         *time.Location
+	skipUnknown bool
+}
+
+// JSONOption configures a JSONDecoder.
+type JSONOption func(*JSONDecoder)
+
+// WithJSONLocation sets the location of date and time strings without a time zone for
+// DateTime columns without a time zone in their type. The default is UTC.
+func WithJSONLocation(loc *time.Location) JSONOption {
+	return func(d *JSONDecoder) { d.loc = loc }
+}
+
+// WithJSONSkipUnknownFields ignores object fields that don't match any column (or tuple element)
+// instead of returning an error.
+func WithJSONSkipUnknownFields() JSONOption {
+	return func(d *JSONDecoder) { d.skipUnknown = true }
+}
+
+// NewJSONEachRowDecoder returns a decoder of JSONEachRow input, where each row is an object
+// keyed by column name.
+func NewJSONEachRowDecoder(r io.Reader, columns []column.ColumnCore, opts ...JSONOption) (*JSONDecoder, error) {
+	return newJSONDecoder(r, columns, false, opts)
+}
+
+// NewJSONCompactEachRowDecoder returns a decoder of JSONCompactEachRow input, where each row is
+// an array of values in column order.
+func NewJSONCompactEachRowDecoder(r io.Reader, columns []column.ColumnCore, opts ...JSONOption) (*JSONDecoder, error) {
+	return newJSONDecoder(r, columns, true, opts)
+}
+
+func newJSONDecoder(r io.Reader, columns []column.ColumnCore, compact bool, opts []JSONOption) (*JSONDecoder, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("json: no columns")
+	}
+	d := &JSONDecoder{
+		dec:     json.NewDecoder(r),
+		columns: columns,
+		fields:  make([]jsonField, len(columns)),
+		index:   make(map[string]int, len(columns)),
+		seen:    make([]bool, len(columns)),
+		compact: compact,
+		loc:     time.UTC,
+	}
+	d.dec.UseNumber()
+	for _, o := range opts {
+		o(d)
+	}
+	for i, col := range columns {
+		field, err := d.newField(col.Type(), col)
+		if err != nil {
+			return nil, fmt.Errorf("json: column %q: %w", string(col.Name()), err)
+		}
+		d.fields[i] = field
+		d.index[string(col.Name())] = i
+	}
+	return d, nil
+}
+
+// Columns returns the columns the decoder appends to.
+func (d *JSONDecoder) Columns() []column.ColumnCore {
+	return d.columns
+}
+
+// DecodeRow decodes the next row of the input and appends it to the columns.
+// It returns io.EOF when there are no more rows.
+//
+// If a row can't be decoded, the values already appended for it are removed from the columns
+// and the rest of the row is skipped, so the caller can log the error and call DecodeRow again
+// to continue with the next row. If the input itself is not valid JSON, every later call
+// returns an error too.
+func (d *JSONDecoder) DecodeRow() error {
+	tok, err := d.token()
+	if err != nil {
+		return err
+	}
+	for _, f := range d.fields {
+		f.mark()
+	}
+	if d.compact {
+		err = d.decodeCompactRow(tok)
+	} else {
+		err = d.decodeRow(tok)
+	}
+	row := d.row
+	d.row++
+	if err != nil {
+		for _, f := range d.fields {
+			f.rollback()
+		}
+		for d.depth > 0 {
+			if _, skipErr := d.token(); skipErr != nil {
+				break
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("json: row %d: %w", row, err)
+	}
+	return nil
+}
+
+// Decode decodes up to maxRows rows (all rows if maxRows <= 0) and returns the number of
+// decoded rows. It returns io.EOF together with the number of rows decoded before the end
+// of the input, so a caller can flush the last batch and stop.
+// On a decode error it returns the rows decoded before the failed row, which is not kept.
+func (d *JSONDecoder) Decode(maxRows int) (int, error) {
+	n := 0
+	for maxRows <= 0 || n < maxRows {
+		if err := d.DecodeRow(); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+func (d *JSONDecoder) decodeRow(tok json.Token) error {
+	if tok != json.Delim('{') {
+		return fmt.Errorf("expected object, got %v", tok)
+	}
+	clear(d.seen)
+	for d.dec.More() {
+		key, err := d.token()
+		if err != nil {
+			return err
+		}
+		name, _ := key.(string)
+		i, ok := d.index[name]
+		if !ok {
+			if !d.skipUnknown {
+				return fmt.Errorf("unknown field %q", name)
+			}
+			if err := d.skipValue(); err != nil {
+				return err
+			}
+			continue
+		}
+		if d.seen[i] {
+			return fmt.Errorf("duplicate field %q", name)
+		}
+		d.seen[i] = true
+		if err := d.decodeField(i); err != nil {
+			return err
+		}
+	}
+	if _, err := d.token(); err != nil {
+		return err
+	}
+	for i, seen := range d.seen {
+		if !seen {
+			if err := d.fields[i].appendDefault(); err != nil {
+				return fmt.Errorf("column %q: %w", string(d.columns[i].Name()), err)
+			}
+		}
+	}
+	return nil
+}
+
+func (d *JSONDecoder) decodeCompactRow(tok json.Token) error {
+	if tok != json.Delim('[') {
+		return fmt.Errorf("expected array, got %v", tok)
+	}
+	i := 0
+	for ; d.dec.More(); i++ {
+		if i == len(d.fields) {
+			return fmt.Errorf("too many values, expected %d", len(d.fields))
+		}
+		if err := d.decodeField(i); err != nil {
+			return err
+		}
+	}
+	if i != len(d.fields) {
+		return fmt.Errorf("got %d values, expected %d", i, len(d.fields))
+	}
+	_, err := d.token()
+	return err
+}
+
+func (d *JSONDecoder) decodeField(i int) error {
+	tok, err := d.token()
+	if err != nil {
+		return err
+	}
+	if err := d.fields[i].decode(d, tok); err != nil {
+		return fmt.Errorf("column %q: %w", string(d.columns[i].Name()), err)
+	}
+	return nil
+}
+
+// token reads the next token of the input and tracks the nesting depth.
+func (d *JSONDecoder) token() (json.Token, error) {
+	tok, err := d.dec.Token()
+	switch tok {
+	case json.Delim('{'), json.Delim('['):
+		d.depth++
+	case json.Delim('}'), json.Delim(']'):
+		d.depth--
+	}
+	return tok, err
+}
+
+// skipValue skips the next value of the input.
+func (d *JSONDecoder) skipValue() error {
+	depth := d.depth
+	for {
+		if _, err := d.token(); err != nil {
+			return err
+		}
+		if d.depth == depth {
+			return nil
+		}
+	}
+}
+
+// closeDelim reads the closing delimiter of the current array or object.
+func (d *JSONDecoder) closeDelim() error {
+	_, err := d.token()
+	return err
+}
+
+// jsonField decodes JSON values into a column.
+type jsonField interface {
+	// decode decodes the value starting with tok.
+	decode(d *JSONDecoder, tok json.Token) error
+	appendDefault() error
+	// mark records the number of rows of the column and its nested columns.
+	mark()
+	// rollback removes the rows appended since the last mark.
+	rollback()
+}
+
+type jsonArrayColumn interface {
+	NumRow() int
+	Remove(n int)
+	AppendLen(int)
+	Column() column.ColumnCore
+}
+
+type jsonMapColumn interface {
+	NumRow() int
+	Remove(n int)
+	AppendLen(int)
+	KeyColumn() column.ColumnCore
+	ValueColumn() column.ColumnCore
+}
+
+type jsonTupleColumn interface {
+	Columns() []column.ColumnCore
+}
+
+type jsonNullColumn interface {
+	AppendNil()
+}
+
+func (d *JSONDecoder) newField(chType []byte, col column.ColumnCore) (jsonField, error) {
+	chType = helper.NestedToArrayType(helper.FilterSimpleAggregate(chType))
+	switch {
+	case helper.IsArray(chType):
+		arr, ok := col.(jsonArrayColumn)
+		if !ok {
+			return nil, fmt.Errorf("unexpected column %T for %s", col, chType)
+		}
+		elem, err := d.newField(chType[helper.LenArrayStr:len(chType)-1], arr.Column())
+		if err != nil {
+			return nil, err
+		}
+		return &jsonArray{col: arr, elem: elem}, nil
+	case helper.IsMap(chType):
+		m, ok := col.(jsonMapColumn)
+		if !ok {
+			return nil, fmt.Errorf("unexpected column %T for %s", col, chType)
+		}
+		kv, err := helper.TypesInParentheses(chType[helper.LenMapStr : len(chType)-1])
+		if err != nil {
+			return nil, err
+		}
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid map type %s", chType)
+		}
+		key, err := d.newLeaf(kv[0].ChType, m.KeyColumn())
+		if err != nil {
+			return nil, err
+		}
+		value, err := d.newField(kv[1].ChType, m.ValueColumn())
+		if err != nil {
+			return nil, err
+		}
+		return &jsonMap{col: m, key: key, value: value}, nil
+	case helper.IsTuple(chType):
+		t, ok := col.(jsonTupleColumn)
+		if !ok {
+			return nil, fmt.Errorf("unexpected column %T for %s", col, chType)
+		}
+		elems, err := helper.TypesInParentheses(chType[helper.LenTupleStr : len(chType)-1])
+		if err != nil {
+			return nil, err
+		}
+		columns := t.Columns()
+		if len(elems) != len(columns) {
+			return nil, fmt.Errorf("tuple %s has %d columns", chType, len(columns))
+		}
+		tuple := &jsonTuple{
+			fields: make([]jsonField, len(elems)),
+			index:  make(map[string]int, len(elems)),
+			seen:   make([]bool, len(elems)),
+		}
+		for i, elem := range elems {
+			tuple.fields[i], err = d.newField(elem.ChType, columns[i])
+			if err != nil {
+				return nil, err
+			}
+			if len(elem.Name) > 0 {
+				tuple.index[string(elem.Name)] = i
+			}
+		}
+		return tuple, nil
+	}
+	return d.newLeaf(chType, col)
+}
+
+type jsonArray struct {
+	col  jsonArrayColumn
+	elem jsonField
+	rows int
+}
+
+func (a *jsonArray) decode(d *JSONDecoder, tok json.Token) error {
+	if tok == nil {
+		return a.appendDefault()
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("expected array, got %v", tok)
+	}
+	n := 0
+	for ; d.dec.More(); n++ {
+		tok, err := d.token()
+		if err != nil {
+			return err
+		}
+		if err := a.elem.decode(d, tok); err != nil {
+			return fmt.Errorf("array item %d: %w", n, err)
+		}
+	}
+	a.col.AppendLen(n)
+	return d.closeDelim()
+}
+
+func (a *jsonArray) appendDefault() error {
+	a.col.AppendLen(0)
+	return nil
+}
+
+func (a *jsonArray) mark() {
+	a.rows = a.col.NumRow()
+	a.elem.mark()
+}
+
+func (a *jsonArray) rollback() {
+	a.col.Remove(a.rows)
+	a.elem.rollback()
+}
+
+type jsonMap struct {
+	col   jsonMapColumn
+	key   jsonField
+	value jsonField
+	rows  int
+}
+
+func (m *jsonMap) decode(d *JSONDecoder, tok json.Token) error {
+	if tok == nil {
+		return m.appendDefault()
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("expected object, got %v", tok)
+	}
+	n := 0
+	for ; d.dec.More(); n++ {
+		key, err := d.token()
+		if err != nil {
+			return err
+		}
+		if err := m.key.decode(d, key); err != nil {
+			return fmt.Errorf("map key %v: %w", key, err)
+		}
+		tok, err := d.token()
+		if err != nil {
+			return err
+		}
+		if err := m.value.decode(d, tok); err != nil {
+			return fmt.Errorf("map value of %v: %w", key, err)
+		}
+	}
+	m.col.AppendLen(n)
+	return d.closeDelim()
+}
+
+func (m *jsonMap) appendDefault() error {
+	m.col.AppendLen(0)
+	return nil
+}
+
+func (m *jsonMap) mark() {
+	m.rows = m.col.NumRow()
+	m.key.mark()
+	m.value.mark()
+}
+
+func (m *jsonMap) rollback() {
+	m.col.Remove(m.rows)
+	m.key.rollback()
+	m.value.rollback()
+}
+
+type jsonTuple struct {
+	fields []jsonField
+	index  map[string]int
+	seen   []bool
+}
+
+func (t *jsonTuple) decode(d *JSONDecoder, tok json.Token) error {
+	switch tok {
+	case nil:
+		return t.appendDefault()
+	case json.Delim('['):
+		return t.decodeArray(d)
+	case json.Delim('{'):
+		return t.decodeObject(d)
+	}
+	return fmt.Errorf("expected array or object, got %v", tok)
+}
+
+func (t *jsonTuple) decodeArray(d *JSONDecoder) error {
+	i := 0
+	for ; d.dec.More(); i++ {
+		if i == len(t.fields) {
+			return fmt.Errorf("too many tuple values, expected %d", len(t.fields))
+		}
+		tok, err := d.token()
+		if err != nil {
+			return err
+		}
+		if err := t.fields[i].decode(d, tok); err != nil {
+			return fmt.Errorf("tuple item %d: %w", i, err)
+		}
+	}
+	if i != len(t.fields) {
+		return fmt.Errorf("got %d tuple values, expected %d", i, len(t.fields))
+	}
+	return d.closeDelim()
+}
+
+func (t *jsonTuple) decodeObject(d *JSONDecoder) error {
+	seen := t.seen
+	clear(seen)
+	for d.dec.More() {
+		key, err := d.token()
+		if err != nil {
+			return err
+		}
+		name, _ := key.(string)
+		i, ok := t.index[name]
+		if !ok {
+			if !d.skipUnknown {
+				return fmt.Errorf("unknown tuple element %q", name)
+			}
+			if err := d.skipValue(); err != nil {
+				return err
+			}
+			continue
+		}
+		if seen[i] {
+			return fmt.Errorf("duplicate tuple element %q", name)
+		}
+		seen[i] = true
+		tok, err := d.token()
+		if err != nil {
+			return err
+		}
+		if err := t.fields[i].decode(d, tok); err != nil {
+			return fmt.Errorf("tuple element %q: %w", name, err)
+		}
+	}
+	for i, ok := range seen {
+		if !ok {
+			if err := t.fields[i].appendDefault(); err != nil {
+				return err
+			}
+		}
+	}
+	return d.closeDelim()
+}
+
+func (t *jsonTuple) appendDefault() error {
+	for _, f := range t.fields {
+		if err := f.appendDefault(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *jsonTuple) mark() {
+	for _, f := range t.fields {
+		f.mark()
+	}
+}
+
+func (t *jsonTuple) rollback() {
+	for _, f := range t.fields {
+		f.rollback()
+	}
+}
+
+// jsonParser converts a scalar JSON token (string, json.Number or bool) to the Go type of a column.
+type jsonParser func(tok json.Token) (any, error)
+
+type jsonLeaf struct {
+	col   column.ColumnCore
+	null  jsonNullColumn
+	parse jsonParser
+	zero  any
+	rows  int
+}
+
+func (l *jsonLeaf) decode(_ *JSONDecoder, tok json.Token) error {
+	if tok == nil {
+		if l.null != nil {
+			l.null.AppendNil()
+			return nil
+		}
+		return l.col.AppendAny(l.zero)
+	}
+	if _, ok := tok.(json.Delim); ok {
+		return fmt.Errorf("expected scalar value, got %v", tok)
+	}
+	v, err := l.parse(tok)
+	if err != nil {
+		return err
+	}
+	return l.col.AppendAny(v)
+}
+
+func (l *jsonLeaf) appendDefault() error {
+	if l.null != nil {
+		l.null.AppendNil()
+		return nil
+	}
+	return l.col.AppendAny(l.zero)
+}
+
+func (l *jsonLeaf) mark() {
+	l.rows = l.col.NumRow()
+}
+
+func (l *jsonLeaf) rollback() {
+	l.col.Remove(l.rows)
+}
+
+//nolint:funlen,gocyclo
+func (d *JSONDecoder) newLeaf(chType []byte, col column.ColumnCore) (*jsonLeaf, error) {
+	chType = helper.FilterSimpleAggregate(chType)
+	if helper.IsLowCardinality(chType) {
+		chType = chType[helper.LenLowCardinalityStr : len(chType)-1]
+	}
+	leaf := &jsonLeaf{col: col}
+	if helper.IsNullable(chType) {
+		chType = chType[helper.LenNullableStr : len(chType)-1]
+		null, ok := col.(jsonNullColumn)
+		if !ok {
+			return nil, fmt.Errorf("unexpected column %T for Nullable(%s)", col, chType)
+		}
+		leaf.null = null
+	}
+
+	switch {
+	case string(chType) == "Bool":
+		leaf.parse, leaf.zero = parseJSONBool, false
+	case string(chType) == "Int8":
+		leaf.parse, leaf.zero = jsonIntParser[int8](8), int8(0)
+	case string(chType) == "Int16":
+		leaf.parse, leaf.zero = jsonIntParser[int16](16), int16(0)
+	case string(chType) == "Int32":
+		leaf.parse, leaf.zero = jsonIntParser[int32](32), int32(0)
+	case string(chType) == "Int64":
+		leaf.parse, leaf.zero = jsonIntParser[int64](64), int64(0)
+	case string(chType) == "UInt8":
+		leaf.parse, leaf.zero = jsonUintParser[uint8](8), uint8(0)
+	case string(chType) == "UInt16":
+		leaf.parse, leaf.zero = jsonUintParser[uint16](16), uint16(0)
+	case string(chType) == "UInt32":
+		leaf.parse, leaf.zero = jsonUintParser[uint32](32), uint32(0)
+	case string(chType) == "UInt64":
+		leaf.parse, leaf.zero = jsonUintParser[uint64](64), uint64(0)
+	case string(chType) == "Int128":
+		leaf.parse, leaf.zero = jsonBigParser(types.Int128FromBigEx), types.Int128{}
+	case string(chType) == "Int256":
+		leaf.parse, leaf.zero = jsonBigParser(types.Int256FromBigEx), types.Int256{}
+	case string(chType) == "UInt128":
+		leaf.parse, leaf.zero = jsonBigParser(types.Uint128FromBigEx), types.Uint128{}
+	case string(chType) == "UInt256":
+		leaf.parse, leaf.zero = jsonBigParser(types.Uint256FromBigEx), types.Uint256{}
+	case string(chType) == "Float32":
+		leaf.parse, leaf.zero = parseJSONFloat32, float32(0)
+	case string(chType) == "Float64":
+		leaf.parse, leaf.zero = parseJSONFloat64, float64(0)
+	case string(chType) == "BFloat16":
+		leaf.parse, leaf.zero = parseJSONBFloat16, types.BFloat16(0)
+	case string(chType) == "String":
+		leaf.parse, leaf.zero = parseJSONString, ""
+	case helper.IsFixedString(chType):
+		size, err := strconv.Atoi(string(chType[helper.FixedStringStrLen : len(chType)-1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixed string length: %s: %w", chType, err)
+		}
+		rtype := reflect.ArrayOf(size, reflect.TypeFor[byte]())
+		leaf.parse, leaf.zero = jsonFixedStringParser(rtype), reflect.Zero(rtype).Interface()
+	case helper.IsEnum8(chType):
+		return leaf, leaf.setEnum(chType[helper.Enum8StrLen:len(chType)-1], func(v int16) any { return int8(v) })
+	case helper.IsEnum16(chType):
+		return leaf, leaf.setEnum(chType[helper.Enum16StrLen:len(chType)-1], func(v int16) any { return v })
+	case helper.IsDecimal(chType):
+		return leaf, leaf.setDecimal(chType)
+	case string(chType) == "Date", string(chType) == "Date32":
+		leaf.parse, leaf.zero = parseJSONDate, time.Unix(0, 0).UTC()
+	case string(chType) == "DateTime", helper.IsDateTimeWithParam(chType), helper.IsDateTime64(chType):
+		leaf.parse, leaf.zero = jsonDateTimeParser(d.dateTimeLocation(chType)), time.Unix(0, 0).UTC()
+	case string(chType) == "UUID":
+		leaf.parse, leaf.zero = parseJSONUUID, types.UUID{}
+	case string(chType) == "IPv4":
+		leaf.parse, leaf.zero = parseJSONIPv4, types.IPv4{}
+	case string(chType) == "IPv6":
+		leaf.parse, leaf.zero = parseJSONIPv6, types.IPv6{}
+	default:
+		return nil, fmt.Errorf("unsupported type %s", chType)
+	}
+	return leaf, nil
+}
+
+func (l *jsonLeaf) setEnum(values []byte, conv func(int16) any) error {
+	intToString, stringToInt, err := helper.ExtractEnum(values)
+	if err != nil {
+		return err
+	}
+	// the default value of an enum is the smallest value
+	zero := int16(math.MaxInt16)
+	for v := range intToString {
+		zero = min(zero, v)
+	}
+	l.zero = conv(zero)
+	l.parse = func(tok json.Token) (any, error) {
+		if name, ok := tok.(string); ok {
+			if v, ok := stringToInt[name]; ok {
+				return conv(v), nil
+			}
+		}
+		s, err := jsonNumber(tok)
+		if err != nil {
+			return nil, err
+		}
+		v, err := strconv.ParseInt(s, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("unknown enum value %v", tok)
+		}
+		if _, ok := intToString[int16(v)]; !ok {
+			return nil, fmt.Errorf("unknown enum value %v", tok)
+		}
+		return conv(int16(v)), nil
+	}
+	return nil
+}
+
+func (l *jsonLeaf) setDecimal(chType []byte) error {
+	params := bytes.Split(chType[helper.DecimalStrLen:len(chType)-1], []byte(", "))
+	if len(params) != 2 {
+		return fmt.Errorf("invalid decimal type %s", chType)
+	}
+	precision, err := strconv.Atoi(string(params[0]))
+	if err != nil {
+		return fmt.Errorf("invalid decimal precision %s: %w", chType, err)
+	}
+	scale, err := strconv.Atoi(string(params[1]))
+	if err != nil {
+		return fmt.Errorf("invalid decimal scale %s: %w", chType, err)
+	}
+
+	var conv func(*big.Int) (any, bool)
+	switch {
+	case precision <= 9:
+		l.zero = types.Decimal32(0)
+		conv = func(i *big.Int) (any, bool) {
+			return types.Decimal32(i.Int64()), i.IsInt64() && i.Int64() >= math.MinInt32 && i.Int64() <= math.MaxInt32
+		}
+	case precision <= 18:
+		l.zero = types.Decimal64(0)
+		conv = func(i *big.Int) (any, bool) {
+			return types.Decimal64(i.Int64()), i.IsInt64()
+		}
+	case precision <= 38:
+		l.zero = types.Decimal128{}
+		conv = func(i *big.Int) (any, bool) {
+			v, ok := types.Int128FromBigEx(i)
+			return types.Decimal128(v), ok
+		}
+	case precision <= 76:
+		l.zero = types.Decimal256{}
+		conv = func(i *big.Int) (any, bool) {
+			v, ok := types.Int256FromBigEx(i)
+			return types.Decimal256(v), ok
+		}
+	default:
+		return fmt.Errorf("max precision is 76 but got %d: %s", precision, chType)
+	}
+
+	multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)
+	l.parse = func(tok json.Token) (any, error) {
+		s, err := jsonNumber(tok)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := new(big.Rat).SetString(s)
+		if !ok {
+			return nil, fmt.Errorf("invalid decimal %q", s)
+		}
+		num := new(big.Int).Mul(r.Num(), multiplier)
+		num.Quo(num, r.Denom())
+		if new(big.Int).Abs(num).Cmp(limit) >= 0 {
+			return nil, fmt.Errorf("decimal %s overflows %s", s, chType)
+		}
+		v, ok := conv(num)
+		if !ok {
+			return nil, fmt.Errorf("decimal %s overflows %s", s, chType)
+		}
+		return v, nil
+	}
+	return nil
+}
+
+// dateTimeLocation returns the time zone of a DateTime or DateTime64 type, or the decoder
+// location if the type has no time zone.
+func (d *JSONDecoder) dateTimeLocation(chType []byte) *time.Location {
+	var params [][]byte
+	switch {
+	case helper.IsDateTime64(chType):
+		params = bytes.Split(chType[helper.DateTime64StrLen:len(chType)-1], []byte(", "))
+		params = params[1:]
+	case helper.IsDateTimeWithParam(chType):
+		params = [][]byte{chType[helper.DateTimeStrLen : len(chType)-1]}
+	}
+	if len(params) > 0 && len(params[0]) >= 3 {
+		if loc, err := time.LoadLocation(string(params[0][1 : len(params[0])-1])); err == nil {
+			return loc
+		}
+	}
+	return d.loc
+}
+
+// jsonNumber returns the text of a number token, or of a string token holding a number.
+func jsonNumber(tok json.Token) (string, error) {
+	switch v := tok.(type) {
+	case json.Number:
+		return string(v), nil
+	case string:
+		return v, nil
+	}
+	return "", fmt.Errorf("expected number, got %v", tok)
+}
+
+func jsonIntParser[T int8 | int16 | int32 | int64](bitSize int) jsonParser {
+	return func(tok json.Token) (any, error) {
+		s, err := jsonNumber(tok)
+		if err != nil {
+			return nil, err
+		}
+		v, err := strconv.ParseInt(s, 10, bitSize)
+		if err != nil {
+			return nil, err
+		}
+		return T(v), nil
+	}
+}
+
+func jsonUintParser[T uint8 | uint16 | uint32 | uint64](bitSize int) jsonParser {
+	return func(tok json.Token) (any, error) {
+		s, err := jsonNumber(tok)
+		if err != nil {
+			return nil, err
+		}
+		v, err := strconv.ParseUint(s, 10, bitSize)
+		if err != nil {
+			return nil, err
+		}
+		return T(v), nil
+	}
+}
+
+func jsonBigParser[T any](conv func(*big.Int) (T, bool)) jsonParser {
+	return func(tok json.Token) (any, error) {
+		s, err := jsonNumber(tok)
+		if err != nil {
+			return nil, err
+		}
+		i, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", s)
+		}
+		v, ok := conv(i)
+		if !ok {
+			return nil, fmt.Errorf("integer %s out of range", s)
+		}
+		return v, nil
+	}
+}
+
+func parseJSONFloat64(tok json.Token) (any, error) {
+	s, err := jsonNumber(tok)
+	if err != nil {
+		return nil, err
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func parseJSONFloat32(tok json.Token) (any, error) {
+	s, err := jsonNumber(tok)
+	if err != nil {
+		return nil, err
+	}
+	v, err := strconv.ParseFloat(s, 32)
+	return float32(v), err
+}
+
+func parseJSONBFloat16(tok json.Token) (any, error) {
+	v, err := parseJSONFloat32(tok)
+	if err != nil {
+		return nil, err
+	}
+	return types.BFloat16FromFloat32(v.(float32)), nil
+}
+
+func parseJSONBool(tok json.Token) (any, error) {
+	switch v := tok.(type) {
+	case bool:
+		return v, nil
+	case json.Number:
+		switch v {
+		case "0":
+			return false, nil
+		case "1":
+			return true, nil
+		}
+	case string:
+		return strconv.ParseBool(v)
+	}
+	return nil, fmt.Errorf("expected bool, got %v", tok)
+}
+
+func parseJSONString(tok json.Token) (any, error) {
+	switch v := tok.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return string(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return nil, fmt.Errorf("expected string, got %v", tok)
+}
+
+func jsonFixedStringParser(rtype reflect.Type) jsonParser {
+	return func(tok json.Token) (any, error) {
+		s, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %v", tok)
+		}
+		if len(s) > rtype.Len() {
+			return nil, fmt.Errorf("string of %d bytes is too long for FixedString(%d)", len(s), rtype.Len())
+		}
+		v := reflect.New(rtype).Elem()
+		reflect.Copy(v, reflect.ValueOf(s))
+		return v.Interface(), nil
+	}
+}
+
+func parseJSONDate(tok json.Token) (any, error) {
+	if s, ok := tok.(string); ok {
+		if t, err := time.Parse(time.DateOnly, s); err == nil {
+			return t, nil
+		}
+	}
+	s, err := jsonNumber(tok)
+	if err != nil {
+		return nil, err
+	}
+	days, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", s)
+	}
+	return time.Unix(days*24*60*60, 0).UTC(), nil
+}
+
+var jsonDateTimeLayouts = []string{time.DateTime, "2006-01-02T15:04:05", time.DateOnly}
+
+func jsonDateTimeParser(loc *time.Location) jsonParser {
+	return func(tok json.Token) (any, error) {
+		if s, ok := tok.(string); ok {
+			if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+				return t, nil
+			}
+			for _, layout := range jsonDateTimeLayouts {
+				if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+					return t, nil
+				}
+			}
+		}
+		s, err := jsonNumber(tok)
+		if err != nil {
+			return nil, err
+		}
+		return parseEpoch(s)
+	}
+}
+
+// parseEpoch parses seconds since the epoch with an optional fraction of up to nanoseconds.
+func parseEpoch(s string) (time.Time, error) {
+	secs, frac, _ := strings.Cut(s, ".")
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date time %q", s)
+	}
+	var nsec int64
+	if frac != "" {
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		nsec, err = strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date time %q", s)
+		}
+		if strings.HasPrefix(secs, "-") {
+			nsec = -nsec
+		}
+	}
+	return time.Unix(sec, nsec), nil
+}
+
+func parseJSONUUID(tok json.Token) (any, error) {
+	s, ok := tok.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected string, got %v", tok)
+	}
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return types.UUIDFromBigEndian(u), nil
+}
+
+func parseJSONIPv4(tok json.Token) (any, error) {
+	if n, ok := tok.(json.Number); ok {
+		v, err := strconv.ParseUint(string(n), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		return types.IPv4{byte(v), byte(v >> 8), byte(v >> 16), byte(v >> 24)}, nil
+	}
+	s, ok := tok.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected string, got %v", tok)
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return nil, err
+	}
+	addr = addr.Unmap()
+	if !addr.Is4() {
+		return nil, fmt.Errorf("%s is not an IPv4 address", s)
+	}
+	return types.IPv4FromAddr(addr), nil
+}
+
+func parseJSONIPv6(tok json.Token) (any, error) {
+	s, ok := tok.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected string, got %v", tok)
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return nil, err
+	}
+	return types.IPv6FromAddr(addr), nil
+}
@@ -0,0 +1,240 @@
+package format_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+	"github.com/vahid-sohrabloo/chconn/v3/format"
+)
+
+func jsonDecoderColumns(t *testing.T, headers ...string) []column.ColumnCore {
+	t.Helper()
+	var h []column.ColumnHeader
+	for _, header := range headers {
+		name, chType, _ := strings.Cut(header, " ")
+		h = append(h, column.ColumnHeader{Name: []byte(name), ChType: []byte(chType)})
+	}
+	w := format.NewNativeWriter(io.Discard)
+	if err := w.SetColumns(h); err != nil {
+		t.Fatalf("SetColumns: %v", err)
+	}
+	return w.Columns()
+}
+
+func columnJSON(col column.ColumnCore) string {
+	var rows []string
+	for i := 0; i < col.NumRow(); i++ {
+		rows = append(rows, string(col.ToJSON(i, false, nil)))
+	}
+	return strings.Join(rows, "|")
+}
+
+func TestJSONEachRowDecoder(t *testing.T) {
+	columns := jsonDecoderColumns(t,
+		"id UInt64",
+		"i128 Int128",
+		"f Float32",
+		"ok Bool",
+		"name String",
+		"code FixedString(3)",
+		"lc LowCardinality(String)",
+		"n Nullable(Int32)",
+		"e Enum8('a' = 1, 'b' = 2)",
+		"d Decimal(18, 2)",
+		"d256 Decimal(76, 3)",
+		"day Date",
+		"ts DateTime('UTC')",
+		"ts64 DateTime64(3, 'UTC')",
+		"u UUID",
+		"ip4 IPv4",
+		"ip6 IPv6",
+		"arr Array(Array(Int16))",
+		"an Array(Nullable(String))",
+		"m Map(String, Array(UInt8))",
+		"tp Tuple(a Int32, b String)",
+		"ta Array(Tuple(Int8, String))",
+	)
+	input := `{"id": 18446744073709551615, "i128": "-170141183460469231731687303715884105728", "f": 1.5, "ok": true,` +
+		` "name": "a\"b", "code": "ab", "lc": "x", "n": null, "e": "b", "d": "12.345", "d256": 1.5,` +
+		` "day": "2024-02-29", "ts": "2024-01-02T03:04:05+01:00", "ts64": 1700000000.123,` +
+		` "u": "417ddc5d-e556-4d27-95dd-a34d84e46a50", "ip4": "1.2.3.4", "ip6": "::1",` +
+		` "arr": [[1, 2], [], [3]], "an": ["x", null], "m": {"k": [1, 2]}, "tp": {"b": "x", "a": 7},` +
+		` "ta": [[1, "x"], [2, "y"]]}
+{"id": "1", "name": 5, "n": 3, "e": 1, "d": -0.5, "day": 19000, "ts": "2024-01-02 03:04:05", "ts64": "2024-01-02 03:04:05.5",` +
+		` "ip4": 16909060, "tp": [1, "y"]}
+`
+	dec, err := format.NewJSONEachRowDecoder(strings.NewReader(input), columns)
+	if err != nil {
+		t.Fatalf("NewJSONEachRowDecoder: %v", err)
+	}
+	n, err := dec.Decode(0)
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("Decode: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("got %d rows, want 2", n)
+	}
+
+	want := map[string]string{
+		"id":   `"18446744073709551615"|"1"`,
+		"i128": `"-170141183460469231731687303715884105728"|"0"`,
+		"f":    `1.5|0`,
+		"ok":   `true|false`,
+		"name": `"a\"b"|"5"`,
+		"lc":   `"x"|""`,
+		"n":    `null|3`,
+		"e":    `"b"|"a"`,
+		"d":    `"12.34"|"-.5"`,
+		"d256": `"1.5"|"0"`,
+		"day":  `"2024-02-29"|"2022-01-08"`,
+		"ts":   `"2024-01-02 02:04:05"|"2024-01-02 03:04:05"`,
+		"ts64": `"2023-11-14 22:13:20.123000000"|"2024-01-02 03:04:05.500000000"`,
+		"u":    `"417ddc5d-e556-4d27-95dd-a34d84e46a50"|"00000000-0000-0000-0000-000000000000"`,
+		"ip4":  `"1.2.3.4"|"1.2.3.4"`,
+		"ip6":  `"::1"|"::"`,
+		"arr":  `[[1,2],[],[3]]|[]`,
+		"an":   `["x",null]|[]`,
+		"m":    `{"k":[1,2]}|{}`,
+		"tp":   `{"a":7,"b":"x"}|{"a":1,"b":"y"}`,
+		"ta":   `[[1,"x"],[2,"y"]]|[]`,
+	}
+	for _, col := range columns {
+		w, ok := want[string(col.Name())]
+		if !ok {
+			continue
+		}
+		if got := columnJSON(col); got != w {
+			t.Errorf("column %s: got %s, want %s", col.Name(), got, w)
+		}
+	}
+	if code := columns[5].(column.Column[[3]byte]); code.Row(0) != [3]byte{'a', 'b'} || code.Row(1) != [3]byte{} {
+		t.Errorf("code: got %v, want [a b 0] and zero", code.Data())
+	}
+}
+
+func TestJSONCompactEachRowDecoder(t *testing.T) {
+	columns := jsonDecoderColumns(t, "id Int32", "ts DateTime", "tags Array(String)")
+	input := `[1, "2024-01-02 03:04:05", ["a"]] [2, 1704164645, []]`
+	dec, err := format.NewJSONCompactEachRowDecoder(strings.NewReader(input), columns,
+		format.WithJSONLocation(time.FixedZone("", 3600)))
+	if err != nil {
+		t.Fatalf("NewJSONCompactEachRowDecoder: %v", err)
+	}
+	n, err := dec.Decode(1)
+	if err != nil || n != 1 {
+		t.Fatalf("Decode: got %d, %v", n, err)
+	}
+	if err := dec.DecodeRow(); err != nil {
+		t.Fatalf("DecodeRow: %v", err)
+	}
+	if err := dec.DecodeRow(); !errors.Is(err, io.EOF) {
+		t.Fatalf("DecodeRow: got %v, want io.EOF", err)
+	}
+	ts := columns[1].(column.Column[time.Time])
+	if got := ts.Row(0).Unix(); got != 1704161045 {
+		t.Errorf("ts: got %d, want 1704161045", got)
+	}
+	if got := ts.Row(1).Unix(); got != 1704164645 {
+		t.Errorf("ts: got %d, want 1704164645", got)
+	}
+	if got := columnJSON(columns[2]); got != `["a"]|[]` {
+		t.Errorf("tags: got %s", got)
+	}
+}
+
+func TestJSONDecoderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		opts    []format.JSONOption
+		wantErr string
+	}{
+		{name: "unknown field", input: `{"x": 1}`, wantErr: `unknown field "x"`},
+		{name: "duplicate field", input: `{"id": 1, "id": 2}`, wantErr: `duplicate field "id"`},
+		{name: "overflow", input: `{"id": 300}`, wantErr: `column "id"`},
+		{name: "decimal overflow", input: `{"d": 100}`, wantErr: `overflows`},
+		{name: "not object", input: `[1]`, wantErr: `expected object`},
+		{name: "truncated", input: `{"id": 1`, wantErr: "unexpected end"},
+		{name: "bad enum", input: `{"e": "c"}`, wantErr: `unknown enum value`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := jsonDecoderColumns(t, "id UInt8", "d Decimal(4, 2)", "e Enum16('a' = 1000)")
+			dec, err := format.NewJSONEachRowDecoder(strings.NewReader(tt.input), columns, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewJSONEachRowDecoder: %v", err)
+			}
+			err = dec.DecodeRow()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	columns := jsonDecoderColumns(t, "id UInt8", "e Enum16('a' = 1000, 'b' = -5)")
+	dec, err := format.NewJSONEachRowDecoder(bytes.NewReader([]byte(`{"x": {"y": [1]}, "id": 1}`)), columns,
+		format.WithJSONSkipUnknownFields())
+	if err != nil {
+		t.Fatalf("NewJSONEachRowDecoder: %v", err)
+	}
+	if err := dec.DecodeRow(); err != nil {
+		t.Fatalf("DecodeRow: %v", err)
+	}
+	if got := columnJSON(columns[1]); got != `"b"` {
+		t.Errorf("enum default: got %s, want \"b\"", got)
+	}
+
+	if _, err := format.NewJSONEachRowDecoder(strings.NewReader(""), jsonDecoderColumns(t, "p Point")); err == nil {
+		t.Fatal("expected an error for an unsupported type")
+	}
+}
+
+func TestJSONDecoderSkipBadRows(t *testing.T) {
+	columns := jsonDecoderColumns(t,
+		"id UInt8",
+		"arr Array(Array(Int16))",
+		"m Map(String, UInt8)",
+		"tp Tuple(a Int32, b Nullable(String))",
+	)
+	input := `{"id": 1, "arr": [[1, 2]], "m": {"a": 1}, "tp": [1, "x"]}
+{"id": 2, "arr": [[3], [4, "z"]], "m": {}, "tp": [2, null]}
+{"id": 3, "m": {"b": 2, "c": 300}, "arr": [[5]]}
+{"tp": {"a": 4, "b": "y", "c": [1]}, "id": 4}
+{"id": 5, "arr": [[6]], "m": {"d": 4}, "tp": {"b": "z"}}
+`
+	dec, err := format.NewJSONEachRowDecoder(strings.NewReader(input), columns)
+	if err != nil {
+		t.Fatalf("NewJSONEachRowDecoder: %v", err)
+	}
+	var errs []string
+	for {
+		err := dec.DecodeRow()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	wantErrs := []string{"json: row 1:", "json: row 2:", "json: row 3:"}
+	if len(errs) != len(wantErrs) {
+		t.Fatalf("got errors %q, want %d errors", errs, len(wantErrs))
+	}
+	for i, want := range wantErrs {
+		if !strings.HasPrefix(errs[i], want) {
+			t.Errorf("error %d: got %q, want prefix %q", i, errs[i], want)
+		}
+	}
+
+	want := []string{`1|5`, `[[1,2]]|[[6]]`, `{"a":1}|{"d":4}`, `{"a":1,"b":"x"}|{"a":0,"b":"z"}`}
+	for i, col := range columns {
+		if got := columnJSON(col); got != want[i] {
+			t.Errorf("column %s: got %s, want %s", col.Name(), got, want[i])
+		}
+	}
+}
@@ -0,0 +1,615 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v3"
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+	"github.com/vahid-sohrabloo/chconn/v3/internal/helper"
+)
+
+// JSONFormat is a ClickHouse JSON output format written by JSONWriter.
+type JSONFormat int
+
+const (
+	// JSONFormatJSON writes an object with "meta", "data" (one object per row), "rows" and
+	// "statistics" sections, like the JSON format of the server.
+	JSONFormatJSON JSONFormat = iota
+	// JSONFormatCompact is JSONFormatJSON with each row as an array of values.
+	JSONFormatCompact
+	// JSONFormatEachRow writes one object per row, separated by newlines.
+	JSONFormatEachRow
+	// JSONFormatStrings is JSONFormatJSON with every value, including numbers, arrays and maps, as a string.
+	JSONFormatStrings
+)
+
+// JSONDateFormat is the output format of DateTime and DateTime64 values.
+type JSONDateFormat int
+
+const (
+	// JSONDateSimple writes "2006-01-02 15:04:05[.fraction]" strings, like the server.
+	JSONDateSimple JSONDateFormat = iota
+	// JSONDateRFC3339 writes RFC 3339 strings with the time zone offset.
+	JSONDateRFC3339
+	// JSONDateUnix writes seconds since the epoch as a number, with a fraction for DateTime64.
+	JSONDateUnix
+)
+
+// JSONNaN is the output of NaN and infinite floats, which JSON can't represent.
+type JSONNaN int
+
+const (
+	// JSONNaNNull writes null.
+	JSONNaNNull JSONNaN = iota
+	// JSONNaNString writes "nan", "inf" or "-inf".
+	JSONNaNString
+	// JSONNaNError returns an error.
+	JSONNaNError
+)
+
+// JSONWriterOption configures a JSONWriter.
+type JSONWriterOption func(*JSONWriter)
+
+// WithJSONQuote64BitInts sets whether 64 bit and larger integers are written as strings, so
+// JavaScript clients don't lose precision. The default is true, like the server.
+func WithJSONQuote64BitInts(quote bool) JSONWriterOption {
+	return func(j *JSONWriter) { j.quote64 = quote }
+}
+
+// WithJSONDateFormat sets the output format of DateTime and DateTime64 values. The default is JSONDateSimple.
+func WithJSONDateFormat(f JSONDateFormat) JSONWriterOption {
+	return func(j *JSONWriter) { j.dateFormat = f }
+}
+
+// WithJSONNaN sets the output of NaN and infinite floats. The default is JSONNaNNull.
+func WithJSONNaN(n JSONNaN) JSONWriterOption {
+	return func(j *JSONWriter) { j.nan = n }
+}
+
+// jsonWriterFlushSize is the size of buffered output that is written to the io.Writer inside a block.
+const jsonWriterFlushSize = 64 * 1024
+
+// JSONWriter writes query results to an io.Writer in a ClickHouse JSON output format.
+// Unlike JSON, it streams the output and doesn't keep it in memory, so it can write directly
+// to an http.ResponseWriter.
+type JSONWriter struct {
+	w      io.Writer
+	format JSONFormat
+	buf    []byte
+	start  time.Time
+
+	columns     []column.ColumnCore
+	fields      []jsonEncoder
+	rows        uint64
+	wroteHeader bool
+
+	quote64    bool
+	dateFormat JSONDateFormat
+	nan        JSONNaN
+}
+
+// NewJSONWriter returns a JSONWriter that writes to w in the given format.
+func NewJSONWriter(w io.Writer, format JSONFormat, opts ...JSONWriterOption) *JSONWriter {
+	j := &JSONWriter{
+		w:       w,
+		format:  format,
+		start:   time.Now(),
+		quote64: true,
+	}
+	for _, o := range opts {
+		o(j)
+	}
+	return j
+}
+
+// WriteStmt writes all blocks of the select statement and finishes the output with the statistics
+// of the query.
+func (j *JSONWriter) WriteStmt(stmt chconn.SelectStmt) error {
+	for stmt.Next() {
+		if err := j.WriteBlock(stmt.Columns()); err != nil {
+			stmt.Close()
+			return err
+		}
+	}
+	if err := stmt.Err(); err != nil {
+		return err
+	}
+	if !j.wroteHeader {
+		j.setColumns(stmt.Columns())
+	}
+	return j.Finish(stmt.Stats())
+}
+
+// WriteBlock writes the rows of a block. All blocks must have the same columns.
+func (j *JSONWriter) WriteBlock(columns []column.ColumnCore) error {
+	if !j.wroteHeader {
+		j.setColumns(columns)
+	} else if len(columns) != len(j.columns) {
+		return fmt.Errorf("json: got %d columns, expected %d", len(columns), len(j.columns))
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	for i, col := range columns {
+		if col != j.columns[i] {
+			enc, err := j.newEncoder(col.Type(), col)
+			if err != nil {
+				return fmt.Errorf("json: column %q: %w", string(col.Name()), err)
+			}
+			j.columns[i], j.fields[i] = col, enc
+		}
+	}
+
+	numRows := columns[0].NumRow()
+	for row := 0; row < numRows; row++ {
+		if err := j.writeRow(row); err != nil {
+			return err
+		}
+		j.rows++
+		if len(j.buf) >= jsonWriterFlushSize {
+			if err := j.flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return j.flush()
+}
+
+// Finish writes the end of the output. stats are written in the "statistics" section of the
+// JSON, JSONCompact and JSONStrings formats; if they are nil, only the elapsed time is written.
+func (j *JSONWriter) Finish(stats *chconn.QueryStats) error {
+	if j.format == JSONFormatEachRow {
+		return j.flush()
+	}
+	if !j.wroteHeader {
+		j.setColumns(nil)
+	}
+	if j.rows > 0 {
+		j.buf = append(j.buf, '\n')
+	}
+	j.buf = append(j.buf, "\t],\n\n\t\"rows\": "...)
+	j.buf = strconv.AppendUint(j.buf, j.rows, 10)
+
+	elapsed := time.Since(j.start)
+	if stats != nil {
+		if stats.RowsBeforeLimit > 0 {
+			j.buf = append(j.buf, ",\n\n\t\"rows_before_limit_at_least\": "...)
+			j.buf = strconv.AppendUint(j.buf, stats.RowsBeforeLimit, 10)
+		}
+		if stats.Elapsed > 0 {
+			elapsed = stats.Elapsed
+		}
+	}
+	j.buf = append(j.buf, ",\n\n\t\"statistics\":\n\t{\n\t\t\"elapsed\": "...)
+	j.buf = strconv.AppendFloat(j.buf, elapsed.Seconds(), 'f', -1, 64)
+	if stats != nil {
+		j.buf = append(j.buf, ",\n\t\t\"rows_read\": "...)
+		j.buf = strconv.AppendUint(j.buf, stats.ReadRows, 10)
+		j.buf = append(j.buf, ",\n\t\t\"bytes_read\": "...)
+		j.buf = strconv.AppendUint(j.buf, stats.ReadBytes, 10)
+	}
+	j.buf = append(j.buf, "\n\t}\n}\n"...)
+	return j.flush()
+}
+
+// setColumns sets the columns of the output and writes the header.
+func (j *JSONWriter) setColumns(columns []column.ColumnCore) {
+	j.columns = make([]column.ColumnCore, len(columns))
+	j.fields = make([]jsonEncoder, len(columns))
+	j.wroteHeader = true
+	if j.format == JSONFormatEachRow {
+		return
+	}
+	j.buf = append(j.buf, "{\n\t\"meta\":\n\t["...)
+	for i, col := range columns {
+		if i > 0 {
+			j.buf = append(j.buf, ',')
+		}
+		j.buf = append(j.buf, "\n\t\t{\n\t\t\t\"name\": "...)
+		j.buf = helper.AppendJSONSting(j.buf, false, col.Name())
+		j.buf = append(j.buf, ",\n\t\t\t\"type\": "...)
+		j.buf = helper.AppendJSONSting(j.buf, false, col.Type())
+		j.buf = append(j.buf, "\n\t\t}"...)
+	}
+	j.buf = append(j.buf, "\n\t],\n\n\t\"data\":\n\t[\n"...)
+}
+
+func (j *JSONWriter) writeRow(row int) error {
+	var err error
+	object := j.format != JSONFormatCompact
+	switch {
+	case j.format == JSONFormatEachRow:
+	case j.rows > 0:
+		j.buf = append(j.buf, ",\n\t\t"...)
+	default:
+		j.buf = append(j.buf, "\t\t"...)
+	}
+	if object {
+		j.buf = append(j.buf, '{')
+	} else {
+		j.buf = append(j.buf, '[')
+	}
+	for i, col := range j.columns {
+		if i > 0 {
+			j.buf = append(j.buf, ',')
+		}
+		if object {
+			j.buf = helper.AppendJSONSting(j.buf, false, col.Name())
+			j.buf = append(j.buf, ':')
+		}
+		if j.format == JSONFormatStrings {
+			j.buf, err = j.fields[i].encodeString(j, row, j.buf)
+		} else {
+			j.buf, err = j.fields[i].encode(j, row, j.buf)
+		}
+		if err != nil {
+			return fmt.Errorf("json: column %q: %w", string(col.Name()), err)
+		}
+	}
+	if object {
+		j.buf = append(j.buf, '}')
+	} else {
+		j.buf = append(j.buf, ']')
+	}
+	if j.format == JSONFormatEachRow {
+		j.buf = append(j.buf, '\n')
+	}
+	return nil
+}
+
+func (j *JSONWriter) flush() error {
+	if len(j.buf) == 0 {
+		return nil
+	}
+	_, err := j.w.Write(j.buf)
+	j.buf = j.buf[:0]
+	if err != nil {
+		return fmt.Errorf("json: write: %w", err)
+	}
+	return nil
+}
+
+// jsonEncoder encodes the rows of a column.
+type jsonEncoder interface {
+	encode(j *JSONWriter, row int, b []byte) ([]byte, error)
+	// encodeString encodes the row as a JSON string, for the JSONStrings format.
+	encodeString(j *JSONWriter, row int, b []byte) ([]byte, error)
+}
+
+type jsonOffsetsColumn interface {
+	Offsets() []uint64
+}
+
+type jsonNilColumn interface {
+	RowIsNil(row int) bool
+}
+
+// jsonRowRange returns the range of the nested rows of row.
+func jsonRowRange(col jsonOffsetsColumn, row int) (start, end int) {
+	offsets := col.Offsets()
+	if row > 0 {
+		start = int(offsets[row-1])
+	}
+	return start, int(offsets[row])
+}
+
+func (j *JSONWriter) newEncoder(chType []byte, col column.ColumnCore) (jsonEncoder, error) {
+	chType = helper.NestedToArrayType(helper.FilterSimpleAggregate(chType))
+	switch {
+	case helper.IsArray(chType):
+		arr, ok := col.(interface {
+			jsonOffsetsColumn
+			Column() column.ColumnCore
+		})
+		if !ok {
+			return nil, fmt.Errorf("unexpected column %T for %s", col, chType)
+		}
+		elem, err := j.newEncoder(chType[helper.LenArrayStr:len(chType)-1], arr.Column())
+		if err != nil {
+			return nil, err
+		}
+		return &jsonArrayEncoder{col: arr, elem: elem}, nil
+	case helper.IsMap(chType):
+		m, ok := col.(interface {
+			jsonOffsetsColumn
+			KeyColumn() column.ColumnCore
+			ValueColumn() column.ColumnCore
+		})
+		if !ok {
+			return nil, fmt.Errorf("unexpected column %T for %s", col, chType)
+		}
+		kv, err := helper.TypesInParentheses(chType[helper.LenMapStr : len(chType)-1])
+		if err != nil {
+			return nil, err
+		}
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid map type %s", chType)
+		}
+		value, err := j.newEncoder(kv[1].ChType, m.ValueColumn())
+		if err != nil {
+			return nil, err
+		}
+		return &jsonMapEncoder{col: m, key: m.KeyColumn(), value: value}, nil
+	case helper.IsTuple(chType):
+		t, ok := col.(interface{ Columns() []column.ColumnCore })
+		if !ok {
+			return nil, fmt.Errorf("unexpected column %T for %s", col, chType)
+		}
+		elems, err := helper.TypesInParentheses(chType[helper.LenTupleStr : len(chType)-1])
+		if err != nil {
+			return nil, err
+		}
+		columns := t.Columns()
+		if len(elems) != len(columns) {
+			return nil, fmt.Errorf("tuple %s has %d columns", chType, len(columns))
+		}
+		tuple := &jsonTupleEncoder{
+			names:  make([][]byte, len(elems)),
+			fields: make([]jsonEncoder, len(elems)),
+			named:  true,
+		}
+		for i, elem := range elems {
+			tuple.fields[i], err = j.newEncoder(elem.ChType, columns[i])
+			if err != nil {
+				return nil, err
+			}
+			tuple.names[i] = elem.Name
+			tuple.named = tuple.named && len(elem.Name) > 0
+		}
+		return tuple, nil
+	}
+	return newJSONLeafEncoder(chType, col), nil
+}
+
+type jsonArrayEncoder struct {
+	col  jsonOffsetsColumn
+	elem jsonEncoder
+}
+
+func (a *jsonArrayEncoder) encode(j *JSONWriter, row int, b []byte) ([]byte, error) {
+	start, end := jsonRowRange(a.col, row)
+	b = append(b, '[')
+	var err error
+	for i := start; i < end; i++ {
+		if i > start {
+			b = append(b, ',')
+		}
+		if b, err = a.elem.encode(j, i, b); err != nil {
+			return b, err
+		}
+	}
+	return append(b, ']'), nil
+}
+
+func (a *jsonArrayEncoder) encodeString(j *JSONWriter, row int, b []byte) ([]byte, error) {
+	return jsonEncodeAsString(a, j, row, b)
+}
+
+type jsonMapEncoder struct {
+	col   jsonOffsetsColumn
+	key   column.ColumnCore
+	value jsonEncoder
+}
+
+func (m *jsonMapEncoder) encode(j *JSONWriter, row int, b []byte) ([]byte, error) {
+	start, end := jsonRowRange(m.col, row)
+	b = append(b, '{')
+	var err error
+	for i := start; i < end; i++ {
+		if i > start {
+			b = append(b, ',')
+		}
+		// object keys are always strings
+		b = append(b, '"')
+		b = m.key.ToJSON(i, true, b)
+		b = append(b, '"', ':')
+		if b, err = m.value.encode(j, i, b); err != nil {
+			return b, err
+		}
+	}
+	return append(b, '}'), nil
+}
+
+func (m *jsonMapEncoder) encodeString(j *JSONWriter, row int, b []byte) ([]byte, error) {
+	return jsonEncodeAsString(m, j, row, b)
+}
+
+type jsonTupleEncoder struct {
+	names  [][]byte
+	fields []jsonEncoder
+	named  bool
+}
+
+func (t *jsonTupleEncoder) encode(j *JSONWriter, row int, b []byte) ([]byte, error) {
+	if t.named {
+		b = append(b, '{')
+	} else {
+		b = append(b, '[')
+	}
+	var err error
+	for i, field := range t.fields {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		if t.named {
+			b = helper.AppendJSONSting(b, false, t.names[i])
+			b = append(b, ':')
+		}
+		if b, err = field.encode(j, row, b); err != nil {
+			return b, err
+		}
+	}
+	if t.named {
+		return append(b, '}'), nil
+	}
+	return append(b, ']'), nil
+}
+
+func (t *jsonTupleEncoder) encodeString(j *JSONWriter, row int, b []byte) ([]byte, error) {
+	return jsonEncodeAsString(t, j, row, b)
+}
+
+// jsonEncodeAsString encodes the row as JSON and writes it as a JSON string.
+func jsonEncodeAsString(enc jsonEncoder, j *JSONWriter, row int, b []byte) ([]byte, error) {
+	v, err := enc.encode(j, row, nil)
+	if err != nil {
+		return b, err
+	}
+	return helper.AppendJSONSting(b, false, v), nil
+}
+
+type jsonLeafKind int
+
+const (
+	jsonLeafOther jsonLeafKind = iota
+	jsonLeafInt64
+	jsonLeafFloat
+	jsonLeafDateTime
+	jsonLeafDateTime64
+)
+
+type jsonLeafEncoder struct {
+	col       column.ColumnCore
+	null      jsonNilColumn
+	kind      jsonLeafKind
+	precision int
+}
+
+func newJSONLeafEncoder(chType []byte, col column.ColumnCore) *jsonLeafEncoder {
+	if helper.IsLowCardinality(chType) {
+		chType = chType[helper.LenLowCardinalityStr : len(chType)-1]
+	}
+	leaf := &jsonLeafEncoder{col: col}
+	if helper.IsNullable(chType) {
+		chType = chType[helper.LenNullableStr : len(chType)-1]
+		leaf.null, _ = col.(jsonNilColumn)
+	}
+	switch {
+	case string(chType) == "Int64", string(chType) == "UInt64",
+		string(chType) == "Int128", string(chType) == "UInt128",
+		string(chType) == "Int256", string(chType) == "UInt256":
+		leaf.kind = jsonLeafInt64
+	case string(chType) == "Float32", string(chType) == "Float64":
+		leaf.kind = jsonLeafFloat
+	case string(chType) == "DateTime", helper.IsDateTimeWithParam(chType):
+		leaf.kind = jsonLeafDateTime
+	case helper.IsDateTime64(chType):
+		leaf.kind = jsonLeafDateTime64
+		params := chType[helper.DateTime64StrLen : len(chType)-1]
+		for i, c := range params {
+			if c < '0' || c > '9' {
+				params = params[:i]
+				break
+			}
+		}
+		leaf.precision, _ = strconv.Atoi(string(params))
+	}
+	return leaf
+}
+
+func (l *jsonLeafEncoder) isNil(row int) bool {
+	return l.null != nil && l.null.RowIsNil(row)
+}
+
+func (l *jsonLeafEncoder) encode(j *JSONWriter, row int, b []byte) ([]byte, error) {
+	if l.isNil(row) {
+		return append(b, "null"...), nil
+	}
+	switch l.kind {
+	case jsonLeafInt64:
+		return l.col.ToJSON(row, !j.quote64, b), nil
+	case jsonLeafFloat:
+		return l.encodeFloat(j, row, b)
+	case jsonLeafDateTime, jsonLeafDateTime64:
+		// DateTime64 is formatted here even for JSONDateSimple, to write the
+		// fraction with the precision of the type
+		if j.dateFormat != JSONDateSimple || l.kind == jsonLeafDateTime64 {
+			return l.encodeTime(j, row, b), nil
+		}
+	}
+	return l.col.ToJSON(row, false, b), nil
+}
+
+func (l *jsonLeafEncoder) encodeString(j *JSONWriter, row int, b []byte) ([]byte, error) {
+	if l.isNil(row) {
+		return append(b, "null"...), nil
+	}
+	v, err := l.encode(j, row, nil)
+	if err != nil {
+		return b, err
+	}
+	if len(v) > 0 && v[0] == '"' {
+		return append(b, v...), nil
+	}
+	return helper.AppendJSONSting(b, false, v), nil
+}
+
+func (l *jsonLeafEncoder) encodeFloat(j *JSONWriter, row int, b []byte) ([]byte, error) {
+	var f float64
+	switch col := l.col.(type) {
+	case column.RowReader[float32]:
+		f = float64(col.Row(row))
+	case column.RowReader[float64]:
+		f = col.Row(row)
+	default:
+		return l.col.ToJSON(row, false, b), nil
+	}
+	if !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return l.col.ToJSON(row, false, b), nil
+	}
+	switch j.nan {
+	case JSONNaNString:
+		switch {
+		case math.IsNaN(f):
+			return append(b, `"nan"`...), nil
+		case f > 0:
+			return append(b, `"inf"`...), nil
+		default:
+			return append(b, `"-inf"`...), nil
+		}
+	case JSONNaNError:
+		return b, fmt.Errorf("cannot write %v as JSON", f)
+	}
+	return append(b, "null"...), nil
+}
+
+func (l *jsonLeafEncoder) encodeTime(j *JSONWriter, row int, b []byte) []byte {
+	col, ok := l.col.(column.RowReader[time.Time])
+	if !ok {
+		return l.col.ToJSON(row, false, b)
+	}
+	t := col.Row(row)
+	if j.dateFormat == JSONDateUnix {
+		if l.kind != jsonLeafDateTime64 || l.precision == 0 {
+			return strconv.AppendInt(b, t.Unix(), 10)
+		}
+		sec, nsec := t.Unix(), int64(t.Nanosecond())
+		if sec < 0 && nsec > 0 {
+			// Unix rounds down, but the fraction of a negative time is written after the sign
+			sec, nsec = sec+1, 1e9-nsec
+			if sec == 0 {
+				b = append(b, '-')
+			}
+		}
+		b = strconv.AppendInt(b, sec, 10)
+		frac := strconv.AppendInt(nil, nsec+1e9, 10)
+		b = append(b, '.')
+		return append(b, frac[1:1+min(l.precision, 9)]...)
+	}
+	var frac string
+	if l.kind == jsonLeafDateTime64 && l.precision > 0 {
+		frac = ".000000000"[:1+min(l.precision, 9)]
+	}
+	b = append(b, '"')
+	if j.dateFormat == JSONDateSimple {
+		b = t.AppendFormat(b, time.DateTime+frac)
+	} else {
+		b = t.AppendFormat(b, "2006-01-02T15:04:05"+frac+"Z07:00")
+	}
+	return append(b, '"')
+}
@@ -0,0 +1,162 @@
+package format_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v3"
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+	"github.com/vahid-sohrabloo/chconn/v3/format"
+)
+
+func jsonWriterColumns(t *testing.T) []column.ColumnCore {
+	t.Helper()
+	columns := jsonDecoderColumns(t,
+		"id UInt64",
+		"name Nullable(String)",
+		"f Float64",
+		"ts DateTime64(3, 'UTC')",
+		"tags Array(String)",
+		"m Map(UInt8, Tuple(a Int64, b Array(Float32)))",
+	)
+	input := `{"id": 1, "name": "a", "f": 1.5, "ts": "2024-01-02 03:04:05.25", "tags": ["x", "y"], "m": {"1": [2, [0.5]]}}
+{"id": 18446744073709551615, "name": null, "f": "nan", "ts": -1.5, "tags": [], "m": {}}
+`
+	dec, err := format.NewJSONEachRowDecoder(strings.NewReader(input), columns)
+	if err != nil {
+		t.Fatalf("NewJSONEachRowDecoder: %v", err)
+	}
+	if n, _ := dec.Decode(0); n != 2 {
+		t.Fatalf("decoded %d rows, want 2", n)
+	}
+	return columns
+}
+
+func TestJSONWriterEachRow(t *testing.T) {
+	var buf bytes.Buffer
+	w := format.NewJSONWriter(&buf, format.JSONFormatEachRow,
+		format.WithJSONQuote64BitInts(false),
+		format.WithJSONDateFormat(format.JSONDateUnix),
+		format.WithJSONNaN(format.JSONNaNString))
+	if err := w.WriteBlock(jsonWriterColumns(t)); err != nil {
+		t.Fatalf("WriteBlock: %v", err)
+	}
+	if err := w.Finish(nil); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	want := `{"id":1,"name":"a","f":1.5,"ts":1704164645.250,"tags":["x","y"],"m":{"1":{"a":2,"b":[0.5]}}}
+{"id":18446744073709551615,"name":null,"f":"nan","ts":-1.500,"tags":[],"m":{}}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestJSONWriterJSON(t *testing.T) {
+	var buf bytes.Buffer
+	w := format.NewJSONWriter(&buf, format.JSONFormatJSON, format.WithJSONDateFormat(format.JSONDateRFC3339))
+	columns := jsonWriterColumns(t)
+	if err := w.WriteBlock(columns); err != nil {
+		t.Fatalf("WriteBlock: %v", err)
+	}
+	if err := w.WriteBlock(columns); err != nil {
+		t.Fatalf("WriteBlock: %v", err)
+	}
+	if err := w.Finish(&chconn.QueryStats{ReadRows: 10, ReadBytes: 80, Elapsed: time.Millisecond, RowsBeforeLimit: 7}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	var out struct {
+		Meta []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"meta"`
+		Data                   []map[string]any `json:"data"`
+		Rows                   int              `json:"rows"`
+		RowsBeforeLimitAtLeast int              `json:"rows_before_limit_at_least"`
+		Statistics             map[string]float64
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(out.Meta) != len(columns) || out.Meta[5].Name != "m" || out.Meta[5].Type != "Map(UInt8, Tuple(a Int64, b Array(Float32)))" {
+		t.Errorf("meta: %+v", out.Meta)
+	}
+	if out.Rows != 4 || len(out.Data) != 4 || out.RowsBeforeLimitAtLeast != 7 {
+		t.Errorf("rows: %d, data: %d, rows before limit: %d", out.Rows, len(out.Data), out.RowsBeforeLimitAtLeast)
+	}
+	if out.Statistics["elapsed"] != 0.001 || out.Statistics["rows_read"] != 10 || out.Statistics["bytes_read"] != 80 {
+		t.Errorf("statistics: %v", out.Statistics)
+	}
+	if got := out.Data[0]["id"]; got != "1" {
+		t.Errorf("id: got %v, want \"1\"", got)
+	}
+	if got := out.Data[0]["ts"]; got != "2024-01-02T03:04:05.250Z" {
+		t.Errorf("ts: got %v", got)
+	}
+	if got := out.Data[1]["f"]; got != nil {
+		t.Errorf("f: got %v, want nil", got)
+	}
+}
+
+func TestJSONWriterCompactAndStrings(t *testing.T) {
+	var buf bytes.Buffer
+	w := format.NewJSONWriter(&buf, format.JSONFormatCompact)
+	if err := w.WriteBlock(jsonWriterColumns(t)); err != nil {
+		t.Fatalf("WriteBlock: %v", err)
+	}
+	if err := w.Finish(nil); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	var compact struct {
+		Data [][]any `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &compact); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(compact.Data) != 2 || compact.Data[0][1] != "a" || compact.Data[1][0] != "18446744073709551615" {
+		t.Errorf("data: %v", compact.Data)
+	}
+
+	buf.Reset()
+	w = format.NewJSONWriter(&buf, format.JSONFormatStrings)
+	if err := w.WriteBlock(jsonWriterColumns(t)); err != nil {
+		t.Fatalf("WriteBlock: %v", err)
+	}
+	if err := w.Finish(nil); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	var strs struct {
+		Data []map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &strs); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	want := map[string]any{
+		"id": "1", "name": "a", "f": "1.5", "ts": "2024-01-02 03:04:05.250",
+		"tags": `["x","y"]`, "m": `{"1":{"a":"2","b":[0.5]}}`,
+	}
+	for k, v := range want {
+		if strs.Data[0][k] != v {
+			t.Errorf("%s: got %v, want %v", k, strs.Data[0][k], v)
+		}
+	}
+	if strs.Data[1]["name"] != nil {
+		t.Errorf("name: got %v, want nil", strs.Data[1]["name"])
+	}
+}
+
+func TestJSONWriterNaNError(t *testing.T) {
+	col := column.New[float32]()
+	col.SetName([]byte("f"))
+	col.SetType([]byte("Float32"))
+	col.Append(float32(math.Inf(-1)))
+	w := format.NewJSONWriter(&bytes.Buffer{}, format.JSONFormatEachRow, format.WithJSONNaN(format.JSONNaNError))
+	if err := w.WriteBlock([]column.ColumnCore{col}); err == nil {
+		t.Fatal("expected an error for -Inf")
+	}
+}
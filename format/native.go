@@ -255,6 +255,108 @@ func (n *NativeReader) ReadBlockInto(r io.Reader, serverInfo *shared.ServerInfo,
 	return nil
 }
 
+// ReadBlockAs reads one block from r and projects it onto columns, which must
+// have their name and type set. Stored columns are matched by name: identical
+// types are read directly, compatible types are widened (Int32 to Int64,
+// Float32 to Float64, String to LowCardinality(String), T to Nullable(T), ...),
+// columns missing from the block are filled with defaults and stored columns
+// without a target are dropped. It returns the block's row count.
+// Returns io.EOF when no more data is available.
+func (n *NativeReader) ReadBlockAs(r io.Reader, serverInfo *shared.ServerInfo, columns ...column.ColumnCore) (int, error) {
+	if serverInfo == nil {
+		serverInfo = shared.EmptyServerInfo()
+	}
+
+	byName := make(map[string]column.ColumnCore, len(columns))
+	for _, col := range columns {
+		if len(col.Type()) == 0 {
+			return 0, fmt.Errorf("native: column %q has no type; call SetType before reading", col.Name())
+		}
+		if _, dup := byName[string(col.Name())]; dup {
+			return 0, fmt.Errorf("native: duplicate column name %q", col.Name())
+		}
+		byName[string(col.Name())] = col
+	}
+
+	reader := readerwriter.NewReader(r)
+
+	numColumns, err := reader.Uvarint()
+	if err != nil {
+		return 0, fmt.Errorf("native: read num columns: %w", err)
+	}
+
+	if numColumns > maxColumns {
+		return 0, fmt.Errorf("native: implausible column count %d", numColumns)
+	}
+
+	numRows, err := reader.Uvarint()
+	if err != nil {
+		return 0, fmt.Errorf("native: read num rows: %w", err)
+	}
+
+	filled := make(map[string]bool, len(columns))
+	for i := range numColumns {
+		name, err := reader.ByteString()
+		if err != nil {
+			return 0, fmt.Errorf("native: read column %d name: %w", i, err)
+		}
+
+		chType, err := reader.ByteString()
+		if err != nil {
+			return 0, fmt.Errorf("native: read column %d type: %w", i, err)
+		}
+
+		dst := byName[string(name)]
+		col := dst
+		if dst == nil || string(dst.Type()) != string(chType) {
+			col, err = column.ColumnByType(chType, 0, false, false, serverInfo.Timezone)
+			if err != nil {
+				return 0, fmt.Errorf("native: create column %q (type %s): %w", string(name), string(chType), err)
+			}
+		}
+
+		if err := col.SetColumnHeader(column.ColumnHeader{
+			Name:   name,
+			ChType: chType,
+		}); err != nil {
+			return 0, fmt.Errorf("native: set column header %q: %w", string(name), err)
+		}
+
+		if err := col.ReadHeader(reader, serverInfo); err != nil {
+			return 0, fmt.Errorf("native: read header for column %q: %w", string(name), err)
+		}
+
+		if numRows > 0 {
+			if err := col.ReadRaw(int(numRows)); err != nil {
+				return 0, fmt.Errorf("native: read data for column %q: %w", string(name), err)
+			}
+		} else {
+			col.Reset()
+		}
+
+		if dst == nil {
+			continue
+		}
+		if col != dst {
+			if err := convertColumn(dst, col); err != nil {
+				return 0, fmt.Errorf("native: column %q: %w", string(name), err)
+			}
+		}
+		filled[string(name)] = true
+	}
+
+	for _, dst := range columns {
+		if filled[string(dst.Name())] {
+			continue
+		}
+		if err := fillDefault(dst, int(numRows)); err != nil {
+			return 0, fmt.Errorf("native: %w", err)
+		}
+	}
+
+	return int(numRows), nil
+}
+
 // Read streams all blocks from a SelectStmt to w in Native binary format.
 // This is the export path: SELECT → Native binary file.
 func (n *NativeReader) Read(stmt chconn.SelectStmt, w io.Writer) error {
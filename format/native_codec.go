@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/go-faster/city"
 	kpzstd "github.com/klauspost/compress/zstd"
 	lz4 "github.com/pierrec/lz4/v4"
 )
@@ -26,8 +27,10 @@ type Codec struct {
 }
 
 // shared klauspost encoder/decoder; EncodeAll/DecodeAll are concurrency-safe.
+// The decoder keeps GOMAXPROCS block decoders so parallel reads decompress
+// concurrently instead of queueing on one.
 var kpEnc, _ = kpzstd.NewWriter(nil, kpzstd.WithEncoderConcurrency(1))
-var kpDec, _ = kpzstd.NewReader(nil, kpzstd.WithDecoderConcurrency(1))
+var kpDec, _ = kpzstd.NewReader(nil, kpzstd.WithDecoderConcurrency(0))
 
 func zstdCodec() *Codec {
 	return &Codec{
@@ -83,6 +86,12 @@ func writeCompressedHeader(w io.Writer, name string) error {
 	return err
 }
 
+// compressedHeaderSize returns the length of the header writeCompressedHeader
+// writes for the codec name, i.e. the offset of the first compressed unit.
+func compressedHeaderSize(name string) int64 {
+	return int64(len(compressedMagic) + len(binary.AppendUvarint(nil, uint64(len(name)))) + len(name))
+}
+
 // readCompressedHeader verifies the magic bytes and that the stored codec name
 // matches wantName.
 func readCompressedHeader(r io.Reader, wantName string) error {
@@ -112,41 +121,64 @@ func readCompressedHeader(r io.Reader, wantName string) error {
 
 // writeCompressedUnit compresses plaintext and writes a unit:
 // uvarint(compLen), uvarint(uncompLen), then compLen compressed bytes.
-func writeCompressedUnit(w io.Writer, codec *Codec, plaintext []byte) error {
+// It returns the unit's size and the CityHash128 of its compressed bytes.
+func writeCompressedUnit(w io.Writer, codec *Codec, plaintext []byte) (int64, city.U128, error) {
 	comp, err := codec.Compress(nil, plaintext)
 	if err != nil {
-		return fmt.Errorf("native: compress: %w", err)
+		return 0, city.U128{}, fmt.Errorf("native: compress: %w", err)
 	}
 	hdr := binary.AppendUvarint(nil, uint64(len(comp)))
 	hdr = binary.AppendUvarint(hdr, uint64(len(plaintext)))
 	if _, err := w.Write(hdr); err != nil {
-		return err
+		return 0, city.U128{}, err
 	}
 	if _, err := w.Write(comp); err != nil {
-		return err
+		return 0, city.U128{}, err
 	}
-	return nil
+	return int64(len(hdr) + len(comp)), city.CH128(comp), nil
 }
 
 // readCompressedUnit reads one compressed unit and returns its decompressed
 // plaintext. The error from reading the first length is returned raw so io.EOF
-// propagates to signal end-of-stream.
+// propagates to signal end-of-stream; the end-of-units marker written before a
+// block index (see WithBlockIndex) also returns io.EOF.
 func readCompressedUnit(r io.Reader, codec *Codec) ([]byte, error) {
-	compLen, err := readUvarint(r)
+	comp, uncompLen, err := readRawUnit(r)
 	if err != nil {
 		return nil, err
 	}
+	return decompressUnit(codec, comp, uncompLen)
+}
+
+// readRawUnit reads one compressed unit without decompressing it, returning
+// the compressed bytes and the recorded uncompressed length. It reports the
+// end of stream and the end-of-units marker as io.EOF, like readCompressedUnit.
+func readRawUnit(r io.Reader) ([]byte, uint64, error) {
+	compLen, err := readUvarint(r)
+	if err != nil {
+		return nil, 0, err
+	}
 	uncompLen, err := readUvarint(r)
 	if err != nil {
-		return nil, fmt.Errorf("native: read uncompressed length: %w", err)
+		return nil, 0, fmt.Errorf("native: read uncompressed length: %w", err)
+	}
+	if compLen == 0 && uncompLen == 0 {
+		// End-of-units marker: a trailing block index follows.
+		return nil, 0, io.EOF
 	}
 	if compLen > maxCompressedUnit || uncompLen > maxCompressedUnit {
-		return nil, fmt.Errorf("native: compressed unit length out of range (comp=%d uncomp=%d)", compLen, uncompLen)
+		return nil, 0, fmt.Errorf("native: compressed unit length out of range (comp=%d uncomp=%d)", compLen, uncompLen)
 	}
 	comp := make([]byte, compLen)
 	if _, err := io.ReadFull(r, comp); err != nil {
-		return nil, fmt.Errorf("native: read compressed unit: %w", err)
+		return nil, 0, fmt.Errorf("native: read compressed unit: %w", err)
 	}
+	return comp, uncompLen, nil
+}
+
+// decompressUnit decompresses the compressed bytes of a unit and checks the
+// result against the recorded uncompressed length.
+func decompressUnit(codec *Codec, comp []byte, uncompLen uint64) ([]byte, error) {
 	plain, err := codec.Decompress(make([]byte, 0, uncompLen), comp)
 	if err != nil {
 		return nil, fmt.Errorf("native: decompress: %w", err)
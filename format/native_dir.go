@@ -1,11 +1,14 @@
 package format
 
 import (
+	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 
@@ -18,25 +21,51 @@ type ColumnMeta struct {
 	Name  string
 	Type  string
 	file  string
+	// size is the committed length of the column file: bytes past it belong to
+	// no block, e.g. left by an interrupted AppendDir. -1 when the metadata
+	// predates it (CNDM2 and older).
+	size int64
 }
 
 const dirFileSuffix = ".native"
 const dirMetaFile = "metadata.bin"
-const dirMagic = "CNDM1"
+const dirMagic = "CNDM3"
+
+// dirMagicV2 marks metadata written before column file sizes were recorded. It
+// is still accepted by readDirMeta, with every size unknown.
+const dirMagicV2 = "CNDM2"
+
+// dirMagicV1 marks metadata written before block statistics were recorded. It
+// is still accepted by readDirMeta and read as a single block without stats.
+const dirMagicV1 = "CNDM1"
+
+// maxDirBlocks bounds the block count read from a metadata sidecar.
+const maxDirBlocks = 1 << 24
 
 // writeDirMeta writes an uncompressed sidecar file <dir>/metadata.bin with
-// the dataset schema and file mapping. Format:
+// the dataset schema, file mapping and block index. Format:
 //
-//	magic    5 bytes "CNDM1"
+//	magic    5 bytes "CNDM3"
 //	uvarint  rowCount
 //	uvarint  numColumns
 //	repeated numColumns:
 //	  uvarint+bytes  name
 //	  uvarint+bytes  chType
 //	  uvarint+bytes  fileName
+//	  uvarint        committed size of the column file
+//	uvarint  numBlocks
+//	repeated numBlocks:
+//	  uvarint  rows
+//	  repeated numColumns:
+//	    uvarint  byte offset of the block in the column file
+//	    stats    see appendColumnStats
 //
 // The write is atomic: a temp file is renamed into place on success.
-func writeDirMeta(dir string, metas []ColumnMeta, rowCount int) error {
+func writeDirMeta(dir string, metas []ColumnMeta, blocks []BlockMeta) error {
+	rowCount := 0
+	for _, b := range blocks {
+		rowCount += b.Rows
+	}
 	var buf []byte
 	buf = append(buf, dirMagic...)
 	buf = binary.AppendUvarint(buf, uint64(rowCount))
@@ -48,6 +77,15 @@ func writeDirMeta(dir string, metas []ColumnMeta, rowCount int) error {
 		buf = append(buf, m.Type...)
 		buf = binary.AppendUvarint(buf, uint64(len(m.file)))
 		buf = append(buf, m.file...)
+		buf = binary.AppendUvarint(buf, uint64(m.size))
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(blocks)))
+	for _, b := range blocks {
+		buf = binary.AppendUvarint(buf, uint64(b.Rows))
+		for i := range metas {
+			buf = binary.AppendUvarint(buf, uint64(b.offsets[i]))
+			buf = appendColumnStats(buf, b.Stats[i])
+		}
 	}
 
 	dst := filepath.Join(dir, dirMetaFile)
@@ -69,56 +107,108 @@ func writeDirMeta(dir string, metas []ColumnMeta, rowCount int) error {
 }
 
 // readDirMeta reads the uncompressed sidecar file <dir>/metadata.bin.
-// Returns rowCount, ordered column metas, or an error if the file is missing
-// or corrupt. Bounds: numColumns <= 1<<20, each string field <= 4096 bytes.
-func readDirMeta(dir string) (rowCount int, metas []ColumnMeta, err error) {
-	f, err := os.Open(filepath.Join(dir, dirMetaFile))
+// Returns rowCount, ordered column metas and the block index, or an error if
+// the file is missing or corrupt. Bounds: numColumns <= 1<<20, each string
+// field <= 4096 bytes, numBlocks <= 1<<24. Metadata without a block index
+// (CNDM1) is read as one block covering every row, with no stats; metadata
+// without column file sizes (CNDM1 and CNDM2) leaves them unknown.
+func readDirMeta(dir string) (rowCount int, metas []ColumnMeta, blocks []BlockMeta, err error) {
+	data, err := os.ReadFile(filepath.Join(dir, dirMetaFile))
 	if err != nil {
-		return 0, nil, err
+		return 0, nil, nil, err
 	}
-	defer f.Close()
+	f := bytes.NewReader(data)
 
 	var magic [5]byte
 	if _, err := io.ReadFull(f, magic[:]); err != nil {
-		return 0, nil, fmt.Errorf("native: read dir metadata magic: %w", err)
+		return 0, nil, nil, fmt.Errorf("native: read dir metadata magic: %w", err)
 	}
-	if string(magic[:]) != dirMagic {
-		return 0, nil, fmt.Errorf("native: not a chconn dir metadata file (bad magic %v)", magic)
+	if string(magic[:]) != dirMagic && string(magic[:]) != dirMagicV2 && string(magic[:]) != dirMagicV1 {
+		return 0, nil, nil, fmt.Errorf("native: not a chconn dir metadata file (bad magic %v)", magic)
 	}
 
 	rc, err := readUvarint(f)
 	if err != nil {
-		return 0, nil, fmt.Errorf("native: read dir metadata rowCount: %w", err)
+		return 0, nil, nil, fmt.Errorf("native: read dir metadata rowCount: %w", err)
 	}
 
 	numCols, err := readUvarint(f)
 	if err != nil {
-		return 0, nil, fmt.Errorf("native: read dir metadata numColumns: %w", err)
+		return 0, nil, nil, fmt.Errorf("native: read dir metadata numColumns: %w", err)
 	}
 	if numCols > 1<<20 {
-		return 0, nil, fmt.Errorf("native: dir metadata numColumns %d exceeds limit 1<<20", numCols)
+		return 0, nil, nil, fmt.Errorf("native: dir metadata numColumns %d exceeds limit 1<<20", numCols)
 	}
 
 	metas = make([]ColumnMeta, numCols)
 	for i := range metas {
 		name, err := readBoundedString(f, 4096)
 		if err != nil {
-			return 0, nil, fmt.Errorf("native: column[%d] name: %w", i, err)
+			return 0, nil, nil, fmt.Errorf("native: column[%d] name: %w", i, err)
 		}
 		chType, err := readBoundedString(f, 4096)
 		if err != nil {
-			return 0, nil, fmt.Errorf("native: column[%d] type: %w", i, err)
+			return 0, nil, nil, fmt.Errorf("native: column[%d] type: %w", i, err)
 		}
 		fileName, err := readBoundedString(f, 4096)
 		if err != nil {
-			return 0, nil, fmt.Errorf("native: column[%d] file: %w", i, err)
+			return 0, nil, nil, fmt.Errorf("native: column[%d] file: %w", i, err)
 		}
 		if !validDirColumnFile(fileName) {
-			return 0, nil, fmt.Errorf("native: column[%d] invalid file name %q", i, fileName)
+			return 0, nil, nil, fmt.Errorf("native: column[%d] invalid file name %q", i, fileName)
+		}
+		size := int64(-1)
+		if string(magic[:]) == dirMagic {
+			v, err := readUvarint(f)
+			if err != nil {
+				return 0, nil, nil, fmt.Errorf("native: column[%d] size: %w", i, err)
+			}
+			size = int64(v)
 		}
-		metas[i] = ColumnMeta{Index: i, Name: name, Type: chType, file: fileName}
+		metas[i] = ColumnMeta{Index: i, Name: name, Type: chType, file: fileName, size: size}
 	}
-	return int(rc), metas, nil
+
+	if string(magic[:]) == dirMagicV1 {
+		return int(rc), metas, []BlockMeta{{Rows: int(rc), Stats: make([]ColumnStats, numCols)}}, nil
+	}
+
+	numBlocks, err := readUvarint(f)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("native: read dir metadata numBlocks: %w", err)
+	}
+	if numBlocks > maxDirBlocks {
+		return 0, nil, nil, fmt.Errorf("native: dir metadata numBlocks %d exceeds limit 1<<24", numBlocks)
+	}
+	blocks = make([]BlockMeta, numBlocks)
+	rowOffset := uint64(0)
+	for i := range blocks {
+		rows, err := readUvarint(f)
+		if err != nil {
+			return 0, nil, nil, fmt.Errorf("native: block[%d] rows: %w", i, err)
+		}
+		b := BlockMeta{
+			RowOffset: int(rowOffset),
+			Rows:      int(rows),
+			Stats:     make([]ColumnStats, numCols),
+			offsets:   make([]int64, numCols),
+		}
+		for j := range metas {
+			off, err := readUvarint(f)
+			if err != nil {
+				return 0, nil, nil, fmt.Errorf("native: block[%d] column[%d] offset: %w", i, j, err)
+			}
+			b.offsets[j] = int64(off)
+			if b.Stats[j], err = readColumnStats(f); err != nil {
+				return 0, nil, nil, fmt.Errorf("native: block[%d] column[%d] stats: %w", i, j, err)
+			}
+		}
+		rowOffset += rows
+		blocks[i] = b
+	}
+	if rowOffset != rc {
+		return 0, nil, nil, fmt.Errorf("native: dir metadata blocks hold %d rows, want %d", rowOffset, rc)
+	}
+	return int(rc), metas, blocks, nil
 }
 
 // readBoundedString reads a uvarint-length-prefixed string from r, rejecting
@@ -152,16 +242,9 @@ func validDirColumnFile(name string) bool {
 	return true
 }
 
-// WriteDir writes each column to its own single-column Native file:
-// col_<NNNNNN>__<name>.native. Files are self-describing and individually readable.
-// A metadata sidecar (metadata.bin) is written after all column files succeed,
-// recording the schema without requiring decompression on OpenDir.
-// Pass WithZSTD()/WithLZ4()/WithCodec() to compress each column file.
-// Pass WithConcurrency(n) to control the number of parallel write workers
-// (default: runtime.NumCPU()).
-// On any error, already-written files are removed (best-effort) before
-// returning, so a failed WriteDir does not leave a half-populated directory.
-func WriteDir(dir string, cols []column.ColumnCore, opts ...Option) error {
+// checkDirColumns validates cols as one block of a dir-mode dataset: every
+// column typed, equal row counts and unique names. It returns the row count.
+func checkDirColumns(cols []column.ColumnCore) (int, error) {
 	rowCount := 0
 	if len(cols) > 0 {
 		rowCount = cols[0].NumRow()
@@ -169,17 +252,36 @@ func WriteDir(dir string, cols []column.ColumnCore, opts ...Option) error {
 	seen := make(map[string]struct{}, len(cols))
 	for _, col := range cols {
 		if len(col.Type()) == 0 {
-			return fmt.Errorf("native: column %q has no type; call SetType before writing", col.Name())
+			return 0, fmt.Errorf("native: column %q has no type; call SetType before writing", col.Name())
 		}
 		if col.NumRow() != rowCount {
-			return fmt.Errorf("native: column %q has %d rows, want %d", col.Name(), col.NumRow(), rowCount)
+			return 0, fmt.Errorf("native: column %q has %d rows, want %d", col.Name(), col.NumRow(), rowCount)
 		}
 		name := string(col.Name())
 		if _, dup := seen[name]; dup {
-			return fmt.Errorf("native: duplicate column name %q", name)
+			return 0, fmt.Errorf("native: duplicate column name %q", name)
 		}
 		seen[name] = struct{}{}
 	}
+	return rowCount, nil
+}
+
+// WriteDir writes each column to its own single-column Native file:
+// col_<NNNNNN>__<name>.native. Files are self-describing and individually readable.
+// A metadata sidecar (metadata.bin) is written after all column files succeed,
+// recording the schema without requiring decompression on OpenDir, together
+// with per-block row counts and min/max statistics (see DirReader.OpenBlocks).
+// Pass WithBlockRows(n) to store the columns as blocks of at most n rows.
+// Pass WithZSTD()/WithLZ4()/WithCodec() to compress each column file.
+// Pass WithConcurrency(n) to control the number of parallel write workers
+// (default: runtime.NumCPU()).
+// On any error, already-written files are removed (best-effort) before
+// returning, so a failed WriteDir does not leave a half-populated directory.
+func WriteDir(dir string, cols []column.ColumnCore, opts ...Option) error {
+	rowCount, err := checkDirColumns(cols)
+	if err != nil {
+		return err
+	}
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return err
 	}
@@ -197,6 +299,10 @@ func WriteDir(dir string, cols []column.ColumnCore, opts ...Option) error {
 	if workers > len(cols) && len(cols) > 0 {
 		workers = len(cols)
 	}
+	chunks, err := chunkColumns(cols, rowCount, cfg.blockRows)
+	if err != nil {
+		return err
+	}
 
 	var (
 		mu       sync.Mutex
@@ -205,6 +311,21 @@ func WriteDir(dir string, cols []column.ColumnCore, opts ...Option) error {
 	)
 
 	metas := make([]ColumnMeta, len(cols))
+	blocks := make([]BlockMeta, len(chunks))
+	rowOffset := 0
+	for k, chunk := range chunks {
+		rows := rowCount
+		if len(chunk) > 0 {
+			rows = chunk[0].NumRow()
+		}
+		blocks[k] = BlockMeta{
+			RowOffset: rowOffset,
+			Rows:      rows,
+			Stats:     make([]ColumnStats, len(cols)),
+			offsets:   make([]int64, len(cols)),
+		}
+		rowOffset += rows
+	}
 
 	sem := make(chan struct{}, max(workers, 1))
 	var wg sync.WaitGroup
@@ -220,7 +341,13 @@ func WriteDir(dir string, cols []column.ColumnCore, opts ...Option) error {
 			fn := fmt.Sprintf("col_%06d__%s%s", i, sanitize(name), dirFileSuffix)
 			path := filepath.Join(dir, fn)
 
-			err := WriteFile(path, []column.ColumnCore{col}, opts...)
+			colBlocks := make([]column.ColumnCore, len(chunks))
+			stats := make([]ColumnStats, len(chunks))
+			for k, chunk := range chunks {
+				colBlocks[k] = chunk[i]
+				stats[k] = columnStats(chunk[i])
+			}
+			offs, size, err := writeColumnFile(path, colBlocks, opts)
 
 			mu.Lock()
 			defer mu.Unlock()
@@ -229,7 +356,11 @@ func WriteDir(dir string, cols []column.ColumnCore, opts ...Option) error {
 					firstErr = fmt.Errorf("native: write column %q: %w", name, err)
 				}
 			} else {
-				metas[i] = ColumnMeta{Index: i, Name: name, Type: string(col.Type()), file: fn}
+				metas[i] = ColumnMeta{Index: i, Name: name, Type: string(col.Type()), file: fn, size: size}
+				for k := range blocks {
+					blocks[k].Stats[i] = stats[k]
+					blocks[k].offsets[i] = offs[k]
+				}
 				written = append(written, path)
 			}
 		}()
@@ -243,7 +374,7 @@ func WriteDir(dir string, cols []column.ColumnCore, opts ...Option) error {
 		return firstErr
 	}
 
-	if err := writeDirMeta(dir, metas, rowCount); err != nil {
+	if err := writeDirMeta(dir, metas, blocks); err != nil {
 		for _, p := range written {
 			os.Remove(p)
 		}
@@ -252,12 +383,61 @@ func WriteDir(dir string, cols []column.ColumnCore, opts ...Option) error {
 	return nil
 }
 
+// writeColumnFile writes blocks as consecutive blocks of a single-column file
+// at path, atomically as WriteFile does, and returns the byte offset of every
+// block and the size of the file.
+func writeColumnFile(path string, blocks []column.ColumnCore, opts []Option) ([]int64, int64, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return nil, 0, err
+	}
+	tmp := f.Name()
+	offs, size, err := writeColumnBlocks(f, 0, blocks, opts)
+	if err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return nil, 0, err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return nil, 0, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return nil, 0, err
+	}
+	return offs, size, nil
+}
+
+// writeColumnBlocks writes blocks to w, which already holds start bytes of a
+// column file (the compressed file header is written when start is 0), and
+// returns the byte offset of every block and the offset reached.
+func writeColumnBlocks(w io.Writer, start int64, blocks []column.ColumnCore, opts []Option) ([]int64, int64, error) {
+	cw := &countingWriter{w: w, n: start}
+	fw := NewFileWriter(cw, append(opts[:len(opts):len(opts)], withoutBlockIndex())...)
+	if fw.codec != nil {
+		fw.headerWritten = start > 0
+		if err := fw.writeHeader(); err != nil {
+			return nil, 0, err
+		}
+	}
+	offs := make([]int64, len(blocks))
+	for k, col := range blocks {
+		offs[k] = cw.n
+		if err := fw.WriteBlock(col); err != nil {
+			return nil, 0, err
+		}
+	}
+	return offs, cw.n, nil
+}
+
 // DirReader reads a dir-mode dataset, opening only the columns requested.
 type DirReader struct {
 	dir      string
 	opts     []Option
 	cols     []ColumnMeta
 	byName   map[string]ColumnMeta
+	blocks   []BlockMeta
 	rowCount int
 }
 
@@ -266,7 +446,7 @@ type DirReader struct {
 // read or decompress any column data. The sidecar must have been written by
 // WriteDir; dirs without metadata.bin are rejected.
 func OpenDir(dir string, opts ...Option) (*DirReader, error) {
-	rowCount, metas, err := readDirMeta(dir)
+	rowCount, metas, blocks, err := readDirMeta(dir)
 	if err != nil {
 		return nil, fmt.Errorf("native: open dir %s: %w", dir, err)
 	}
@@ -274,6 +454,7 @@ func OpenDir(dir string, opts ...Option) (*DirReader, error) {
 		dir:      dir,
 		opts:     opts,
 		byName:   make(map[string]ColumnMeta, len(metas)),
+		blocks:   blocks,
 		rowCount: rowCount,
 	}
 	for _, meta := range metas {
@@ -292,7 +473,13 @@ func (dr *DirReader) Columns() []ColumnMeta { return dr.cols }
 // RowCount returns the dataset row count.
 func (dr *DirReader) RowCount() int { return dr.rowCount }
 
+// Blocks returns the dataset's block index, in row order. Each block's Stats
+// are indexed by ColumnMeta.Index.
+func (dr *DirReader) Blocks() []BlockMeta { return dr.blocks }
+
 // OpenColumn reads ONLY the named column's file and returns its column.
+// A column stored as several blocks (see WithBlockRows and AppendDir) is read
+// block by block and returned as one column holding every row.
 // The DirReader's own options (e.g. WithZSTD()/WithLZ4()/WithCodec()) are applied automatically; any
 // opts passed here are appended and may augment or override them.
 func (dr *DirReader) OpenColumn(name string, opts ...Option) (column.ColumnCore, error) {
@@ -300,20 +487,110 @@ func (dr *DirReader) OpenColumn(name string, opts ...Option) (column.ColumnCore,
 	if !ok {
 		return nil, fmt.Errorf("native: column %q not found in %s", name, dr.dir)
 	}
-	allOpts := append(append([]Option(nil), dr.opts...), opts...)
+	allOpts := append(dr.fileOpts(), opts...)
 	fr, err := OpenFile(filepath.Join(dr.dir, meta.file), allOpts...)
 	if err != nil {
 		return nil, err
 	}
 	defer fr.Close()
-	_, cols, err := fr.ReadBlock()
+	block := dr.onlyBlock()
+	if block >= 0 {
+		if block > 0 {
+			if err := fr.seekOffset(dr.blocks[block].offsets[meta.Index]); err != nil {
+				return nil, err
+			}
+		}
+		_, cols, err := fr.ReadBlock()
+		if err != nil {
+			return nil, err
+		}
+		if len(cols) == 0 {
+			return nil, fmt.Errorf("native: column file for %q has no columns", name)
+		}
+		return cols[0], nil
+	}
+
+	// Rows are copied into a fresh column: decoded columns are not built for
+	// appending.
+	chType := []byte(meta.Type)
+	col, err := column.ColumnByType(chType, 0, false, false, "")
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("native: column %q: %w", name, err)
 	}
-	if len(cols) == 0 {
-		return nil, fmt.Errorf("native: column file for %q has no columns", name)
+	if err := col.SetColumnHeader(column.ColumnHeader{Name: []byte(name), ChType: chType}); err != nil {
+		return nil, fmt.Errorf("native: column %q: %w", name, err)
+	}
+	for b, block := range dr.blocks {
+		if block.Rows == 0 {
+			continue
+		}
+		if err := fr.seekOffset(block.offsets[meta.Index]); err != nil {
+			return nil, fmt.Errorf("native: seek column %q to block %d: %w", name, b, err)
+		}
+		n, cols, err := fr.ReadBlock()
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		if err == nil && len(cols) != 1 {
+			err = fmt.Errorf("block has %d columns, want 1", len(cols))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("native: column %q: block %d: %w", name, b, err)
+		}
+		if n != block.Rows {
+			return nil, fmt.Errorf("native: column %q: block %d has %d rows, metadata says %d", name, b, n, block.Rows)
+		}
+		if err := appendColumn(col, cols[0]); err != nil {
+			return nil, fmt.Errorf("native: column %q: block %d: %w", name, b, err)
+		}
+	}
+	return col, nil
+}
+
+// appendColumn appends the rows of src to dst, a column of the same type. Values
+// are copied in bulk through Data and AppendMulti (DataP and AppendMultiP when
+// the type holds a Nullable) where the column has them, and row by row through
+// AppendAny otherwise.
+func appendColumn(dst, src column.ColumnCore) error {
+	get, put := "Data", "AppendMulti"
+	if strings.Contains(string(src.Type()), "Nullable(") {
+		get, put = "DataP", "AppendMultiP"
+	}
+	g := reflect.ValueOf(src).MethodByName(get)
+	p := reflect.ValueOf(dst).MethodByName(put)
+	if g.IsValid() && p.IsValid() && g.Type().NumIn() == 0 && g.Type().NumOut() == 1 &&
+		p.Type().NumIn() == 1 && g.Type().Out(0) == p.Type().In(0) {
+		data := []reflect.Value{g.Call(nil)[0]}
+		if p.Type().IsVariadic() {
+			p.CallSlice(data)
+		} else {
+			p.Call(data)
+		}
+		return nil
+	}
+	for row := range src.NumRow() {
+		if err := dst.AppendAny(src.RowAny(row)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// onlyBlock returns the index of the dataset's only non-empty block (0 when
+// every block is empty, so a read still finds the column's type), or -1 when
+// several blocks hold rows.
+func (dr *DirReader) onlyBlock() int {
+	block := -1
+	for i, b := range dr.blocks {
+		if b.Rows == 0 {
+			continue
+		}
+		if block >= 0 {
+			return -1
+		}
+		block = i
 	}
-	return cols[0], nil
+	return max(block, 0)
 }
 
 // OpenColumns reads the named columns concurrently (workers from the options
@@ -361,6 +638,78 @@ func (dr *DirReader) OpenColumns(names []string, opts ...Option) ([]column.Colum
 	return out, nil
 }
 
+// OpenColumnsAs reads the dataset into caller-provided columns, which must have
+// their name and type set, adapting to schema changes: columns the dataset
+// lacks are filled with defaults, compatible types are widened (see
+// NativeReader.ReadBlockAs) and dataset columns without a target are never
+// opened. Columns are read concurrently as in OpenColumns. It returns the
+// dataset row count.
+func (dr *DirReader) OpenColumnsAs(cols ...column.ColumnCore) (int, error) {
+	errs := make([]error, len(cols))
+	sem := make(chan struct{}, max(min(resolve(dr.opts).workers(), len(cols)), 1))
+	var wg sync.WaitGroup
+
+	for i, col := range cols {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = dr.readColumnAs(col)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return 0, err
+		}
+	}
+	return dr.rowCount, nil
+}
+
+// readColumnAs reads the dataset column named like col into col, or fills col
+// with defaults when the dataset has no such column.
+func (dr *DirReader) readColumnAs(col column.ColumnCore) error {
+	meta, ok := dr.byName[string(col.Name())]
+	if !ok {
+		if err := fillDefault(col, dr.rowCount); err != nil {
+			return fmt.Errorf("native: %w", err)
+		}
+		return nil
+	}
+	block := dr.onlyBlock()
+	if block < 0 {
+		src, err := dr.OpenColumn(meta.Name)
+		if err != nil {
+			return err
+		}
+		if err := convertColumn(col, src); err != nil {
+			return fmt.Errorf("native: column %q: %w", meta.Name, err)
+		}
+		return nil
+	}
+	fr, err := OpenFile(filepath.Join(dr.dir, meta.file), dr.fileOpts()...)
+	if err != nil {
+		return err
+	}
+	defer fr.Close()
+	if block > 0 {
+		if err := fr.seekOffset(dr.blocks[block].offsets[meta.Index]); err != nil {
+			return err
+		}
+	}
+	_, err = fr.ReadBlockAs(col)
+	return err
+}
+
+// fileOpts returns the options for opening one column file: the DirReader's
+// options without pipelining, since WithConcurrency already sets how many
+// column files are read at once.
+func (dr *DirReader) fileOpts() []Option {
+	return append(dr.opts[:len(dr.opts):len(dr.opts)], withoutPipeline())
+}
+
 // Close is a no-op (per-column files are opened and closed on demand).
 func (dr *DirReader) Close() error { return nil }
 
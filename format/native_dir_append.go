@@ -0,0 +1,279 @@
+package format
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+// AppendDir appends cols as a new block to the dir-mode dataset in dir, or
+// writes a new dataset with WriteDir when dir holds none. cols must match the
+// dataset schema: the same column names and types, in the same order. Pass the
+// codec option the dataset was written with; WithConcurrency(n) controls the
+// number of parallel append workers as in WriteDir, and WithBlockRows(n) splits
+// cols into several blocks of at most n rows.
+//
+// Every column file grows by the new blocks before metadata.bin is replaced the
+// same atomic way WriteDir writes it (temp file + rename), so a reader opening
+// the dataset sees either the old or the new block index, never a partial one.
+// On any error the column files are truncated back to their previous sizes
+// (best-effort); bytes an interrupted append left past the sizes recorded in
+// metadata.bin are overwritten by the next append. AppendDir must not run concurrently on the same dir.
+func AppendDir(dir string, cols []column.ColumnCore, opts ...Option) error {
+	rowCount, err := checkDirColumns(cols)
+	if err != nil {
+		return err
+	}
+	_, metas, blocks, err := readDirMeta(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return WriteDir(dir, cols, opts...)
+	}
+	if err != nil {
+		return fmt.Errorf("native: append to %s: %w", dir, err)
+	}
+	if len(cols) != len(metas) {
+		return fmt.Errorf("native: append to %s: got %d columns, dataset has %d", dir, len(cols), len(metas))
+	}
+	for i, col := range cols {
+		if string(col.Name()) != metas[i].Name || string(col.Type()) != metas[i].Type {
+			return fmt.Errorf("native: append to %s: column %d is %q %s, dataset has %q %s",
+				dir, i, col.Name(), col.Type(), metas[i].Name, metas[i].Type)
+		}
+	}
+
+	cfg := resolve(opts)
+	if len(metas) > 0 {
+		if err := checkDirCodec(filepath.Join(dir, metas[0].file), cfg.codec); err != nil {
+			return fmt.Errorf("native: append to %s: %w", dir, err)
+		}
+	}
+	// Metadata written before the block index was recorded has no offsets for
+	// its single block, which starts right after the (optional) file header.
+	for i := range blocks {
+		if blocks[i].offsets == nil {
+			blocks[i].offsets = make([]int64, len(metas))
+			if cfg.codec != nil {
+				for j := range blocks[i].offsets {
+					blocks[i].offsets[j] = compressedHeaderSize(cfg.codec.Name)
+				}
+			}
+		}
+	}
+
+	chunks, err := chunkColumns(cols, rowCount, cfg.blockRows)
+	if err != nil {
+		return err
+	}
+	rowOffset := 0
+	if n := len(blocks); n > 0 {
+		rowOffset = blocks[n-1].RowOffset + blocks[n-1].Rows
+	}
+	added := make([]BlockMeta, len(chunks))
+	for k, chunk := range chunks {
+		rows := rowCount
+		if len(chunk) > 0 {
+			rows = chunk[0].NumRow()
+		}
+		added[k] = BlockMeta{
+			RowOffset: rowOffset,
+			Rows:      rows,
+			Stats:     make([]ColumnStats, len(cols)),
+			offsets:   make([]int64, len(cols)),
+		}
+		rowOffset += rows
+	}
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		appended []int
+	)
+	sem := make(chan struct{}, max(min(cfg.workers(), len(cols)), 1))
+	var wg sync.WaitGroup
+
+	for i := range cols {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			colBlocks := make([]column.ColumnCore, len(chunks))
+			stats := make([]ColumnStats, len(chunks))
+			for k, chunk := range chunks {
+				colBlocks[k] = chunk[i]
+				stats[k] = columnStats(chunk[i])
+			}
+			offs, size, err := appendBlocksFile(filepath.Join(dir, metas[i].file), metas[i].size, colBlocks, opts)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("native: append column %q: %w", metas[i].Name, err)
+				}
+				return
+			}
+			for k := range added {
+				added[k].offsets[i] = offs[k]
+				added[k].Stats[i] = stats[k]
+			}
+			metas[i].size = size
+			appended = append(appended, i)
+		}()
+	}
+	wg.Wait()
+
+	rollback := func() {
+		for _, i := range appended {
+			os.Truncate(filepath.Join(dir, metas[i].file), added[0].offsets[i])
+		}
+	}
+	if firstErr != nil {
+		rollback()
+		return firstErr
+	}
+	if err := writeDirMeta(dir, metas, append(blocks, added...)); err != nil {
+		rollback()
+		return fmt.Errorf("native: write dir metadata: %w", err)
+	}
+	return nil
+}
+
+// appendBlocksFile writes blocks after the committed size bytes of the column
+// file at path (its end when size is -1), dropping whatever an interrupted
+// append left past them, and returns the byte offset of every block and the
+// new size. The file is truncated back to its committed size on error.
+func appendBlocksFile(path string, size int64, blocks []column.ColumnCore, opts []Option) ([]int64, int64, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	off, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	if size >= 0 {
+		if size > off {
+			f.Close()
+			return nil, 0, fmt.Errorf("file has %d bytes, metadata records %d", off, size)
+		}
+		if err := f.Truncate(size); err != nil {
+			f.Close()
+			return nil, 0, err
+		}
+		if off, err = f.Seek(size, io.SeekStart); err != nil {
+			f.Close()
+			return nil, 0, err
+		}
+	}
+	offs, end, err := writeColumnBlocks(f, off, blocks, opts)
+	if err != nil {
+		f.Truncate(off)
+		f.Close()
+		return nil, 0, err
+	}
+	if err := f.Close(); err != nil {
+		os.Truncate(path, off)
+		return nil, 0, err
+	}
+	return offs, end, nil
+}
+
+// checkDirCodec verifies that the column file at path was written with codec
+// (or uncompressed, when codec is nil), so appended blocks match the existing ones.
+func checkDirCodec(path string, codec *Codec) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if codec != nil {
+		return readCompressedHeader(f, codec.Name)
+	}
+	var magic [4]byte
+	if _, err := io.ReadFull(f, magic[:]); err == nil && magic == compressedMagic {
+		return errors.New("native: dataset is compressed; pass the codec option it was written with")
+	}
+	return nil
+}
+
+// PartitionPath returns the dataset directory AppendPartitioned uses under root
+// for rows whose key column renders as value: root/<key>=<value>, with key and
+// value query-escaped.
+func PartitionPath(root, key, value string) string {
+	return filepath.Join(root, url.QueryEscape(key)+"="+url.QueryEscape(value))
+}
+
+// AppendPartitioned splits cols by the value of the key column and appends each
+// group with AppendDir to the dataset at PartitionPath(root, key, value),
+// creating datasets as needed. Values are rendered as in JSON output, without
+// quotes (e.g. 2024-01-02 for a Date, null for NULL); pass WithPartitionValue
+// to partition by a function of that value instead, such as the day of a
+// DateTime. The key column is stored in every partition. Partitions are
+// appended one at a time in order of first appearance; on error, partitions
+// appended before the failing one keep their new block.
+func AppendPartitioned(root, key string, cols []column.ColumnCore, opts ...Option) error {
+	if _, err := checkDirColumns(cols); err != nil {
+		return err
+	}
+	var keyCol column.ColumnCore
+	for _, col := range cols {
+		if string(col.Name()) == key {
+			keyCol = col
+			break
+		}
+	}
+	if keyCol == nil {
+		return fmt.Errorf("native: partition key column %q not found", key)
+	}
+	cfg := resolve(opts)
+
+	var (
+		values  []string
+		index   = map[string]int{}
+		rowPart = make([]int, keyCol.NumRow())
+		b       []byte
+	)
+	for row := range keyCol.NumRow() {
+		b = keyCol.ToJSON(row, true, b[:0])
+		v := string(bytes.Trim(b, `"`))
+		if cfg.partitionValue != nil {
+			v = cfg.partitionValue(v)
+		}
+		p, ok := index[v]
+		if !ok {
+			p = len(values)
+			index[v] = p
+			values = append(values, v)
+		}
+		rowPart[row] = p
+	}
+
+	if len(values) <= 1 {
+		if len(values) == 0 {
+			return nil
+		}
+		return AppendDir(PartitionPath(root, key, values[0]), cols, opts...)
+	}
+
+	parts, err := splitColumns(cols, rowPart, len(values))
+	if err != nil {
+		return err
+	}
+	for p, v := range values {
+		if err := AppendDir(PartitionPath(root, key, v), parts[p], opts...); err != nil {
+			return fmt.Errorf("native: partition %s=%s: %w", key, v, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,372 @@
+package format
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+	"github.com/vahid-sohrabloo/chconn/v3/types"
+)
+
+// readDirStrings reads every block of column name in dir and joins its values.
+func readDirStrings(t *testing.T, dir, name string, opts []Option, preds ...Predicate) string {
+	t.Helper()
+	dr, err := OpenDir(dir, opts...)
+	if err != nil {
+		t.Fatalf("OpenDir: %v", err)
+	}
+	br, err := dr.OpenBlocks([]string{name}, preds...)
+	if err != nil {
+		t.Fatalf("OpenBlocks: %v", err)
+	}
+	defer br.Close()
+	var got []string
+	for {
+		_, cols, err := br.ReadBlock()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ReadBlock: %v", err)
+		}
+		for i := range cols[0].NumRow() {
+			got = append(got, strings.Trim(string(cols[0].ToJSON(i, false, nil)), `"`))
+		}
+	}
+	return strings.Join(got, " ")
+}
+
+func TestAppendDir(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{name: "plain"},
+		{name: "zstd", opts: []Option{WithZSTD()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, block := range [][]column.ColumnCore{
+				{int32Col("id", 1, 2), strCol("s", "a", "b")},
+				{int32Col("id"), strCol("s")},
+				{int32Col("id", 10, 11, 12), strCol("s", "c", "d", "e")},
+			} {
+				if err := AppendDir(dir, block, tc.opts...); err != nil {
+					t.Fatalf("AppendDir: %v", err)
+				}
+			}
+			block := []column.ColumnCore{int32Col("id", 20, 21, 22), strCol("s", "f", "g", "h")}
+			if err := AppendDir(dir, block, append(tc.opts, WithBlockRows(2))...); err != nil {
+				t.Fatalf("AppendDir: %v", err)
+			}
+
+			dr, err := OpenDir(dir, tc.opts...)
+			if err != nil {
+				t.Fatalf("OpenDir: %v", err)
+			}
+			blocks := dr.Blocks()
+			if dr.RowCount() != 8 || len(blocks) != 5 || blocks[2].RowOffset != 2 || blocks[2].Stats[0].Min != int64(10) ||
+				blocks[4].RowOffset != 7 || blocks[4].Rows != 1 || blocks[4].Stats[0].Min != int64(22) {
+				t.Fatalf("RowCount = %d, blocks = %+v", dr.RowCount(), blocks)
+			}
+			if got := readDirStrings(t, dir, "s", tc.opts); got != "a b c d e f g h" {
+				t.Fatalf("got %q", got)
+			}
+			if got := readDirStrings(t, dir, "s", tc.opts, Range("id", 5, 20)); got != "c d e f g" {
+				t.Fatalf("got %q with predicate", got)
+			}
+			cols, err := dr.OpenColumns([]string{"id", "s"})
+			if err != nil {
+				t.Fatalf("OpenColumns: %v", err)
+			}
+			if got := cols[1].(*column.String).Data(); strings.Join(got, " ") != "a b c d e f g h" ||
+				cols[0].NumRow() != 8 || cols[0].(*column.Base[int32]).Row(7) != 22 {
+				t.Fatalf("OpenColumns got %d rows, %q", cols[0].NumRow(), got)
+			}
+			id := column.New[int64]()
+			id.SetName([]byte("id"))
+			id.SetType([]byte("Int64"))
+			if n, err := dr.OpenColumnsAs(id); err != nil || n != 8 || id.NumRow() != 8 || id.Row(4) != 12 {
+				t.Fatalf("OpenColumnsAs: %v, got %v", err, id.Data())
+			}
+
+			mr, err := OpenFileMmap(dir)
+			if tc.opts != nil {
+				if err == nil {
+					mr.Close()
+					t.Fatal("expected OpenFileMmap to reject a compressed dataset")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OpenFileMmap: %v", err)
+			}
+			defer mr.Close()
+			rows := 0
+			for {
+				n, _, err := mr.ReadBlock()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("ReadBlock: %v", err)
+				}
+				rows += n
+			}
+			if rows != 8 {
+				t.Fatalf("mmap read %d rows, want 8", rows)
+			}
+		})
+	}
+}
+
+func TestAppendDirSingleBlockOpenColumn(t *testing.T) {
+	dir := t.TempDir()
+	if err := AppendDir(dir, []column.ColumnCore{int32Col("id")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendDir(dir, []column.ColumnCore{int32Col("id", 7, 8)}); err != nil {
+		t.Fatal(err)
+	}
+	dr, err := OpenDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := dr.OpenColumn("id")
+	if err != nil {
+		t.Fatalf("OpenColumn: %v", err)
+	}
+	if col.NumRow() != 2 || col.(*column.Base[int32]).Row(1) != 8 {
+		t.Fatalf("got %d rows", col.NumRow())
+	}
+}
+
+func TestOpenColumnBlocks(t *testing.T) {
+	n := column.New[int32]().Nullable()
+	n.SetName([]byte("n"))
+	n.SetType([]byte("Nullable(Int32)"))
+	tags := column.NewString().Array()
+	tags.SetName([]byte("tags"))
+	tags.SetType([]byte("Array(String)"))
+	lc := column.NewString().LC()
+	lc.SetName([]byte("lc"))
+	lc.SetType([]byte("LowCardinality(String)"))
+	for i, v := range []string{"a", "b", "a", "c", "b"} {
+		if i%2 == 0 {
+			n.AppendP(nil)
+		} else {
+			n.Append(int32(i))
+		}
+		tags.Append([]string{v, v + v})
+		lc.Append(v)
+	}
+	dir := t.TempDir()
+	if err := WriteDir(dir, []column.ColumnCore{n, tags, lc}, WithBlockRows(2), WithLZ4()); err != nil {
+		t.Fatalf("WriteDir: %v", err)
+	}
+	dr, err := OpenDir(dir, WithLZ4())
+	if err != nil {
+		t.Fatalf("OpenDir: %v", err)
+	}
+	if len(dr.Blocks()) != 3 {
+		t.Fatalf("got %d blocks, want 3", len(dr.Blocks()))
+	}
+	cols, err := dr.OpenColumns([]string{"n", "tags", "lc"})
+	if err != nil {
+		t.Fatalf("OpenColumns: %v", err)
+	}
+	for i, want := range []string{
+		"null 1 null 3 null",
+		`["a","aa"] ["b","bb"] ["a","aa"] ["c","cc"] ["b","bb"]`,
+		"a b a c b",
+	} {
+		var got []string
+		for row := range cols[i].NumRow() {
+			got = append(got, strings.Trim(string(cols[i].ToJSON(row, false, nil)), `"`))
+		}
+		if strings.Join(got, " ") != want {
+			t.Fatalf("column %s = %q, want %q", cols[i].Name(), strings.Join(got, " "), want)
+		}
+	}
+}
+
+// TestAppendDirOrphanBytes simulates an AppendDir that wrote a block to every
+// column file but died before replacing metadata.bin.
+func TestAppendDirOrphanBytes(t *testing.T) {
+	dir := t.TempDir()
+	for _, block := range [][]column.ColumnCore{
+		{int32Col("id", 1, 2), strCol("s", "a", "b")},
+		{int32Col("id", 3), strCol("s", "c")},
+	} {
+		if err := AppendDir(dir, block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dr, err := OpenDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, col := range []column.ColumnCore{int32Col("id", 99), strCol("s", "orphan")} {
+		f, err := os.OpenFile(filepath.Join(dir, dr.Columns()[i].file), os.O_WRONLY|os.O_APPEND, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := NewFileWriter(f).WriteBlock(col); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+	}
+
+	check := func(want string) {
+		t.Helper()
+		dr, err := OpenDir(dir)
+		if err != nil {
+			t.Fatalf("OpenDir: %v", err)
+		}
+		col, err := dr.OpenColumn("s")
+		if err != nil {
+			t.Fatalf("OpenColumn: %v", err)
+		}
+		if got := strings.Join(col.(*column.String).Data(), " "); got != want {
+			t.Fatalf("OpenColumn got %q, want %q", got, want)
+		}
+		ins := &fakeInserter{t: t, header: []string{"s String"}}
+		if err := LoadDir(context.Background(), ins, "INSERT INTO t VALUES", dir); err != nil {
+			t.Fatalf("LoadDir: %v", err)
+		}
+		if got := strings.Join(ins.rows, " "); got != strings.ReplaceAll(`"`+want+`"`, " ", `" "`) {
+			t.Fatalf("LoadDir got %s, want %q", got, want)
+		}
+		mr, err := OpenFileMmap(dir)
+		if err != nil {
+			t.Fatalf("OpenFileMmap: %v", err)
+		}
+		defer mr.Close()
+		var got []string
+		for {
+			_, cols, err := mr.ReadBlock()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("mmap ReadBlock: %v", err)
+			}
+			got = append(got, cols[1].(*column.String).Data()...)
+		}
+		if strings.Join(got, " ") != want {
+			t.Fatalf("mmap got %q, want %q", got, want)
+		}
+	}
+	check("a b c")
+
+	if err := AppendDir(dir, []column.ColumnCore{int32Col("id", 4), strCol("s", "d")}); err != nil {
+		t.Fatalf("AppendDir: %v", err)
+	}
+	check("a b c d")
+}
+
+func TestAppendDirMismatch(t *testing.T) {
+	dir := t.TempDir()
+	if err := AppendDir(dir, []column.ColumnCore{int32Col("id", 1)}, WithLZ4()); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendDir(dir, []column.ColumnCore{floatCol("id", 1)}, WithLZ4()); err == nil ||
+		!strings.Contains(err.Error(), "dataset has") {
+		t.Fatalf("got %v, want a schema error", err)
+	}
+	if err := AppendDir(dir, []column.ColumnCore{int32Col("id", 1), strCol("s", "x")}, WithLZ4()); err == nil {
+		t.Fatal("expected an error for an extra column")
+	}
+	if err := AppendDir(dir, []column.ColumnCore{int32Col("id", 1)}); err == nil ||
+		!strings.Contains(err.Error(), "compressed") {
+		t.Fatalf("got %v, want a codec error", err)
+	}
+	if err := AppendDir(dir, []column.ColumnCore{int32Col("id", 1)}, WithZSTD()); err == nil ||
+		!strings.Contains(err.Error(), "codec mismatch") {
+		t.Fatalf("got %v, want a codec error", err)
+	}
+	dr, err := OpenDir(dir, WithLZ4())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col, err := dr.OpenColumn("id"); err != nil || col.NumRow() != 1 {
+		t.Fatalf("dataset changed by failed appends: %v", err)
+	}
+}
+
+func TestAppendPartitioned(t *testing.T) {
+	day := column.NewDate[types.Date]()
+	day.SetName([]byte("day"))
+	day.SetType([]byte("Date"))
+	tags := column.NewString().Array()
+	tags.SetName([]byte("tags"))
+	tags.SetType([]byte("Array(String)"))
+	d1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	d2 := d1.AddDate(0, 0, 1)
+	for i, d := range []time.Time{d1, d2, d1, d2} {
+		day.Append(d)
+		if d == d1 {
+			tags.Append([]string{"x", strings.Repeat("y", i)})
+		} else {
+			tags.Append(nil)
+		}
+	}
+
+	root := t.TempDir()
+	cols := []column.ColumnCore{day, tags, strCol("s", "a", "b", "c", "d")}
+	if err := AppendPartitioned(root, "day", cols); err != nil {
+		t.Fatalf("AppendPartitioned: %v", err)
+	}
+	if err := AppendPartitioned(root, "day", cols); err != nil {
+		t.Fatalf("AppendPartitioned: %v", err)
+	}
+
+	p1 := PartitionPath(root, "day", "2024-01-01")
+	p2 := PartitionPath(root, "day", "2024-01-02")
+	if got := readDirStrings(t, p1, "s", nil); got != "a c a c" {
+		t.Errorf("%s s: got %q", p1, got)
+	}
+	if got := readDirStrings(t, p1, "tags", nil); got != `["x",""] ["x","yy"] ["x",""] ["x","yy"]` {
+		t.Errorf("%s tags: got %q", p1, got)
+	}
+	if got := readDirStrings(t, p2, "s", nil); got != "b d b d" {
+		t.Errorf("%s s: got %q", p2, got)
+	}
+	if got := readDirStrings(t, p2, "tags", nil); got != "[] [] [] []" {
+		t.Errorf("%s tags: got %q", p2, got)
+	}
+
+	if err := AppendPartitioned(root, "nope", cols); err == nil {
+		t.Fatal("expected an error for a missing key column")
+	}
+}
+
+func TestAppendPartitionedValue(t *testing.T) {
+	ts := column.NewDate[types.DateTime]()
+	ts.SetName([]byte("ts"))
+	ts.SetType([]byte("DateTime('UTC')"))
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	for _, h := range []int{0, 24, 48, 1, 25, 2} {
+		ts.Append(start.Add(time.Duration(h) * time.Hour))
+	}
+	root := t.TempDir()
+	cols := []column.ColumnCore{ts, strCol("s", "a", "b", "c", "d", "e", "f")}
+	err := AppendPartitioned(root, "ts", cols, WithPartitionValue(func(v string) string { return v[:10] }))
+	if err != nil {
+		t.Fatalf("AppendPartitioned: %v", err)
+	}
+	if ts.NumRow() != 6 {
+		t.Fatalf("AppendPartitioned changed its input: %d rows", ts.NumRow())
+	}
+	for day, want := range map[string]string{"2024-01-01": "a d f", "2024-01-02": "b e", "2024-01-03": "c"} {
+		if got := readDirStrings(t, PartitionPath(root, "ts", day), "s", nil); got != want {
+			t.Errorf("%s: got %q, want %q", day, got, want)
+		}
+	}
+}
@@ -246,3 +246,40 @@ func TestWriteDirDuplicateName(t *testing.T) {
 		t.Fatal("expected duplicate column name error from WriteDir")
 	}
 }
+
+// TestDirReaderColumnFilesNotPipelined checks that a DirReader's
+// WithConcurrency sets how many column files are read at once and does not
+// also start a read-ahead pipeline in every column file.
+func TestDirReaderColumnFilesNotPipelined(t *testing.T) {
+	dir := t.TempDir()
+	cols := []column.ColumnCore{floatCol("a", 1, 2, 3), strCol("b", "x", "y", "z")}
+	if err := WriteDir(dir, cols, WithZSTD()); err != nil {
+		t.Fatalf("WriteDir: %v", err)
+	}
+	dr, err := OpenDir(dir, WithZSTD(), WithConcurrency(4))
+	if err != nil {
+		t.Fatalf("OpenDir: %v", err)
+	}
+	defer dr.Close()
+
+	if resolve(dr.fileOpts()).concurrencySet {
+		t.Fatal("column file options keep WithConcurrency")
+	}
+	br, err := dr.OpenBlocks([]string{"a", "b"})
+	if err != nil {
+		t.Fatalf("OpenBlocks: %v", err)
+	}
+	defer br.Close()
+	for i, fr := range br.readers {
+		if fr.workers != 0 {
+			t.Fatalf("reader %d: workers = %d, want 0", i, fr.workers)
+		}
+	}
+	got, err := dr.OpenColumns([]string{"a", "b"})
+	if err != nil {
+		t.Fatalf("OpenColumns: %v", err)
+	}
+	if v := got[1].(*column.String).Row(2); v != "z" {
+		t.Fatalf("b[2] = %q, want %q", v, "z")
+	}
+}
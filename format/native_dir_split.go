@@ -0,0 +1,89 @@
+package format
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+// countingWriter tracks the number of bytes written, to record block offsets.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// copyColumns returns a deep copy of cols, made by encoding them as one
+// Native block and decoding it back.
+func copyColumns(cols []column.ColumnCore) ([]column.ColumnCore, error) {
+	var buf bytes.Buffer
+	if err := NewNativeWriter(&buf).WriteBlock(cols...); err != nil {
+		return nil, err
+	}
+	return NewNativeReader().ReadBlock(&buf, nil)
+}
+
+// splitColumns splits the rows of cols into parts groups, sending row i to
+// group rowPart[i]; rows keep their order within a group. cols are not
+// modified. The partition range is halved recursively, so every row is copied
+// O(log parts) times rather than once per group.
+func splitColumns(cols []column.ColumnCore, rowPart []int, parts int) ([][]column.ColumnCore, error) {
+	out := make([][]column.ColumnCore, parts)
+	var split func(cols []column.ColumnCore, rowPart []int, lo, hi int) error
+	split = func(cols []column.ColumnCore, rowPart []int, lo, hi int) error {
+		if hi-lo == 1 {
+			out[lo] = cols
+			return nil
+		}
+		mid := (lo + hi) / 2
+		right, err := copyColumns(cols)
+		if err != nil {
+			return err
+		}
+		for _, col := range cols {
+			col.DeleteFunc(func(row int) bool { return rowPart[row] >= mid })
+		}
+		for _, col := range right {
+			col.DeleteFunc(func(row int) bool { return rowPart[row] < mid })
+		}
+		var leftPart, rightPart []int
+		for _, p := range rowPart {
+			if p < mid {
+				leftPart = append(leftPart, p)
+			} else {
+				rightPart = append(rightPart, p)
+			}
+		}
+		if err := split(cols, leftPart, lo, mid); err != nil {
+			return err
+		}
+		return split(right, rightPart, mid, hi)
+	}
+	if parts == 0 {
+		return out, nil
+	}
+	cp, err := copyColumns(cols)
+	if err != nil {
+		return nil, err
+	}
+	return out, split(cp, rowPart, 0, parts)
+}
+
+// chunkColumns splits cols into blocks of at most n rows each. n <= 0, or a
+// block that already fits, returns cols itself as the only block.
+func chunkColumns(cols []column.ColumnCore, rowCount, n int) ([][]column.ColumnCore, error) {
+	if n <= 0 || rowCount <= n {
+		return [][]column.ColumnCore{cols}, nil
+	}
+	rowPart := make([]int, rowCount)
+	for i := range rowPart {
+		rowPart[i] = i / n
+	}
+	return splitColumns(cols, rowPart, (rowCount+n-1)/n)
+}
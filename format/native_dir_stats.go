@@ -0,0 +1,508 @@
+package format
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+	"github.com/vahid-sohrabloo/chconn/v3/internal/helper"
+)
+
+// BlockMeta describes one block of a dir-mode dataset, as recorded in its
+// metadata sidecar.
+type BlockMeta struct {
+	RowOffset int // index of the block's first row within the dataset
+	Rows      int
+	Stats     []ColumnStats // indexed by ColumnMeta.Index
+	offsets   []int64       // byte offset of the block in each column file
+}
+
+// ColumnStats is the min/max of a column within one block. Min and Max are
+// int64 for signed integers, uint64 for unsigned integers, float64 for floats
+// and time.Time for Date, Date32, DateTime and DateTime64 (NULL and NaN values
+// are ignored). Both are nil when the type has no stats or no value counted.
+type ColumnStats struct {
+	Min, Max any
+}
+
+// Predicate restricts a dir-mode read to blocks whose stats may hold a value of
+// Column within [Min, Max]. A nil bound is unbounded. Bounds are any Go integer
+// or float type for numeric columns and time.Time for date and time columns.
+type Predicate struct {
+	Column   string
+	Min, Max any
+}
+
+// Range returns a Predicate for column with inclusive bounds min and max.
+func Range(column string, min, max any) Predicate {
+	return Predicate{Column: column, Min: min, Max: max}
+}
+
+// mayMatch reports whether a block with stats s can hold a matching value.
+// Blocks without stats always may match.
+func (p Predicate) mayMatch(s ColumnStats) (bool, error) {
+	if s.Min == nil || s.Max == nil {
+		return true, nil
+	}
+	if p.Min != nil {
+		c, err := compareStat(s.Max, p.Min)
+		if err != nil {
+			return false, err
+		}
+		if c < 0 {
+			return false, nil
+		}
+	}
+	if p.Max != nil {
+		c, err := compareStat(s.Min, p.Max)
+		if err != nil {
+			return false, err
+		}
+		if c > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// compareStat compares a stats value with a predicate bound.
+func compareStat(stat, bound any) (int, error) {
+	if st, ok := stat.(time.Time); ok {
+		bt, ok := bound.(time.Time)
+		if !ok {
+			return 0, fmt.Errorf("cannot compare %T with a date or time column", bound)
+		}
+		return st.Compare(bt), nil
+	}
+	sf, _ := numberToFloat(stat)
+	bf, ok := numberToFloat(bound)
+	if !ok {
+		return 0, fmt.Errorf("cannot compare %T with a numeric column", bound)
+	}
+	return sf.Cmp(bf), nil
+}
+
+// numberToFloat converts any Go integer or non-NaN float to an exact big.Float.
+func numberToFloat(v any) (*big.Float, bool) {
+	f := new(big.Float)
+	switch v := v.(type) {
+	case int:
+		f.SetInt64(int64(v))
+	case int8:
+		f.SetInt64(int64(v))
+	case int16:
+		f.SetInt64(int64(v))
+	case int32:
+		f.SetInt64(int64(v))
+	case int64:
+		f.SetInt64(v)
+	case uint:
+		f.SetUint64(uint64(v))
+	case uint8:
+		f.SetUint64(uint64(v))
+	case uint16:
+		f.SetUint64(uint64(v))
+	case uint32:
+		f.SetUint64(uint64(v))
+	case uint64:
+		f.SetUint64(v)
+	case float32:
+		if math.IsNaN(float64(v)) {
+			return nil, false
+		}
+		f.SetFloat64(float64(v))
+	case float64:
+		if math.IsNaN(v) {
+			return nil, false
+		}
+		f.SetFloat64(v)
+	default:
+		return nil, false
+	}
+	return f, true
+}
+
+// columnStats computes the min/max of col for the types ColumnStats supports.
+func columnStats(col column.ColumnCore) ColumnStats {
+	chType := col.Type()
+	isNil := func(int) bool { return false }
+	if helper.IsNullable(chType) {
+		chType = chType[helper.LenNullableStr : len(chType)-1]
+		if n, ok := col.(interface{ RowIsNil(int) bool }); ok {
+			isNil = n.RowIsNil
+		}
+	}
+	switch string(chType) {
+	case "Int8":
+		return orderedStats[int8, int64](col, isNil)
+	case "Int16":
+		return orderedStats[int16, int64](col, isNil)
+	case "Int32":
+		return orderedStats[int32, int64](col, isNil)
+	case "Int64":
+		return orderedStats[int64, int64](col, isNil)
+	case "UInt8":
+		return orderedStats[uint8, uint64](col, isNil)
+	case "UInt16":
+		return orderedStats[uint16, uint64](col, isNil)
+	case "UInt32":
+		return orderedStats[uint32, uint64](col, isNil)
+	case "UInt64":
+		return orderedStats[uint64, uint64](col, isNil)
+	case "Float32":
+		return orderedStats[float32, float64](col, isNil)
+	case "Float64":
+		return orderedStats[float64, float64](col, isNil)
+	}
+	if bytes.HasPrefix(chType, []byte("Date")) {
+		return timeStats(col, isNil)
+	}
+	return ColumnStats{}
+}
+
+type statsNumber interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64
+}
+
+func orderedStats[T, S statsNumber](col column.ColumnCore, isNil func(int) bool) ColumnStats {
+	r, ok := col.(column.RowReader[T])
+	if !ok {
+		return ColumnStats{}
+	}
+	var lo, hi T
+	found := false
+	for i := range col.NumRow() {
+		v := r.Row(i)
+		if isNil(i) || v != v { // NULL or NaN
+			continue
+		}
+		if !found {
+			lo, hi, found = v, v, true
+			continue
+		}
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	if !found {
+		return ColumnStats{}
+	}
+	return ColumnStats{Min: S(lo), Max: S(hi)}
+}
+
+func timeStats(col column.ColumnCore, isNil func(int) bool) ColumnStats {
+	r, ok := col.(column.RowReader[time.Time])
+	if !ok {
+		return ColumnStats{}
+	}
+	var lo, hi time.Time
+	found := false
+	for i := range col.NumRow() {
+		if isNil(i) {
+			continue
+		}
+		v := r.Row(i)
+		if !found {
+			lo, hi, found = v, v, true
+			continue
+		}
+		if v.Before(lo) {
+			lo = v
+		}
+		if v.After(hi) {
+			hi = v
+		}
+	}
+	if !found {
+		return ColumnStats{}
+	}
+	return ColumnStats{Min: lo.UTC(), Max: hi.UTC()}
+}
+
+const (
+	statsNone byte = iota
+	statsInt
+	statsUint
+	statsFloat
+	statsTime
+)
+
+// appendColumnStats encodes s as a kind byte followed by min and max:
+// varints for int64, uvarints for uint64, 8-byte little-endian IEEE 754 for
+// float64, and varint seconds plus uvarint nanoseconds for time.Time.
+func appendColumnStats(buf []byte, s ColumnStats) []byte {
+	switch lo := s.Min.(type) {
+	case int64:
+		buf = append(buf, statsInt)
+		buf = binary.AppendVarint(buf, lo)
+		return binary.AppendVarint(buf, s.Max.(int64))
+	case uint64:
+		buf = append(buf, statsUint)
+		buf = binary.AppendUvarint(buf, lo)
+		return binary.AppendUvarint(buf, s.Max.(uint64))
+	case float64:
+		buf = append(buf, statsFloat)
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(lo))
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(s.Max.(float64)))
+	case time.Time:
+		hi := s.Max.(time.Time)
+		buf = append(buf, statsTime)
+		buf = binary.AppendVarint(buf, lo.Unix())
+		buf = binary.AppendUvarint(buf, uint64(lo.Nanosecond()))
+		buf = binary.AppendVarint(buf, hi.Unix())
+		return binary.AppendUvarint(buf, uint64(hi.Nanosecond()))
+	}
+	return append(buf, statsNone)
+}
+
+// readColumnStats decodes stats written by appendColumnStats.
+func readColumnStats(r *bytes.Reader) (ColumnStats, error) {
+	kind, err := r.ReadByte()
+	if err != nil {
+		return ColumnStats{}, err
+	}
+	var vals [2]any
+	for i := range vals {
+		switch kind {
+		case statsNone:
+			return ColumnStats{}, nil
+		case statsInt:
+			v, err := binary.ReadVarint(r)
+			if err != nil {
+				return ColumnStats{}, err
+			}
+			vals[i] = v
+		case statsUint:
+			v, err := binary.ReadUvarint(r)
+			if err != nil {
+				return ColumnStats{}, err
+			}
+			vals[i] = v
+		case statsFloat:
+			var b [8]byte
+			if _, err := io.ReadFull(r, b[:]); err != nil {
+				return ColumnStats{}, err
+			}
+			vals[i] = math.Float64frombits(binary.LittleEndian.Uint64(b[:]))
+		case statsTime:
+			sec, err := binary.ReadVarint(r)
+			if err != nil {
+				return ColumnStats{}, err
+			}
+			nsec, err := binary.ReadUvarint(r)
+			if err != nil {
+				return ColumnStats{}, err
+			}
+			if nsec >= uint64(time.Second) {
+				return ColumnStats{}, fmt.Errorf("nanoseconds %d out of range", nsec)
+			}
+			vals[i] = time.Unix(sec, int64(nsec)).UTC()
+		default:
+			return ColumnStats{}, fmt.Errorf("unknown stats kind %d", kind)
+		}
+	}
+	return ColumnStats{Min: vals[0], Max: vals[1]}, nil
+}
+
+// DirBlockReader streams the blocks of a dir-mode dataset selected by
+// DirReader.OpenBlocks, reading only the projected column files.
+type DirBlockReader struct {
+	dr      *DirReader
+	metas   []ColumnMeta
+	readers []*FileReader
+	blocks  []int // selected block indices, ascending
+	pos     int
+	next    []int // block index each reader is positioned at
+	closed  bool
+}
+
+// OpenBlocks opens the named columns (every column if names is empty) for
+// block-by-block reads, skipping blocks whose stats show they cannot satisfy
+// all preds. Predicates may name columns that are not projected. Skipping is
+// per block: rows of a returned block are not filtered, so callers still
+// apply their predicate to each row. Blocks without stats for a predicate's
+// column (e.g. String columns, or datasets written before stats were
+// recorded) are always returned.
+func (dr *DirReader) OpenBlocks(names []string, preds ...Predicate) (*DirBlockReader, error) {
+	for _, p := range preds {
+		if _, ok := dr.byName[p.Column]; !ok {
+			return nil, fmt.Errorf("native: predicate column %q not found in %s", p.Column, dr.dir)
+		}
+		for _, bound := range []any{p.Min, p.Max} {
+			if _, isTime := bound.(time.Time); bound != nil && !isTime {
+				if _, ok := numberToFloat(bound); !ok {
+					return nil, fmt.Errorf("native: predicate on %q: unsupported bound %v (%T)", p.Column, bound, bound)
+				}
+			}
+		}
+	}
+
+	br := &DirBlockReader{dr: dr}
+	for i, b := range dr.blocks {
+		if b.Rows == 0 {
+			continue
+		}
+		match := true
+		for _, p := range preds {
+			ok, err := p.mayMatch(b.Stats[dr.byName[p.Column].Index])
+			if err != nil {
+				return nil, fmt.Errorf("native: predicate on %q: %w", p.Column, err)
+			}
+			if !ok {
+				match = false
+				break
+			}
+		}
+		if match {
+			br.blocks = append(br.blocks, i)
+		}
+	}
+
+	if len(names) == 0 {
+		br.metas = dr.cols
+	} else {
+		for _, name := range names {
+			meta, ok := dr.byName[name]
+			if !ok {
+				return nil, fmt.Errorf("native: column %q not found in %s", name, dr.dir)
+			}
+			br.metas = append(br.metas, meta)
+		}
+	}
+	if len(br.blocks) == 0 {
+		return br, nil
+	}
+	for _, meta := range br.metas {
+		fr, err := OpenFile(filepath.Join(dr.dir, meta.file), dr.fileOpts()...)
+		if err != nil {
+			br.Close()
+			return nil, err
+		}
+		br.readers = append(br.readers, fr)
+	}
+	br.next = make([]int, len(br.readers))
+	return br, nil
+}
+
+// Blocks returns the metadata of the blocks the reader yields, in order.
+func (br *DirBlockReader) Blocks() []BlockMeta {
+	out := make([]BlockMeta, len(br.blocks))
+	for i, b := range br.blocks {
+		out[i] = br.dr.blocks[b]
+	}
+	return out
+}
+
+// ReadBlock reads the next selected block, returning its row count and one
+// column per projected name. Returns io.EOF when no selected blocks remain.
+func (br *DirBlockReader) ReadBlock() (int, []column.ColumnCore, error) {
+	idx, err := br.nextBlock()
+	if err != nil {
+		return 0, nil, err
+	}
+	cols := make([]column.ColumnCore, len(br.metas))
+	for i := range br.metas {
+		if cols[i], err = br.readColumn(i, idx, nil); err != nil {
+			return 0, nil, err
+		}
+	}
+	br.pos++
+	return br.dr.blocks[idx].Rows, cols, nil
+}
+
+// ReadBlockAs reads the next selected block into caller-provided columns,
+// adapting to schema changes as NativeReader.ReadBlockAs does. Targets are
+// matched by name with the columns the reader was opened with; targets the
+// reader did not open are filled with defaults, and opened columns without a
+// target are skipped. Returns io.EOF when no selected blocks remain.
+func (br *DirBlockReader) ReadBlockAs(cols ...column.ColumnCore) (int, error) {
+	idx, err := br.nextBlock()
+	if err != nil {
+		return 0, err
+	}
+	rows := br.dr.blocks[idx].Rows
+	for _, col := range cols {
+		i := slices.IndexFunc(br.metas, func(m ColumnMeta) bool { return m.Name == string(col.Name()) })
+		if i < 0 {
+			if err := fillDefault(col, rows); err != nil {
+				return 0, fmt.Errorf("native: %w", err)
+			}
+			continue
+		}
+		if _, err := br.readColumn(i, idx, col); err != nil {
+			return 0, err
+		}
+	}
+	br.pos++
+	return rows, nil
+}
+
+// nextBlock returns the index of the next selected block.
+func (br *DirBlockReader) nextBlock() (int, error) {
+	if br.closed {
+		return 0, errors.New("native: read from closed DirBlockReader")
+	}
+	if br.pos >= len(br.blocks) {
+		return 0, io.EOF
+	}
+	return br.blocks[br.pos], nil
+}
+
+// readColumn reads block idx of the i-th projected column, seeking first if
+// the reader is not positioned at it. With into == nil the block is decoded
+// into a new column; otherwise it is projected onto into.
+func (br *DirBlockReader) readColumn(i, idx int, into column.ColumnCore) (column.ColumnCore, error) {
+	meta, fr, block := br.metas[i], br.readers[i], br.dr.blocks[idx]
+	if br.next[i] != idx {
+		if err := fr.seekOffset(block.offsets[meta.Index]); err != nil {
+			return nil, fmt.Errorf("native: seek column %q to block %d: %w", meta.Name, idx, err)
+		}
+	}
+	var (
+		n   int
+		col = into
+		err error
+	)
+	if into == nil {
+		var blockCols []column.ColumnCore
+		n, blockCols, err = fr.ReadBlock()
+		if err == nil && len(blockCols) != 1 {
+			err = fmt.Errorf("block has %d columns, want 1", len(blockCols))
+		}
+		if err == nil {
+			col = blockCols[0]
+		}
+	} else {
+		n, err = fr.ReadBlockAs(into)
+	}
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("native: column %q: block %d: %w", meta.Name, idx, err)
+	}
+	if n != block.Rows {
+		return nil, fmt.Errorf("native: column %q: block %d has %d rows, metadata says %d", meta.Name, idx, n, block.Rows)
+	}
+	br.next[i] = idx + 1
+	return col, nil
+}
+
+// Close closes every open column file. It is safe to call more than once.
+func (br *DirBlockReader) Close() error {
+	if br.closed {
+		return nil
+	}
+	br.closed = true
+	var errs []error
+	for _, fr := range br.readers {
+		errs = append(errs, fr.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,204 @@
+package format
+
+import (
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+	"github.com/vahid-sohrabloo/chconn/v3/types"
+)
+
+func int32Col(name string, vals ...int32) *column.Base[int32] {
+	c := column.New[int32]()
+	c.SetName([]byte(name))
+	c.SetType([]byte("Int32"))
+	c.AppendMulti(vals...)
+	return c
+}
+
+func TestWriteDirStats(t *testing.T) {
+	n := column.New[int64]().Nullable()
+	n.SetName([]byte("n"))
+	n.SetType([]byte("Nullable(Int64)"))
+	n.AppendP(nil)
+	v := int64(-7)
+	n.AppendP(&v)
+	n.AppendP(nil)
+
+	ts := column.NewDate[types.DateTime]()
+	ts.SetName([]byte("ts"))
+	ts.SetType([]byte("DateTime"))
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	ts.Append(day.Add(time.Hour))
+	ts.Append(day)
+	ts.Append(day.Add(2 * time.Hour))
+
+	dir := t.TempDir()
+	if err := WriteDir(dir, []column.ColumnCore{
+		int32Col("id", 5, -3, 9),
+		floatCol("f", math.NaN(), 2.5, -1),
+		n,
+		ts,
+		strCol("s", "a", "b", "c"),
+	}, WithZSTD()); err != nil {
+		t.Fatalf("WriteDir: %v", err)
+	}
+
+	dr, err := OpenDir(dir, WithZSTD())
+	if err != nil {
+		t.Fatalf("OpenDir: %v", err)
+	}
+	blocks := dr.Blocks()
+	if len(blocks) != 1 || blocks[0].Rows != 3 || blocks[0].RowOffset != 0 {
+		t.Fatalf("blocks = %+v", blocks)
+	}
+	want := []ColumnStats{
+		{Min: int64(-3), Max: int64(9)},
+		{Min: -1.0, Max: 2.5},
+		{Min: int64(-7), Max: int64(-7)},
+		{Min: day, Max: day.Add(2 * time.Hour)},
+		{},
+	}
+	for i, w := range want {
+		if got := blocks[0].Stats[i]; got != w {
+			t.Errorf("stats[%d] = %+v, want %+v", i, got, w)
+		}
+	}
+
+	br, err := dr.OpenBlocks([]string{"s"}, Range("ts", day.Add(time.Hour), nil), Range("id", nil, 0))
+	if err != nil {
+		t.Fatalf("OpenBlocks: %v", err)
+	}
+	defer br.Close()
+	numRows, cols, err := br.ReadBlock()
+	if err != nil {
+		t.Fatalf("ReadBlock: %v", err)
+	}
+	if numRows != 3 || len(cols) != 1 || cols[0].(*column.String).Row(2) != "c" {
+		t.Fatalf("got %d rows, %d columns", numRows, len(cols))
+	}
+	if _, _, err := br.ReadBlock(); !errors.Is(err, io.EOF) {
+		t.Fatalf("got %v, want io.EOF", err)
+	}
+
+	br, err = dr.OpenBlocks(nil, Range("f", 3, nil))
+	if err != nil {
+		t.Fatalf("OpenBlocks: %v", err)
+	}
+	defer br.Close()
+	if _, _, err := br.ReadBlock(); !errors.Is(err, io.EOF) {
+		t.Fatalf("got %v, want io.EOF for a skipped block", err)
+	}
+}
+
+func TestDirBlockReaderSkipsBlocks(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{name: "plain"},
+		{name: "zstd", opts: []Option{WithZSTD()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			cols := []column.ColumnCore{
+				int32Col("day", 1, 1, 2, 3, 4, 4, 5, 6),
+				strCol("s", "a", "b", "c", "d", "e", "f", "g", "h"),
+			}
+			if err := WriteDir(dir, cols, append(tc.opts, WithBlockRows(2))...); err != nil {
+				t.Fatalf("WriteDir: %v", err)
+			}
+			if cols[0].NumRow() != 8 {
+				t.Fatalf("WriteDir changed its input: %d rows", cols[0].NumRow())
+			}
+
+			dr, err := OpenDir(dir, tc.opts...)
+			if err != nil {
+				t.Fatalf("OpenDir: %v", err)
+			}
+			blocks := dr.Blocks()
+			if dr.RowCount() != 8 || len(blocks) != 4 || blocks[3].RowOffset != 6 || blocks[3].Rows != 2 {
+				t.Fatalf("RowCount = %d, blocks = %+v", dr.RowCount(), blocks)
+			}
+			if st := blocks[1].Stats[0]; st.Min != int64(2) || st.Max != int64(3) {
+				t.Fatalf("block 1 day stats = %+v", st)
+			}
+			br, err := dr.OpenBlocks([]string{"s"}, Range("day", int64(1), uint8(1)), Range("s", "x", nil))
+			if err == nil {
+				br.Close()
+				t.Fatal("expected an error for a string bound")
+			}
+
+			var got []string
+			for _, pred := range []Predicate{Range("day", 1, 1), Range("day", 3.5, 5)} {
+				br, err := dr.OpenBlocks([]string{"s"}, pred)
+				if err != nil {
+					t.Fatalf("OpenBlocks: %v", err)
+				}
+				for {
+					_, cols, err := br.ReadBlock()
+					if errors.Is(err, io.EOF) {
+						break
+					}
+					if err != nil {
+						t.Fatalf("ReadBlock: %v", err)
+					}
+					s := cols[0].(*column.String)
+					for i := range s.NumRow() {
+						got = append(got, s.Row(i))
+					}
+				}
+				if err := br.Close(); err != nil {
+					t.Fatalf("Close: %v", err)
+				}
+			}
+			if want := "a b e f g h"; strings.Join(got, " ") != want {
+				t.Fatalf("got %q, want %q", strings.Join(got, " "), want)
+			}
+		})
+	}
+}
+
+func TestOpenDirLegacyMetadata(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteDir(dir, []column.ColumnCore{int32Col("id", 1, 2)}); err != nil {
+		t.Fatal(err)
+	}
+	dr, err := OpenDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Rewrite metadata.bin in the CNDM1 layout: no block index.
+	var buf []byte
+	buf = append(buf, dirMagicV1...)
+	buf = append(buf, 2, 1)
+	for _, s := range []string{"id", "Int32", dr.Columns()[0].file} {
+		buf = append(buf, byte(len(s)))
+		buf = append(buf, s...)
+	}
+	if err := os.WriteFile(filepath.Join(dir, dirMetaFile), buf, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dr, err = OpenDir(dir)
+	if err != nil {
+		t.Fatalf("OpenDir: %v", err)
+	}
+	if len(dr.Blocks()) != 1 || dr.Blocks()[0].Rows != 2 || dr.Blocks()[0].Stats[0].Min != nil {
+		t.Fatalf("blocks = %+v", dr.Blocks())
+	}
+	br, err := dr.OpenBlocks(nil, Range("id", 10, nil))
+	if err != nil {
+		t.Fatalf("OpenBlocks: %v", err)
+	}
+	defer br.Close()
+	if numRows, _, err := br.ReadBlock(); err != nil || numRows != 2 {
+		t.Fatalf("ReadBlock: %d, %v", numRows, err)
+	}
+}
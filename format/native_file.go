@@ -17,13 +17,18 @@ type FileWriter struct {
 	nw            *NativeWriter
 	codec         *Codec
 	headerWritten bool
+	blockIndex    bool
+	offset        int64 // bytes written so far, for the block index
+	index         []indexEntry
+	finished      bool
 }
 
 // NewFileWriter wraps w. WriteBlock may be called repeatedly (multiple blocks).
-// Pass WithZSTD()/WithLZ4()/WithCodec() to compress each block as a unit.
+// Pass WithZSTD()/WithLZ4()/WithCodec() to compress each block as a unit, and
+// WithBlockIndex() to end a compressed file with a block index on Finish.
 func NewFileWriter(w io.Writer, opts ...Option) *FileWriter {
 	cfg := resolve(opts)
-	fw := &FileWriter{w: w, codec: cfg.codec}
+	fw := &FileWriter{w: w, codec: cfg.codec, blockIndex: cfg.blockIndex}
 	if cfg.codec == nil {
 		fw.nw = NewNativeWriter(w)
 	}
@@ -32,33 +37,79 @@ func NewFileWriter(w io.Writer, opts ...Option) *FileWriter {
 
 // WriteBlock writes one block; all columns must have equal NumRow.
 func (fw *FileWriter) WriteBlock(cols ...column.ColumnCore) error {
+	if fw.finished {
+		return errors.New("native: write to finished FileWriter")
+	}
 	if fw.codec == nil {
+		if fw.blockIndex {
+			return errBlockIndexCodec
+		}
 		return fw.nw.WriteBlock(cols...)
 	}
-	if !fw.headerWritten {
-		if err := writeCompressedHeader(fw.w, fw.codec.Name); err != nil {
-			return err
-		}
-		fw.headerWritten = true
+	if err := fw.writeHeader(); err != nil {
+		return err
 	}
 	var buf bytes.Buffer
 	if err := NewNativeWriter(&buf).WriteBlock(cols...); err != nil {
 		return err
 	}
-	return writeCompressedUnit(fw.w, fw.codec, buf.Bytes())
+	n, sum, err := writeCompressedUnit(fw.w, fw.codec, buf.Bytes())
+	if err != nil {
+		return err
+	}
+	if fw.blockIndex {
+		rows := 0
+		if len(cols) > 0 {
+			rows = cols[0].NumRow()
+		}
+		fw.index = append(fw.index, indexEntry{offset: fw.offset, rows: rows, sum: sum})
+	}
+	fw.offset += n
+	return nil
+}
+
+// writeHeader writes the compressed file header once.
+func (fw *FileWriter) writeHeader() error {
+	if fw.headerWritten {
+		return nil
+	}
+	if err := writeCompressedHeader(fw.w, fw.codec.Name); err != nil {
+		return err
+	}
+	fw.headerWritten = true
+	fw.offset = compressedHeaderSize(fw.codec.Name)
+	return nil
+}
+
+// Finish writes the trailing block index if the writer was created with
+// WithBlockIndex, and is a no-op otherwise. Call it once after the last
+// WriteBlock; it does not close the underlying writer.
+func (fw *FileWriter) Finish() error {
+	if !fw.blockIndex || fw.finished {
+		return nil
+	}
+	if fw.codec == nil {
+		return errBlockIndexCodec
+	}
+	fw.finished = true
+	if err := fw.writeHeader(); err != nil {
+		return err
+	}
+	_, err := fw.w.Write(appendBlockIndex(nil, fw.offset, fw.index))
+	return err
 }
 
 // WriteFile writes cols as a single-block Native file at path.
 // The write is atomic: data goes to path+".tmp" and is renamed to path only on
 // full success. Any error removes the temp file before returning.
-// Pass WithZSTD()/WithLZ4()/WithCodec() to write a compressed file.
+// Pass WithZSTD()/WithLZ4()/WithCodec() to write a compressed file, and
+// WithBlockIndex() to end it with a block index.
 func WriteFile(path string, cols []column.ColumnCore, opts ...Option) error {
 	for _, col := range cols {
 		if len(col.Type()) == 0 {
 			return fmt.Errorf("native: column %q has no type; call SetType before writing", col.Name())
 		}
 	}
-	cfg := resolve(opts)
 	dir := filepath.Dir(path)
 	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
 	if err != nil {
@@ -70,21 +121,12 @@ func WriteFile(path string, cols []column.ColumnCore, opts ...Option) error {
 		os.Remove(tmp)
 		return err
 	}
-	if cfg.codec == nil {
-		if err := NewNativeWriter(f).WriteBlock(cols...); err != nil {
-			return fail(err)
-		}
-	} else {
-		if err := writeCompressedHeader(f, cfg.codec.Name); err != nil {
-			return fail(err)
-		}
-		var buf bytes.Buffer
-		if err := NewNativeWriter(&buf).WriteBlock(cols...); err != nil {
-			return fail(err)
-		}
-		if err := writeCompressedUnit(f, cfg.codec, buf.Bytes()); err != nil {
-			return fail(err)
-		}
+	fw := NewFileWriter(f, opts...)
+	if err := fw.WriteBlock(cols...); err != nil {
+		return fail(err)
+	}
+	if err := fw.Finish(); err != nil {
+		return fail(err)
 	}
 	if err := f.Close(); err != nil {
 		os.Remove(tmp)
@@ -104,17 +146,28 @@ type FileReader struct {
 	nr         *NativeReader
 	codec      *Codec
 	headerRead bool
+	index      *fileIndex // loaded on first use by the block index methods
+	workers    int        // pipeline workers; 0 reads units one by one
+	pipe       *blockPipeline
 }
 
 // OpenFile opens a Native file for streaming reads.
 // Pass WithZSTD()/WithLZ4()/WithCodec() to read a compressed file.
+// With a codec, WithConcurrency(n) pipelines the reads: units are read ahead
+// and decompressed, and for ReadBlock decoded, on n worker goroutines, while
+// blocks are still returned in file order. Uncompressed files are always read
+// sequentially.
 func OpenFile(path string, opts ...Option) (*FileReader, error) {
 	cfg := resolve(opts)
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
-	return &FileReader{f: f, r: f, nr: NewNativeReader(), codec: cfg.codec}, nil
+	fr := &FileReader{f: f, r: f, nr: NewNativeReader(), codec: cfg.codec}
+	if cfg.codec != nil && cfg.concurrencySet {
+		fr.workers = cfg.workers()
+	}
+	return fr, nil
 }
 
 // newReader is a test/helper constructor over an arbitrary io.Reader.
@@ -125,6 +178,10 @@ func newReader(r io.Reader) *FileReader {
 // readUnit reads (and on first call validates the header of) the next compressed
 // unit, returning its decompressed plaintext. Only valid when codec != nil.
 func (fr *FileReader) readUnit() ([]byte, error) {
+	if fr.workers > 0 {
+		res, err := fr.nextUnit(false)
+		return res.plain, err
+	}
 	if !fr.headerRead {
 		if err := readCompressedHeader(fr.r, fr.codec.Name); err != nil {
 			return nil, err
@@ -134,6 +191,24 @@ func (fr *FileReader) readUnit() ([]byte, error) {
 	return readCompressedUnit(fr.r, fr.codec)
 }
 
+// seekOffset positions a file-backed reader at the block (or compressed unit)
+// starting at byte offset off, validating the compressed header first if it
+// has not been read yet.
+func (fr *FileReader) seekOffset(off int64) error {
+	if fr.f == nil {
+		return errors.New("native: seek on a reader without a file")
+	}
+	fr.stopPipeline()
+	if fr.codec != nil && !fr.headerRead {
+		if err := readCompressedHeader(fr.r, fr.codec.Name); err != nil {
+			return err
+		}
+		fr.headerRead = true
+	}
+	_, err := fr.f.Seek(off, io.SeekStart)
+	return err
+}
+
 // ReadBlock reads the next block, building columns from the file's type strings.
 // Returns io.EOF when no blocks remain.
 func (fr *FileReader) ReadBlock() (int, []column.ColumnCore, error) {
@@ -148,7 +223,18 @@ func (fr *FileReader) ReadBlock() (int, []column.ColumnCore, error) {
 			return 0, nil, err
 		}
 	} else {
-		plain, err := fr.readUnit()
+		var plain []byte
+		var err error
+		if fr.workers > 0 {
+			var res unitResult
+			res, err = fr.nextUnit(true)
+			if err == nil && res.decoded {
+				return res.rows, res.cols, nil
+			}
+			plain = res.plain
+		} else {
+			plain, err = fr.readUnit()
+		}
 		if errors.Is(err, io.EOF) {
 			return 0, nil, io.EOF
 		}
@@ -199,8 +285,33 @@ func (fr *FileReader) ReadBlockInto(cols ...column.ColumnCore) (int, error) {
 	return numRows, nil
 }
 
-// Close closes the underlying file (nil for reader-backed instances).
+// ReadBlockAs reads the next block and projects it onto caller-provided
+// columns, adapting to schema changes as NativeReader.ReadBlockAs does: missing
+// columns are filled with defaults, compatible types are widened and extra
+// columns are dropped. Returns io.EOF when no blocks remain.
+func (fr *FileReader) ReadBlockAs(cols ...column.ColumnCore) (int, error) {
+	var r io.Reader = fr.r
+	if fr.codec != nil {
+		plain, err := fr.readUnit()
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		r = bytes.NewReader(plain)
+	}
+	numRows, err := fr.nr.ReadBlockAs(r, nil, cols...)
+	if fr.codec == nil && errors.Is(err, io.EOF) {
+		return 0, io.EOF
+	}
+	return numRows, err
+}
+
+// Close stops any read-ahead and closes the underlying file (nil for
+// reader-backed instances).
 func (fr *FileReader) Close() error {
+	fr.stopPipeline()
 	if fr.f == nil {
 		return nil
 	}
@@ -0,0 +1,145 @@
+package format
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+// unitResult is one compressed unit after a pipeline worker has processed it.
+type unitResult struct {
+	plain   []byte
+	rows    int
+	cols    []column.ColumnCore
+	decoded bool // cols holds the decoded block
+	err     error
+}
+
+// unitJob is one compressed unit waiting for a pipeline worker.
+type unitJob struct {
+	comp      []byte
+	uncompLen uint64
+	out       chan unitResult
+}
+
+// blockPipeline reads compressed units ahead of the caller and decompresses
+// (and, for ReadBlock, decodes) them on worker goroutines. Results are handed
+// out in file order through order, which holds one buffered result channel per
+// unit in flight and so bounds the read-ahead.
+type blockPipeline struct {
+	order chan chan unitResult
+	done  chan struct{}
+	wg    sync.WaitGroup
+	err   error // sticky error returned once the pipeline failed
+}
+
+// startPipeline starts reading units from the current position of fr.r with
+// the given number of workers. decode makes workers build columns as well.
+func (fr *FileReader) startPipeline(workers int, decode bool) *blockPipeline {
+	p := &blockPipeline{
+		order: make(chan chan unitResult, 2*workers),
+		done:  make(chan struct{}),
+	}
+	jobs := make(chan unitJob, workers)
+
+	p.wg.Add(1 + workers)
+	go func() {
+		defer p.wg.Done()
+		defer close(p.order)
+		defer close(jobs)
+		// The pipeline owns the read position until it is stopped, and every
+		// stop is followed by a seek, so reading ahead through a buffer is safe.
+		r := bufio.NewReaderSize(fr.r, 1<<16)
+		for {
+			comp, uncompLen, err := readRawUnit(r)
+			out := make(chan unitResult, 1)
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return
+				}
+				out <- unitResult{err: err}
+				select {
+				case p.order <- out:
+				case <-p.done:
+				}
+				return
+			}
+			select {
+			case p.order <- out:
+			case <-p.done:
+				return
+			}
+			select {
+			case jobs <- unitJob{comp: comp, uncompLen: uncompLen, out: out}:
+			case <-p.done:
+				return
+			}
+		}
+	}()
+	for range workers {
+		go func() {
+			defer p.wg.Done()
+			for job := range jobs {
+				var res unitResult
+				res.plain, res.err = decompressUnit(fr.codec, job.comp, job.uncompLen)
+				if res.err == nil && decode {
+					res.rows, res.cols, _, res.err = readBlockFromBytes(res.plain, nil)
+					res.decoded = true
+				}
+				job.out <- res
+			}
+		}()
+	}
+	return p
+}
+
+// next returns the next unit in file order, or io.EOF after the last one.
+func (p *blockPipeline) next() (unitResult, error) {
+	if p.err != nil {
+		return unitResult{}, p.err
+	}
+	out, ok := <-p.order
+	if !ok {
+		return unitResult{}, io.EOF
+	}
+	res := <-out
+	if res.err != nil {
+		p.err = res.err
+		return unitResult{}, res.err
+	}
+	return res, nil
+}
+
+// stop cancels the read-ahead and waits for every goroutine to exit. The read
+// position of the underlying reader is undefined afterwards.
+func (p *blockPipeline) stop() {
+	close(p.done)
+	p.wg.Wait()
+}
+
+// nextUnit returns the next unit through the pipeline, starting it on first
+// use. decode is honored only when the pipeline starts: a unit that was not
+// decoded carries its plaintext for the caller to decode.
+func (fr *FileReader) nextUnit(decode bool) (unitResult, error) {
+	if fr.pipe == nil {
+		if !fr.headerRead {
+			if err := readCompressedHeader(fr.r, fr.codec.Name); err != nil {
+				return unitResult{}, err
+			}
+			fr.headerRead = true
+		}
+		fr.pipe = fr.startPipeline(fr.workers, decode)
+	}
+	return fr.pipe.next()
+}
+
+// stopPipeline stops a running pipeline, if any.
+func (fr *FileReader) stopPipeline() {
+	if fr.pipe != nil {
+		fr.pipe.stop()
+		fr.pipe = nil
+	}
+}
@@ -0,0 +1,140 @@
+package format
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+// writeSeqFile writes n single-row blocks holding 0..n-1, with a block index.
+func writeSeqFile(t *testing.T, n int, opts ...Option) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "seq.native")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw := NewFileWriter(f, append(opts, WithBlockIndex())...)
+	for i := range n {
+		if err := fw.WriteBlock(floatCol("v", float64(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fw.Finish(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFileReaderPipeline(t *testing.T) {
+	const blocks = 50
+	for _, tc := range []struct {
+		name string
+		opt  Option
+	}{
+		{name: "zstd", opt: WithZSTD()},
+		{name: "lz4", opt: WithLZ4()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writeSeqFile(t, blocks, tc.opt)
+
+			fr, err := OpenFile(path, tc.opt, WithConcurrency(4))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer fr.Close()
+			for i := range blocks {
+				numRows, cols, err := fr.ReadBlock()
+				if err != nil {
+					t.Fatalf("block %d: %v", i, err)
+				}
+				if v := cols[0].(*column.Base[float64]).Row(0); numRows != 1 || v != float64(i) {
+					t.Fatalf("block %d: got %v (%d rows)", i, v, numRows)
+				}
+			}
+			if _, _, err := fr.ReadBlock(); !errors.Is(err, io.EOF) {
+				t.Fatalf("got %v, want io.EOF", err)
+			}
+
+			// Seeking restarts the pipeline at the new position.
+			if err := fr.SeekBlock(40); err != nil {
+				t.Fatal(err)
+			}
+			col := floatCol("v")
+			for i := 40; i < blocks; i++ {
+				if _, err := fr.ReadBlockInto(col); err != nil {
+					t.Fatalf("ReadBlockInto block %d: %v", i, err)
+				}
+				if v := col.Row(0); v != float64(i) {
+					t.Fatalf("ReadBlockInto block %d: got %v", i, v)
+				}
+			}
+			if _, err := fr.ReadBlockInto(col); !errors.Is(err, io.EOF) {
+				t.Fatalf("got %v, want io.EOF", err)
+			}
+			if err := fr.Verify(); err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+		})
+	}
+}
+
+func TestFileReaderPipelineErrors(t *testing.T) {
+	path := writeSeqFile(t, 20, WithZSTD())
+	fr, err := OpenFile(path, WithZSTD())
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx, err := fr.loadIndex()
+	fr.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Break the length prefix of unit 10 so it runs past the end of the file.
+	data[idx.entries[10].offset] = 0xff
+	data[idx.entries[10].offset+1] = 0x7f
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fr, err = OpenFile(path, WithZSTD(), WithConcurrency(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fr.Close()
+	for i := range 10 {
+		if _, _, err := fr.ReadBlock(); err != nil {
+			t.Fatalf("block %d: %v", i, err)
+		}
+	}
+	for range 2 {
+		if _, _, err := fr.ReadBlock(); err == nil || errors.Is(err, io.EOF) || !strings.Contains(err.Error(), "native:") {
+			t.Fatalf("got %v, want a read error", err)
+		}
+	}
+
+	// Closing with units still in flight stops the pipeline.
+	other := writeSeqFile(t, 20, WithZSTD())
+	fr2, err := OpenFile(other, WithZSTD(), WithConcurrency(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := fr2.ReadBlock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := fr2.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
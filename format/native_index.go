@@ -0,0 +1,266 @@
+package format
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-faster/city"
+)
+
+// blockIndexMagic ends a compressed native file that carries a block index.
+var blockIndexMagic = [4]byte{'C', 'N', 'X', '1'}
+
+// blockIndexFooterSize is the fixed size of the footer that locates the index:
+// an 8-byte offset followed by blockIndexMagic.
+const blockIndexFooterSize = 12
+
+var errBlockIndexCodec = errors.New("native: WithBlockIndex requires a codec (WithZSTD/WithLZ4/WithCodec)")
+
+// indexEntry is one compressed unit in a block index.
+type indexEntry struct {
+	offset int64
+	rows   int
+	sum    city.U128 // CityHash128 of the unit's compressed bytes
+}
+
+// fileIndex is a decoded block index.
+type fileIndex struct {
+	entries    []indexEntry
+	rowOffsets []int // first row of every block
+	end        int64 // offset of the end-of-units marker
+}
+
+// appendBlockIndex encodes the trailing block index for units ending at byte
+// offset end. Format:
+//
+//	uvarint  0, uvarint 0   end-of-units marker
+//	uvarint  numBlocks
+//	repeated numBlocks:
+//	  uvarint  unit offset
+//	  uvarint  rows
+//	  16 bytes CityHash128 of the compressed bytes (low, high; little-endian)
+//	8 bytes  little-endian offset of the marker
+//	4 bytes  magic "CNX1"
+func appendBlockIndex(buf []byte, end int64, entries []indexEntry) []byte {
+	buf = append(buf, 0, 0)
+	buf = binary.AppendUvarint(buf, uint64(len(entries)))
+	for _, e := range entries {
+		buf = binary.AppendUvarint(buf, uint64(e.offset))
+		buf = binary.AppendUvarint(buf, uint64(e.rows))
+		buf = binary.LittleEndian.AppendUint64(buf, e.sum.Low)
+		buf = binary.LittleEndian.AppendUint64(buf, e.sum.High)
+	}
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(end))
+	return append(buf, blockIndexMagic[:]...)
+}
+
+// loadIndex reads and validates the block index at the end of the file, once.
+// It reads with ReadAt, so the current read position is unchanged.
+func (fr *FileReader) loadIndex() (*fileIndex, error) {
+	if fr.index != nil {
+		return fr.index, nil
+	}
+	if fr.f == nil || fr.codec == nil {
+		return nil, errors.New("native: block index requires a compressed file opened with OpenFile")
+	}
+	info, err := fr.f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	noIndex := fmt.Errorf("native: %s has no block index (written without WithBlockIndex, or truncated)", fr.f.Name())
+	first := compressedHeaderSize(fr.codec.Name)
+	if size < first+2+blockIndexFooterSize {
+		return nil, noIndex
+	}
+	var footer [blockIndexFooterSize]byte
+	if _, err := fr.f.ReadAt(footer[:], size-blockIndexFooterSize); err != nil {
+		return nil, err
+	}
+	if [4]byte(footer[8:]) != blockIndexMagic {
+		return nil, noIndex
+	}
+	end := int64(binary.LittleEndian.Uint64(footer[:8]))
+	if end < first || end > size-blockIndexFooterSize-2 {
+		return nil, fmt.Errorf("native: block index offset %d out of range", end)
+	}
+	data := make([]byte, size-blockIndexFooterSize-end)
+	if _, err := fr.f.ReadAt(data, end); err != nil {
+		return nil, err
+	}
+
+	r := bytes.NewReader(data)
+	if marker, err := readUvarint(r); err != nil || marker != 0 {
+		return nil, errors.New("native: block index: missing end-of-units marker")
+	}
+	if marker, err := readUvarint(r); err != nil || marker != 0 {
+		return nil, errors.New("native: block index: missing end-of-units marker")
+	}
+	n, err := readUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("native: block index: read count: %w", err)
+	}
+	// Every entry takes at least 18 bytes.
+	if n > uint64(len(data))/18 {
+		return nil, fmt.Errorf("native: block index: count %d exceeds index size", n)
+	}
+	idx := &fileIndex{entries: make([]indexEntry, n), rowOffsets: make([]int, n), end: end}
+	rows := 0
+	for i := range idx.entries {
+		off, err := readUvarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("native: block index: entry %d offset: %w", i, err)
+		}
+		n, err := readUvarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("native: block index: entry %d rows: %w", i, err)
+		}
+		var sum [16]byte
+		if _, err := io.ReadFull(r, sum[:]); err != nil {
+			return nil, fmt.Errorf("native: block index: entry %d checksum: %w", i, err)
+		}
+		minOff := uint64(first)
+		if i > 0 {
+			minOff = uint64(idx.entries[i-1].offset) + 1
+		}
+		if off < minOff || off >= uint64(end) || n > maxCompressedUnit {
+			return nil, fmt.Errorf("native: block index: entry %d out of range", i)
+		}
+		idx.entries[i] = indexEntry{
+			offset: int64(off),
+			rows:   int(n),
+			sum:    city.U128{Low: binary.LittleEndian.Uint64(sum[:8]), High: binary.LittleEndian.Uint64(sum[8:])},
+		}
+		idx.rowOffsets[i] = rows
+		rows += int(n)
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("native: block index: %d trailing bytes", r.Len())
+	}
+	fr.index = idx
+	return idx, nil
+}
+
+// FileBlock describes one block of a Native file, as recorded in its block index.
+type FileBlock struct {
+	RowOffset int // index of the block's first row within the file
+	Rows      int
+}
+
+// Blocks returns the file's block index. The file must have been written with
+// WithBlockIndex.
+func (fr *FileReader) Blocks() ([]FileBlock, error) {
+	idx, err := fr.loadIndex()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]FileBlock, len(idx.entries))
+	for i, e := range idx.entries {
+		out[i] = FileBlock{RowOffset: idx.rowOffsets[i], Rows: e.rows}
+	}
+	return out, nil
+}
+
+// SeekBlock positions the reader so the next ReadBlock returns block n
+// (0-based) without decompressing the blocks before it. n equal to the block
+// count positions at the end, so the next read returns io.EOF. Several readers
+// of the same file can each seek to their own blocks to read in parallel.
+// The file must have been written with WithBlockIndex.
+func (fr *FileReader) SeekBlock(n int) error {
+	idx, err := fr.loadIndex()
+	if err != nil {
+		return err
+	}
+	if n < 0 || n > len(idx.entries) {
+		return fmt.Errorf("native: block %d out of range [0, %d]", n, len(idx.entries))
+	}
+	if n == len(idx.entries) {
+		return fr.seekOffset(idx.end)
+	}
+	return fr.seekOffset(idx.entries[n].offset)
+}
+
+// SeekRow positions the reader at the block holding row (0-based, counted over
+// the whole file) and returns the row's index within that block.
+// The file must have been written with WithBlockIndex.
+func (fr *FileReader) SeekRow(row int) (int, error) {
+	idx, err := fr.loadIndex()
+	if err != nil {
+		return 0, err
+	}
+	n := sort.Search(len(idx.entries), func(i int) bool {
+		return idx.rowOffsets[i]+idx.entries[i].rows > row
+	})
+	if row < 0 || n == len(idx.entries) {
+		return 0, fmt.Errorf("native: row %d out of range", row)
+	}
+	if err := fr.seekOffset(idx.entries[n].offset); err != nil {
+		return 0, err
+	}
+	return row - idx.rowOffsets[n], nil
+}
+
+// Verify checks the whole file against its block index: every unit starts at
+// its recorded offset, matches its CityHash128 checksum, decompresses to its
+// recorded length and decodes to its recorded row count, and the units end
+// where the index begins. A truncated file fails because its index footer is
+// gone. On success the reader is positioned at the first block.
+// The file must have been written with WithBlockIndex.
+func (fr *FileReader) Verify() error {
+	idx, err := fr.loadIndex()
+	if err != nil {
+		return err
+	}
+	first := compressedHeaderSize(fr.codec.Name)
+	if err := fr.seekOffset(first); err != nil {
+		return err
+	}
+	cr := &countingReader{r: bufio.NewReader(fr.f), n: first}
+	for i, e := range idx.entries {
+		if cr.n != e.offset {
+			return fmt.Errorf("native: verify block %d: at offset %d, index says %d", i, cr.n, e.offset)
+		}
+		comp, uncompLen, err := readRawUnit(cr)
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return fmt.Errorf("native: verify block %d: %w", i, err)
+		}
+		if city.CH128(comp) != e.sum {
+			return fmt.Errorf("native: verify block %d: checksum mismatch", i)
+		}
+		plain, err := decompressUnit(fr.codec, comp, uncompLen)
+		if err != nil {
+			return fmt.Errorf("native: verify block %d: %w", i, err)
+		}
+		rows, _, _, err := readBlockFromBytes(plain, nil)
+		if err != nil {
+			return fmt.Errorf("native: verify block %d: %w", i, err)
+		}
+		if rows != e.rows {
+			return fmt.Errorf("native: verify block %d: %d rows, index says %d", i, rows, e.rows)
+		}
+	}
+	if cr.n != idx.end {
+		return fmt.Errorf("native: verify: units end at offset %d, index starts at %d", cr.n, idx.end)
+	}
+	return fr.seekOffset(first)
+}
+
+// countingReader tracks the offset reached in the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
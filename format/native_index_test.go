@@ -0,0 +1,189 @@
+package format
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+// writeIndexedFile writes blocks of one Float64 column "v" with a block index.
+func writeIndexedFile(t *testing.T, blocks ...[]float64) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "indexed.native")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw := NewFileWriter(f, WithZSTD(), WithBlockIndex())
+	for _, vals := range blocks {
+		if err := fw.WriteBlock(floatCol("v", vals...)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fw.Finish(); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if err := fw.WriteBlock(floatCol("v", 1)); err == nil {
+		t.Fatal("expected an error writing after Finish")
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFileBlockIndex(t *testing.T) {
+	path := writeIndexedFile(t, []float64{1, 2}, []float64{3}, []float64{4, 5, 6})
+
+	fr, err := OpenFile(path, WithZSTD())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fr.Close()
+
+	// Sequential reads stop at the index.
+	var got []float64
+	for {
+		_, cols, err := fr.ReadBlock()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ReadBlock: %v", err)
+		}
+		got = append(got, cols[0].(*column.Base[float64]).Data()...)
+	}
+	if len(got) != 6 || got[5] != 6 {
+		t.Fatalf("got %v", got)
+	}
+
+	blocks, err := fr.Blocks()
+	if err != nil {
+		t.Fatalf("Blocks: %v", err)
+	}
+	if len(blocks) != 3 || blocks[2] != (FileBlock{RowOffset: 3, Rows: 3}) {
+		t.Fatalf("blocks = %+v", blocks)
+	}
+
+	if err := fr.SeekBlock(1); err != nil {
+		t.Fatalf("SeekBlock: %v", err)
+	}
+	if _, cols, err := fr.ReadBlock(); err != nil || cols[0].(*column.Base[float64]).Row(0) != 3 {
+		t.Fatalf("ReadBlock after SeekBlock(1): %v", err)
+	}
+	inBlock, err := fr.SeekRow(4)
+	if err != nil || inBlock != 1 {
+		t.Fatalf("SeekRow(4) = %d, %v", inBlock, err)
+	}
+	if _, cols, err := fr.ReadBlock(); err != nil || cols[0].(*column.Base[float64]).Row(inBlock) != 5 {
+		t.Fatalf("ReadBlock after SeekRow: %v", err)
+	}
+	if _, err := fr.SeekRow(6); err == nil {
+		t.Fatal("expected an error for a row past the end")
+	}
+	if err := fr.SeekBlock(3); err != nil {
+		t.Fatalf("SeekBlock(3): %v", err)
+	}
+	if _, _, err := fr.ReadBlock(); !errors.Is(err, io.EOF) {
+		t.Fatalf("got %v, want io.EOF", err)
+	}
+
+	if err := fr.Verify(); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if _, cols, err := fr.ReadBlock(); err != nil || cols[0].(*column.Base[float64]).Row(0) != 1 {
+		t.Fatalf("ReadBlock after Verify: %v", err)
+	}
+}
+
+func TestFileBlockIndexVerifyCorruption(t *testing.T) {
+	path := writeIndexedFile(t, []float64{1, 2}, []float64{3})
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verify := func(b []byte) error {
+		p := filepath.Join(t.TempDir(), "bad.native")
+		if err := os.WriteFile(p, b, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		fr, err := OpenFile(p, WithZSTD())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer fr.Close()
+		return fr.Verify()
+	}
+
+	if err := verify(data[:len(data)-5]); err == nil || !strings.Contains(err.Error(), "no block index") {
+		t.Fatalf("truncated: got %v", err)
+	}
+	fr, err := OpenFile(path, WithZSTD())
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx, err := fr.loadIndex()
+	fr.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad := append([]byte(nil), data...)
+	bad[idx.entries[1].offset+5] ^= 0xff
+	if err := verify(bad); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("corrupted: got %v", err)
+	}
+}
+
+func TestBlockIndexOptions(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteFile(filepath.Join(dir, "plain.native"), []column.ColumnCore{floatCol("v", 1)}, WithBlockIndex()); err == nil {
+		t.Fatal("expected an error for a block index without a codec")
+	}
+
+	path := filepath.Join(dir, "lz4.native")
+	if err := WriteFile(path, []column.ColumnCore{floatCol("v", 1)}, WithLZ4(), WithBlockIndex()); err != nil {
+		t.Fatal(err)
+	}
+	fr, err := OpenFile(path, WithLZ4())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fr.Close()
+	if err := fr.Verify(); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	noIndex := filepath.Join(dir, "noindex.native")
+	if err := WriteFile(noIndex, []column.ColumnCore{floatCol("v", 1)}, WithLZ4()); err != nil {
+		t.Fatal(err)
+	}
+	fr2, err := OpenFile(noIndex, WithLZ4())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fr2.Close()
+	if err := fr2.SeekBlock(0); err == nil {
+		t.Fatal("expected an error seeking a file without a block index")
+	}
+
+	// Dir-mode column files ignore WithBlockIndex, so appends keep working.
+	ds := filepath.Join(dir, "ds")
+	for range 2 {
+		if err := AppendDir(ds, []column.ColumnCore{floatCol("v", 1, 2)}, WithZSTD(), WithBlockIndex()); err != nil {
+			t.Fatalf("AppendDir: %v", err)
+		}
+	}
+	dr, err := OpenDir(ds, WithZSTD())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dr.RowCount() != 4 {
+		t.Fatalf("RowCount = %d, want 4", dr.RowCount())
+	}
+}
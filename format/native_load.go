@@ -0,0 +1,450 @@
+package format
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/vahid-sohrabloo/chconn/v3"
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+	"github.com/vahid-sohrabloo/chconn/v3/internal/readerwriter"
+	"github.com/vahid-sohrabloo/chconn/v3/shared"
+)
+
+// Inserter starts streaming inserts; chconn.Conn and chpool.Pool implement it.
+type Inserter interface {
+	InsertStream(ctx context.Context, query string) (chconn.InsertStmt, error)
+}
+
+// LoadProgress reports how much of a file or dataset LoadFile or LoadDir has
+// sent to the server.
+type LoadProgress struct {
+	Blocks    int // blocks sent so far
+	Rows      int // rows sent so far
+	TotalRows int // rows to send, or 0 when unknown (files without a block index)
+}
+
+// LoadFile streams the blocks of the Native file at path into the insert
+// query, e.g. "INSERT INTO t VALUES" or "INSERT INTO t (a, b) VALUES", and
+// flushes it. Stored columns are matched by name to the columns of the
+// server's insert header: column data of the same type is forwarded to the
+// server as stored, without decoding it; other columns are decoded and widened
+// as in ReadBlockAs, and stored columns the insert does not take are skipped.
+// Every insert column must be stored in the file; list the columns to load in
+// the query to leave the others to their defaults.
+//
+// Pass the codec option the file was written with; WithConcurrency(n)
+// pipelines the reads as in OpenFile and WithLoadProgress reports progress.
+// On error the insert is aborted, which closes the connection.
+func LoadFile(ctx context.Context, conn Inserter, query, path string, opts ...Option) error {
+	fr, err := OpenFile(path, opts...)
+	if err != nil {
+		return err
+	}
+	defer fr.Close()
+
+	stmt, targets, err := startLoad(ctx, conn, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	progress := LoadProgress{}
+	if blocks, err := fr.Blocks(); err == nil {
+		for _, b := range blocks {
+			progress.TotalRows += b.Rows
+		}
+	}
+	onProgress := resolve(opts).progress
+
+	data := make([][]byte, len(targets))
+	for {
+		numRows, err := readLoadBlock(fr, targets, data)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("native: load %s: %w", path, err)
+		}
+		if numRows == 0 {
+			continue
+		}
+		if err := stmt.WriteEncoded(ctx, numRows, data...); err != nil {
+			return err
+		}
+		progress.Blocks++
+		progress.Rows += numRows
+		if onProgress != nil {
+			onProgress(progress)
+		}
+	}
+	return stmt.Flush(ctx)
+}
+
+// LoadDir streams the blocks of the dir-mode dataset in dir into the insert
+// query, matching columns as LoadFile does. Column data whose stored type is
+// the insert column's type is forwarded to the server as stored, without
+// decoding it; other columns are decoded and widened. Only the column files
+// the insert takes are read.
+//
+// Pass the codec option the dataset was written with; WithLoadProgress
+// reports progress. On error the insert is aborted, which closes the
+// connection.
+func LoadDir(ctx context.Context, conn Inserter, query, dir string, opts ...Option) error {
+	dr, err := OpenDir(dir, opts...)
+	if err != nil {
+		return err
+	}
+	stmt, targets, err := startLoad(ctx, conn, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	files := make([]*os.File, len(targets))
+	sizes := make([]int64, len(targets))
+	metas := make([]ColumnMeta, len(targets))
+	defer func() {
+		for _, f := range files {
+			if f != nil {
+				f.Close()
+			}
+		}
+	}()
+	for i, target := range targets {
+		meta, ok := dr.byName[string(target.Name())]
+		if !ok {
+			return fmt.Errorf("native: load %s: insert column %q is not in the dataset", dir, target.Name())
+		}
+		f, err := os.Open(filepath.Join(dir, meta.file))
+		if err != nil {
+			return err
+		}
+		files[i] = f
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		metas[i], sizes[i] = meta, info.Size()
+	}
+
+	progress := LoadProgress{TotalRows: dr.rowCount}
+	onProgress := resolve(opts).progress
+	codec := resolve(dr.opts).codec
+	if codec != nil {
+		for i, f := range files {
+			if err := readCompressedHeader(io.NewSectionReader(f, 0, sizes[i]), codec.Name); err != nil {
+				return fmt.Errorf("native: load %s: column %q: %w", dir, metas[i].Name, err)
+			}
+		}
+	}
+
+	data := make([][]byte, len(targets))
+	for b, block := range dr.blocks {
+		if block.Rows == 0 {
+			continue
+		}
+		for i, target := range targets {
+			plain, err := dr.readColumnBlock(files[i], sizes[i], codec, metas[i], b)
+			if err != nil {
+				return fmt.Errorf("native: load %s: column %q: block %d: %w", dir, metas[i].Name, b, err)
+			}
+			if metas[i].Type == string(target.Type()) {
+				data[i], err = columnBlockData(plain, metas[i], block.Rows)
+			} else {
+				data[i], err = convertEncoded(target, plain, block.Rows)
+			}
+			if err != nil {
+				return fmt.Errorf("native: load %s: column %q: block %d: %w", dir, metas[i].Name, b, err)
+			}
+		}
+		if err := stmt.WriteEncoded(ctx, block.Rows, data...); err != nil {
+			return err
+		}
+		progress.Blocks++
+		progress.Rows += block.Rows
+		if onProgress != nil {
+			onProgress(progress)
+		}
+	}
+	return stmt.Flush(ctx)
+}
+
+// startLoad starts the insert and returns its statement and header columns.
+func startLoad(ctx context.Context, conn Inserter, query string) (chconn.InsertStmt, []column.ColumnCore, error) {
+	stmt, err := conn.InsertStream(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	if stmt == nil {
+		return nil, nil, fmt.Errorf("native: load: query %q does not take data", query)
+	}
+	targets, err := stmt.Columns()
+	if err != nil {
+		stmt.Close()
+		return nil, nil, err
+	}
+	return stmt, targets, nil
+}
+
+// readLoadBlock reads the next block of fr for LoadFile and sets data[i] to
+// the encoded data of targets[i]. Stored columns whose type is their target's
+// type are split off the block as stored, without decoding them, unless the
+// type has to be decoded to find its end; other targets are decoded and
+// widened. It returns io.EOF when no blocks remain.
+func readLoadBlock(fr *FileReader, targets []column.ColumnCore, data [][]byte) (int, error) {
+	var (
+		plain []byte
+		src   *bytes.Reader
+		rec   *dataRecorder
+		r     *readerwriter.Reader
+	)
+	if fr.codec == nil {
+		rec = &dataRecorder{r: fr.r}
+		r = readerwriter.NewReader(rec)
+	} else {
+		var err error
+		if plain, err = fr.readUnit(); err != nil {
+			return 0, err
+		}
+		src = bytes.NewReader(plain)
+		r = readerwriter.NewReader(src)
+	}
+	skipper := &columnSkipper{r: r, src: src}
+
+	numCols, err := r.Uvarint()
+	if errors.Is(err, io.EOF) && fr.codec == nil {
+		return 0, io.EOF
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read num columns: %w", err)
+	}
+	if numCols > maxColumns {
+		return 0, fmt.Errorf("implausible column count %d", numCols)
+	}
+	numRows, err := r.Uvarint()
+	if err != nil {
+		return 0, fmt.Errorf("read num rows: %w", unexpectedEOF(err))
+	}
+	if numRows > math.MaxInt {
+		return 0, fmt.Errorf("row count %d exceeds int range", numRows)
+	}
+	rows := int(numRows)
+
+	found := make([]bool, len(targets))
+	for range numCols {
+		name, err := r.ByteString()
+		if err != nil {
+			return 0, fmt.Errorf("read column name: %w", unexpectedEOF(err))
+		}
+		chType, err := r.ByteString()
+		if err != nil {
+			return 0, fmt.Errorf("read column type: %w", unexpectedEOF(err))
+		}
+		t := columnIndex(targets, name)
+		same := t >= 0 && bytes.Equal(chType, targets[t].Type())
+		start := len(plain)
+		if src != nil {
+			start -= src.Len()
+		} else if same {
+			rec.buf = new(bytes.Buffer)
+		}
+		var col column.ColumnCore
+		if (t < 0 || same) && skippable(chType) {
+			err = skipper.skip(chType, rows)
+		} else {
+			col, err = readLoadColumn(r, name, chType, rows)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("column %q: %w", name, unexpectedEOF(err))
+		}
+		switch {
+		case t < 0:
+			continue
+		case same && src != nil:
+			data[t] = plain[start : len(plain)-src.Len()]
+		case same:
+			data[t] = rec.buf.Bytes()
+			rec.buf = nil
+		default:
+			if err := convertColumn(targets[t], col); err != nil {
+				return 0, fmt.Errorf("column %q: %w", name, err)
+			}
+			if data[t], err = encodeColumn(targets[t]); err != nil {
+				return 0, fmt.Errorf("column %q: %w", name, err)
+			}
+		}
+		found[t] = true
+	}
+	for i, ok := range found {
+		if !ok {
+			return 0, fmt.Errorf("insert column %q is not in the file", targets[i].Name())
+		}
+	}
+	return rows, nil
+}
+
+// readLoadColumn decodes a stored column of a block read by readLoadBlock.
+func readLoadColumn(r *readerwriter.Reader, name, chType []byte, rows int) (column.ColumnCore, error) {
+	col, err := column.ColumnByType(chType, 0, false, false, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := col.SetColumnHeader(column.ColumnHeader{Name: name, ChType: chType}); err != nil {
+		return nil, err
+	}
+	if err := col.ReadHeader(r, shared.EmptyServerInfo()); err != nil {
+		return nil, err
+	}
+	if rows > 0 {
+		if err := col.ReadRaw(rows); err != nil {
+			return nil, err
+		}
+	}
+	return col, nil
+}
+
+// unexpectedEOF turns io.EOF inside a block into io.ErrUnexpectedEOF, so it is
+// not taken for the end of the file.
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// dataRecorder copies the bytes read through it to buf while buf is set.
+type dataRecorder struct {
+	r   io.Reader
+	buf *bytes.Buffer
+}
+
+func (d *dataRecorder) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	if d.buf != nil {
+		d.buf.Write(p[:n])
+	}
+	return n, err
+}
+
+// columnIndex returns the index of the column called name in cols, or -1.
+func columnIndex(cols []column.ColumnCore, name []byte) int {
+	for i, col := range cols {
+		if bytes.Equal(col.Name(), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// readColumnBlock returns block b of the column file f (of the given size) as
+// an uncompressed single-column Native block. A compressed file's header must
+// have been checked already.
+func (dr *DirReader) readColumnBlock(f *os.File, size int64, codec *Codec, meta ColumnMeta, b int) ([]byte, error) {
+	// Metadata written before the block index was recorded has one block
+	// starting right after the (optional) file header.
+	off := int64(0)
+	if offsets := dr.blocks[b].offsets; offsets != nil {
+		off = offsets[meta.Index]
+	} else if codec != nil {
+		off = compressedHeaderSize(codec.Name)
+	}
+	if off > size {
+		return nil, fmt.Errorf("offset %d past the end of the file", off)
+	}
+	if codec != nil {
+		comp, uncompLen, err := readRawUnit(io.NewSectionReader(f, off, size-off))
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		return decompressUnit(codec, comp, uncompLen)
+	}
+	// The last block ends at the committed size of the file: bytes past it
+	// belong to no block.
+	end := size
+	if b+1 < len(dr.blocks) {
+		end = dr.blocks[b+1].offsets[meta.Index]
+	} else if meta.size >= 0 {
+		end = meta.size
+	}
+	if end < off {
+		return nil, fmt.Errorf("block ends at offset %d before it starts at %d", end, off)
+	}
+	if end > size {
+		return nil, fmt.Errorf("block ends at offset %d past the end of the file", end)
+	}
+	plain := make([]byte, end-off)
+	if _, err := f.ReadAt(plain, off); err != nil {
+		return nil, err
+	}
+	return plain, nil
+}
+
+// columnBlockData strips the block and column header from a single-column
+// Native block, returning the column's encoded data.
+func columnBlockData(plain []byte, meta ColumnMeta, rows int) ([]byte, error) {
+	br := &BytesReader{data: plain}
+	numCols, err := br.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	numRows, err := br.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if numCols != 1 || numRows != uint64(rows) {
+		return nil, fmt.Errorf("block has %d columns and %d rows, want 1 and %d", numCols, numRows, rows)
+	}
+	name, err := br.bstring()
+	if err != nil {
+		return nil, err
+	}
+	chType, err := br.bstring()
+	if err != nil {
+		return nil, err
+	}
+	if string(name) != meta.Name || string(chType) != meta.Type {
+		return nil, fmt.Errorf("block holds %q %s, want %q %s", name, chType, meta.Name, meta.Type)
+	}
+	return plain[br.off:], nil
+}
+
+// convertEncoded decodes a single-column Native block, widens it into target
+// and returns target's encoded data.
+func convertEncoded(target column.ColumnCore, plain []byte, rows int) ([]byte, error) {
+	numRows, cols, _, err := readBlockFromBytes(plain, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(cols) != 1 || numRows != rows {
+		return nil, fmt.Errorf("block has %d columns and %d rows, want 1 and %d", len(cols), numRows, rows)
+	}
+	if err := convertColumn(target, cols[0]); err != nil {
+		return nil, err
+	}
+	return encodeColumn(target)
+}
+
+// encodeColumn returns the encoded data of col, as InsertStmt.WriteEncoded
+// takes it, and resets col.
+func encodeColumn(col column.ColumnCore) ([]byte, error) {
+	defer col.Reset()
+	var buf bytes.Buffer
+	w := readerwriter.NewWriter()
+	col.HeaderWriter(w)
+	if _, err := w.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	if _, err := col.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
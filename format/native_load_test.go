@@ -0,0 +1,212 @@
+package format
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vahid-sohrabloo/chconn/v3"
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+// fakeInserter records the blocks a load sends, rendered as rows of
+// comma-separated JSON values.
+type fakeInserter struct {
+	t       *testing.T
+	header  []string
+	rows    []string
+	encoded int // blocks sent with WriteEncoded
+	flushed bool
+}
+
+func (f *fakeInserter) InsertStream(context.Context, string) (chconn.InsertStmt, error) {
+	return &fakeInsertStmt{f: f}, nil
+}
+
+type fakeInsertStmt struct {
+	chconn.InsertStmt
+	f    *fakeInserter
+	cols []column.ColumnCore
+}
+
+func (s *fakeInsertStmt) Columns() ([]column.ColumnCore, error) {
+	if s.cols == nil {
+		s.cols = typedColumns(s.f.t, s.f.header...)
+	}
+	return s.cols, nil
+}
+
+func (s *fakeInsertStmt) Write(_ context.Context, cols ...column.ColumnCore) error {
+	for i, col := range cols {
+		if !bytes.Equal(col.Name(), s.cols[i].Name()) {
+			s.f.t.Fatalf("column %d is %q, want %q", i, col.Name(), s.cols[i].Name())
+		}
+	}
+	s.record(cols)
+	return nil
+}
+
+// WriteEncoded rebuilds a Native block from the header and decodes it.
+func (s *fakeInsertStmt) WriteEncoded(_ context.Context, numRows int, data ...[]byte) error {
+	block := binary.AppendUvarint(nil, uint64(len(data)))
+	block = binary.AppendUvarint(block, uint64(numRows))
+	for i, d := range data {
+		for _, b := range [][]byte{s.cols[i].Name(), s.cols[i].Type()} {
+			block = binary.AppendUvarint(block, uint64(len(b)))
+			block = append(block, b...)
+		}
+		block = append(block, d...)
+	}
+	_, cols, _, err := readBlockFromBytes(block, nil)
+	if err != nil {
+		s.f.t.Fatalf("decode encoded block: %v", err)
+	}
+	s.f.encoded++
+	s.record(cols)
+	return nil
+}
+
+func (s *fakeInsertStmt) record(cols []column.ColumnCore) {
+	for row := range cols[0].NumRow() {
+		var vals []string
+		for _, col := range cols {
+			vals = append(vals, string(col.ToJSON(row, false, nil)))
+		}
+		s.f.rows = append(s.f.rows, strings.Join(vals, ","))
+	}
+}
+
+func (s *fakeInsertStmt) Flush(context.Context) error {
+	s.f.flushed = true
+	return nil
+}
+
+func (s *fakeInsertStmt) Close() {}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.native")
+	if err := WriteFile(path, oldSchemaBlock(t), WithZSTD(), WithBlockIndex()); err != nil {
+		t.Fatal(err)
+	}
+	ins := &fakeInserter{t: t, header: []string{"name String", "id Int64", "n Nullable(UInt16)"}}
+	var progress []LoadProgress
+	err := LoadFile(context.Background(), ins, "INSERT INTO t VALUES", path,
+		WithZSTD(), WithConcurrency(2), WithLoadProgress(func(p LoadProgress) { progress = append(progress, p) }))
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	if got := strings.Join(ins.rows, " "); got != `"a","-1",null "b","2",7` {
+		t.Fatalf("rows = %s", got)
+	}
+	if !ins.flushed || ins.encoded != 1 {
+		t.Fatalf("flushed = %v, %d encoded blocks", ins.flushed, ins.encoded)
+	}
+	if len(progress) != 1 || progress[0] != (LoadProgress{Blocks: 1, Rows: 2, TotalRows: 2}) {
+		t.Fatalf("progress = %+v", progress)
+	}
+
+	ins = &fakeInserter{t: t, header: []string{"id Int32", "missing String"}}
+	err = LoadFile(context.Background(), ins, "INSERT INTO t VALUES", path, WithZSTD())
+	if err == nil || !strings.Contains(err.Error(), `"missing" is not in the file`) {
+		t.Fatalf("got %v, want a missing column error", err)
+	}
+}
+
+func TestLoadFileForwardsData(t *testing.T) {
+	defs := []string{"n Nullable(Int32)", "tags Array(String)", "lc LowCardinality(String)", "s String"}
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{name: "plain"},
+		{name: "lz4", opts: []Option{WithLZ4()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "data.native")
+			f, err := os.Create(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fw := NewFileWriter(f, tc.opts...)
+			for _, rows := range [][][]any{
+				{{nil, []string{"a"}, "x", "p"}, {int32(1), []string{}, "y", "q"}},
+				{{int32(2), []string{"b", "c"}, "x", "r"}},
+			} {
+				cols := typedColumns(t, defs...)
+				for _, row := range rows {
+					for i, v := range row {
+						if err := cols[i].AppendAny(v); err != nil {
+							t.Fatalf("AppendAny %s: %v", cols[i].Name(), err)
+						}
+					}
+				}
+				if err := fw.WriteBlock(cols...); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := f.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			// lc and tags are forwarded as stored, n is widened and s is skipped.
+			ins := &fakeInserter{t: t, header: []string{"lc LowCardinality(String)", "n Nullable(Int64)", "tags Array(String)"}}
+			if err := LoadFile(context.Background(), ins, "INSERT INTO t VALUES", path, tc.opts...); err != nil {
+				t.Fatalf("LoadFile: %v", err)
+			}
+			want := `"x",null,["a"] "y","1",[] "x","2",["b","c"]`
+			if got := strings.Join(ins.rows, " "); got != want || ins.encoded != 2 {
+				t.Fatalf("rows = %s, want %s (%d encoded blocks)", got, want, ins.encoded)
+			}
+		})
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{name: "plain"},
+		{name: "lz4", opts: []Option{WithLZ4()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "ds")
+			for _, block := range [][]column.ColumnCore{
+				{int32Col("id", 1, 2), strCol("s", "a", "b"), floatCol("x", 0.5, 1)},
+				{int32Col("id"), strCol("s"), floatCol("x")},
+				{int32Col("id", 3), strCol("s", "c"), floatCol("x", 2)},
+			} {
+				if err := AppendDir(dir, block, tc.opts...); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// "s" and "id" match the stored types and are forwarded as stored;
+			// "id" is widened to Int64 in the second load.
+			for _, header := range [][]string{
+				{"s String", "id Int32"},
+				{"s String", "id Int64"},
+			} {
+				ins := &fakeInserter{t: t, header: header}
+				var last LoadProgress
+				opts := append(tc.opts[:len(tc.opts):len(tc.opts)], WithLoadProgress(func(p LoadProgress) { last = p }))
+				if err := LoadDir(context.Background(), ins, "INSERT INTO t VALUES", dir, opts...); err != nil {
+					t.Fatalf("LoadDir %v: %v", header, err)
+				}
+				want := `"a",1 "b",2 "c",3`
+				if header[1] == "id Int64" {
+					want = `"a","1" "b","2" "c","3"`
+				}
+				if got := strings.Join(ins.rows, " "); got != want {
+					t.Fatalf("%v: rows = %s, want %s", header, got, want)
+				}
+				if ins.encoded != 2 || last != (LoadProgress{Blocks: 2, Rows: 3, TotalRows: 3}) {
+					t.Fatalf("%v: %d encoded blocks, progress %+v", header, ins.encoded, last)
+				}
+			}
+		})
+	}
+}
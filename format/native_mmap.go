@@ -0,0 +1,168 @@
+package format
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+// MmapReader reads Native-format blocks from read-only memory-mapped files.
+// Like BytesReader, Base[T] and String columns alias the mapping with zero copies,
+// and all other column types are streaming-read from it without copying the file.
+//
+// The returned columns and the strings they yield are valid only until Close,
+// which unmaps the files; using them afterwards may crash the program.
+type MmapReader struct {
+	mappings [][]byte
+	readers  []*BytesReader
+	// names is the column name of each reader in dir mode.
+	names  []string
+	dir    bool
+	closed bool
+}
+
+// OpenFileMmap maps an uncompressed Native file, or every column file of an
+// uncompressed dir-mode dataset written by WriteDir, read-only into memory.
+//
+// For a dataset, each ReadBlock returns the next block of every column file, in
+// metadata order. Compressed files can't be mapped; use OpenFile or OpenDir with the
+// codec option for them. On platforms without mmap the files are read into memory.
+func OpenFileMmap(path string) (*MmapReader, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	mr := &MmapReader{dir: info.IsDir()}
+	if !mr.dir {
+		if err := mr.mapFile(path); err != nil {
+			mr.Close()
+			return nil, err
+		}
+		return mr, nil
+	}
+
+	_, metas, _, err := readDirMeta(path)
+	if err != nil {
+		return nil, fmt.Errorf("native: open dir %s: %w", path, err)
+	}
+	for _, meta := range metas {
+		if err := mr.mapFile(filepath.Join(path, meta.file)); err != nil {
+			mr.Close()
+			return nil, fmt.Errorf("native: column %q: %w", meta.Name, err)
+		}
+		// Bytes past the committed size belong to no block.
+		if r := mr.readers[len(mr.readers)-1]; meta.size >= 0 {
+			if meta.size > int64(len(r.data)) {
+				mr.Close()
+				return nil, fmt.Errorf("native: column %q: file has %d bytes, metadata records %d", meta.Name, len(r.data), meta.size)
+			}
+			r.data = r.data[:meta.size]
+		}
+		mr.names = append(mr.names, meta.Name)
+	}
+	return mr, nil
+}
+
+// mapFile maps the file at path and adds a reader over the mapping.
+func (mr *MmapReader) mapFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	// Check the magic before mapping, so a compressed file is never mapped.
+	magic := make([]byte, len(compressedMagic))
+	if n, _ := f.ReadAt(magic, 0); n == len(magic) && bytes.Equal(magic, compressedMagic[:]) {
+		return fmt.Errorf("native: %s is compressed and can't be mapped; use OpenFile with the codec option", path)
+	}
+	var data []byte
+	if info.Size() > 0 {
+		if int64(int(info.Size())) != info.Size() {
+			return fmt.Errorf("native: %s is too large to map (%d bytes)", path, info.Size())
+		}
+		data, err = mmapFile(f, int(info.Size()))
+		if err != nil {
+			return fmt.Errorf("native: mmap %s: %w", path, err)
+		}
+		mr.mappings = append(mr.mappings, data)
+	}
+	mr.readers = append(mr.readers, OpenBytes(data))
+	return nil
+}
+
+// ReadBlock reads the next block, aliasing each ZeroCopyColumn into the mapping.
+// Returns io.EOF when no blocks remain.
+func (mr *MmapReader) ReadBlock() (int, []column.ColumnCore, error) {
+	if mr.closed {
+		return 0, nil, errors.New("native: read from closed MmapReader")
+	}
+	if !mr.dir {
+		return mr.readers[0].ReadBlock()
+	}
+	if len(mr.readers) == 0 {
+		return 0, nil, io.EOF
+	}
+
+	numRows := 0
+	var cols []column.ColumnCore
+	for i, r := range mr.readers {
+		n, block, err := r.ReadBlock()
+		if errors.Is(err, io.EOF) {
+			if i == 0 {
+				return mr.checkEOF()
+			}
+			return 0, nil, fmt.Errorf("native: column %q has fewer blocks than column %q", mr.names[i], mr.names[0])
+		}
+		if err != nil {
+			return 0, nil, fmt.Errorf("native: column %q: %w", mr.names[i], err)
+		}
+		if len(block) != 1 {
+			return 0, nil, fmt.Errorf("native: column file of %q has %d columns, want 1", mr.names[i], len(block))
+		}
+		if i == 0 {
+			numRows = n
+		} else if n != numRows {
+			return 0, nil, fmt.Errorf("native: column %q has %d rows in this block, want %d", mr.names[i], n, numRows)
+		}
+		cols = append(cols, block[0])
+	}
+	return numRows, cols, nil
+}
+
+// checkEOF returns io.EOF if every column file is exhausted, or an error if some
+// column files have more blocks than the first one.
+func (mr *MmapReader) checkEOF() (int, []column.ColumnCore, error) {
+	for i, r := range mr.readers {
+		if r.off < len(r.data) {
+			return 0, nil, fmt.Errorf("native: column %q has more blocks than column %q", mr.names[i], mr.names[0])
+		}
+	}
+	return 0, nil, io.EOF
+}
+
+// Close unmaps the files. Columns returned by ReadBlock must not be used afterwards.
+// Close is idempotent.
+func (mr *MmapReader) Close() error {
+	if mr.closed {
+		return nil
+	}
+	mr.closed = true
+	var errs []error
+	for _, m := range mr.mappings {
+		if err := munmapFile(m); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	mr.mappings = nil
+	mr.readers = nil
+	return errors.Join(errs...)
+}
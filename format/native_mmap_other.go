@@ -0,0 +1,21 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+
+package format
+
+import (
+	"io"
+	"os"
+)
+
+// mmapFile reads the first size bytes of f, as mmap is not supported on this platform.
+func mmapFile(f *os.File, size int) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func munmapFile([]byte) error {
+	return nil
+}
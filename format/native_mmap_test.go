@@ -0,0 +1,119 @@
+package format
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+func TestOpenFileMmap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.native")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw := NewFileWriter(f)
+	if err := fw.WriteBlock(floatCol("cpm", 1.5, 2.5), strCol("bidder", "x", "yy")); err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.WriteBlock(floatCol("cpm", 3.5), strCol("bidder", "zzz")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mr, err := OpenFileMmap(path)
+	if err != nil {
+		t.Fatalf("OpenFileMmap: %v", err)
+	}
+	numRows, cols, err := mr.ReadBlock()
+	if err != nil {
+		t.Fatalf("ReadBlock: %v", err)
+	}
+	if numRows != 2 || cols[0].(*column.Base[float64]).Row(1) != 2.5 || cols[1].(*column.String).Row(1) != "yy" {
+		t.Fatalf("wrong first block: %d rows", numRows)
+	}
+	numRows, cols, err = mr.ReadBlock()
+	if err != nil {
+		t.Fatalf("ReadBlock: %v", err)
+	}
+	if numRows != 1 || cols[1].(*column.String).Row(0) != "zzz" {
+		t.Fatalf("wrong second block: %d rows", numRows)
+	}
+	if _, _, err := mr.ReadBlock(); !errors.Is(err, io.EOF) {
+		t.Fatalf("got %v, want io.EOF", err)
+	}
+	if err := mr.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := mr.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+	if _, _, err := mr.ReadBlock(); err == nil {
+		t.Fatal("expected an error after Close")
+	}
+}
+
+func TestOpenFileMmapDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "ds")
+	if err := WriteDir(dir, []column.ColumnCore{
+		floatCol("cpm", 1, 2, 3),
+		strCol("bidder", "a", "b", "c"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	mr, err := OpenFileMmap(dir)
+	if err != nil {
+		t.Fatalf("OpenFileMmap: %v", err)
+	}
+	defer mr.Close()
+	numRows, cols, err := mr.ReadBlock()
+	if err != nil {
+		t.Fatalf("ReadBlock: %v", err)
+	}
+	if numRows != 3 || len(cols) != 2 {
+		t.Fatalf("got %d rows, %d columns", numRows, len(cols))
+	}
+	if string(cols[1].Name()) != "bidder" || cols[1].(*column.String).Row(2) != "c" {
+		t.Fatal("wrong string column")
+	}
+	if _, _, err := mr.ReadBlock(); !errors.Is(err, io.EOF) {
+		t.Fatalf("got %v, want io.EOF", err)
+	}
+}
+
+func TestOpenFileMmapCompressed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.native")
+	if err := WriteFile(path, []column.ColumnCore{floatCol("v", 1)}, WithZSTD()); err != nil {
+		t.Fatal(err)
+	}
+	_, err := OpenFileMmap(path)
+	if err == nil || !strings.Contains(err.Error(), "compressed") {
+		t.Fatalf("got %v, want a compressed file error", err)
+	}
+	// The file is rejected before it is mapped.
+	unmapped := &MmapReader{}
+	if err := unmapped.mapFile(path); err == nil || len(unmapped.mappings) != 0 {
+		t.Fatalf("got %v with %d mappings, want an error and no mapping", err, len(unmapped.mappings))
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.native")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mr, err := OpenFileMmap(empty)
+	if err != nil {
+		t.Fatalf("OpenFileMmap: %v", err)
+	}
+	defer mr.Close()
+	if _, _, err := mr.ReadBlock(); !errors.Is(err, io.EOF) {
+		t.Fatalf("got %v, want io.EOF", err)
+	}
+}
@@ -6,6 +6,13 @@ import "runtime"
 type config struct {
 	codec       *Codec
 	concurrency int
+	// concurrencySet records an explicit WithConcurrency: FileReader only
+	// pipelines its reads when asked to.
+	concurrencySet bool
+	blockIndex     bool
+	blockRows      int
+	partitionValue func(string) string
+	progress       func(LoadProgress)
 }
 
 // Option configures native readers/writers.
@@ -24,8 +31,43 @@ func WithLZ4() Option { return func(c *config) { c.codec = lz4Codec() } }
 func WithCodec(codec Codec) Option { return func(c *config) { cc := codec; c.codec = &cc } }
 
 // WithConcurrency sets the number of worker goroutines for parallel dir-mode
-// writes and reads. n <= 0 uses runtime.NumCPU().
-func WithConcurrency(n int) Option { return func(c *config) { c.concurrency = n } }
+// writes and reads, and for pipelined reads of compressed files opened with
+// OpenFile. n <= 0 uses runtime.NumCPU().
+func WithConcurrency(n int) Option {
+	return func(c *config) { c.concurrency, c.concurrencySet = n, true }
+}
+
+// WithBlockIndex ends a compressed file with a block index: the byte offset,
+// row count and CityHash128 checksum of every compressed unit. It enables
+// FileReader.SeekBlock, SeekRow and Verify; sequential reads stop at the index.
+// It requires a codec. Dir-mode column files never get one, since
+// metadata.bin already indexes their blocks.
+func WithBlockIndex() Option { return func(c *config) { c.blockIndex = true } }
+
+// WithBlockRows makes WriteDir and AppendDir store their columns as blocks of
+// at most n rows, each with its own min/max statistics, so DirReader.OpenBlocks
+// can skip parts of a large write. n <= 0 (the default) writes a single block.
+func WithBlockRows(n int) Option { return func(c *config) { c.blockRows = n } }
+
+// WithPartitionValue makes AppendPartitioned partition rows by fn(value), where
+// value is the key column rendered as for PartitionPath. For example, to store
+// a DateTime key as one partition per day:
+//
+//	WithPartitionValue(func(v string) string { return v[:10] })
+func WithPartitionValue(fn func(value string) string) Option {
+	return func(c *config) { c.partitionValue = fn }
+}
+
+// WithLoadProgress makes LoadFile and LoadDir call fn after every block they
+// send to the server.
+func WithLoadProgress(fn func(LoadProgress)) Option { return func(c *config) { c.progress = fn } }
+
+// withoutBlockIndex overrides WithBlockIndex for dir-mode column files.
+func withoutBlockIndex() Option { return func(c *config) { c.blockIndex = false } }
+
+// withoutPipeline overrides WithConcurrency for the column files a DirReader
+// opens: its workers already read the columns in parallel.
+func withoutPipeline() Option { return func(c *config) { c.concurrencySet = false } }
 
 func resolve(opts []Option) config {
 	var c config
@@ -0,0 +1,249 @@
+package format
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+	"github.com/vahid-sohrabloo/chconn/v3/internal/helper"
+)
+
+// Schema evolution: readers that take target columns (ReadBlockAs,
+// OpenColumnsAs) match stored columns by name, read identical types directly,
+// widen compatible types, fill columns missing from the data with defaults and
+// drop stored columns no target asks for.
+
+// intBits maps the integer types that can be widened to their width.
+var intBits = map[string]int{
+	"Int8": 8, "Int16": 16, "Int32": 32, "Int64": 64,
+	"UInt8": 8, "UInt16": 16, "UInt32": 32, "UInt64": 64,
+}
+
+// unwrapNullable strips Nullable from chType, moving it inside LowCardinality:
+// LowCardinality(Nullable(String)) gives LowCardinality(String), true.
+func unwrapNullable(chType string) (string, bool) {
+	b := []byte(chType)
+	switch {
+	case helper.IsNullableLowCardinality(b):
+		return helper.LowCardinalityStr + chType[helper.LenLowCardinalityNullableStr:len(chType)-2] + ")", true
+	case helper.IsNullable(b):
+		return chType[helper.LenNullableStr : len(chType)-1], true
+	}
+	return chType, false
+}
+
+// canWiden reports whether values of the (non-Nullable) type src can be stored
+// in dst without loss: wider integers (unsigned into wider signed too),
+// Float32 to Float64, String to LowCardinality(String), Date to Date32,
+// DateTime to DateTime with another timezone or to DateTime64, and DateTime64
+// to a DateTime64 of at least the same precision.
+func canWiden(src, dst string) bool {
+	if src == dst {
+		return true
+	}
+	if sb, ok := intBits[src]; ok {
+		db, ok := intBits[dst]
+		srcSigned, dstSigned := src[0] == 'I', dst[0] == 'I'
+		return ok && db > sb && (srcSigned == dstSigned || dstSigned)
+	}
+	switch {
+	case src == "Float32":
+		return dst == "Float64"
+	case src == "String":
+		return dst == "LowCardinality(String)"
+	case src == "Date":
+		return dst == "Date32"
+	case strings.HasPrefix(src, "DateTime64"):
+		sp, ok := dateTime64Precision(src)
+		dp, ok2 := dateTime64Precision(dst)
+		return ok && ok2 && dp >= sp
+	case strings.HasPrefix(src, "DateTime"):
+		return strings.HasPrefix(dst, "DateTime")
+	}
+	return false
+}
+
+// dateTime64Precision returns the precision of the DateTime64 type chType.
+func dateTime64Precision(chType string) (int, bool) {
+	params, ok := strings.CutPrefix(chType, "DateTime64(")
+	if !ok {
+		return 0, false
+	}
+	params, _, _ = strings.Cut(strings.TrimSuffix(params, ")"), ",")
+	p, err := strconv.Atoi(strings.TrimSpace(params))
+	return p, err == nil
+}
+
+// convertColumn replaces the rows of dst with the rows of src, widening each
+// value to dst's type.
+func convertColumn(dst, src column.ColumnCore) error {
+	srcType, dstType := string(src.Type()), string(dst.Type())
+	srcInner, srcNullable := unwrapNullable(srcType)
+	dstInner, dstNullable := unwrapNullable(dstType)
+	if (srcNullable && !dstNullable) || !canWiden(srcInner, dstInner) {
+		return fmt.Errorf("cannot read %s as %s", srcType, dstType)
+	}
+
+	get := src.RowAny
+	put := dst.AppendAny
+	if srcInner != dstInner {
+		get = rowGetter(src, srcInner)
+		put = valueAppender(dst, dstInner)
+		if get == nil || put == nil {
+			return fmt.Errorf("cannot read %s as %s", srcType, dstType)
+		}
+	}
+	var isNil func(int) bool
+	var appendNil func()
+	if srcNullable {
+		n, ok := src.(interface{ RowIsNil(int) bool })
+		a, ok2 := dst.(interface{ AppendNil() })
+		if !ok || !ok2 {
+			return fmt.Errorf("cannot read %s as %s", srcType, dstType)
+		}
+		isNil, appendNil = n.RowIsNil, a.AppendNil
+	}
+
+	dst.Reset()
+	for i := range src.NumRow() {
+		if isNil != nil && isNil(i) {
+			appendNil()
+			continue
+		}
+		if err := put(get(i)); err != nil {
+			return fmt.Errorf("cannot read %s as %s: %w", srcType, dstType, err)
+		}
+	}
+	return nil
+}
+
+// rowGetter returns a function reading row values of a column of the widenable
+// type chType as int64, uint64, float64, string or time.Time.
+func rowGetter(col column.ColumnCore, chType string) func(int) any {
+	switch chType {
+	case "Int8":
+		return numberRows[int8, int64](col)
+	case "Int16":
+		return numberRows[int16, int64](col)
+	case "Int32":
+		return numberRows[int32, int64](col)
+	case "Int64":
+		return numberRows[int64, int64](col)
+	case "UInt8":
+		return numberRows[uint8, uint64](col)
+	case "UInt16":
+		return numberRows[uint16, uint64](col)
+	case "UInt32":
+		return numberRows[uint32, uint64](col)
+	case "UInt64":
+		return numberRows[uint64, uint64](col)
+	case "Float32":
+		return numberRows[float32, float64](col)
+	case "String":
+		if r, ok := col.(column.RowReader[string]); ok {
+			return func(i int) any { return r.Row(i) }
+		}
+	}
+	if strings.HasPrefix(chType, "Date") {
+		if r, ok := col.(column.RowReader[time.Time]); ok {
+			return func(i int) any { return r.Row(i) }
+		}
+	}
+	return nil
+}
+
+func numberRows[T, S statsNumber](col column.ColumnCore) func(int) any {
+	r, ok := col.(column.RowReader[T])
+	if !ok {
+		return nil
+	}
+	return func(i int) any { return S(r.Row(i)) }
+}
+
+// valueAppender returns a function appending a value produced by rowGetter to a
+// column of the widened type chType.
+func valueAppender(col column.ColumnCore, chType string) func(any) error {
+	switch chType {
+	case "Int16":
+		return numberAppender[int16](col)
+	case "Int32":
+		return numberAppender[int32](col)
+	case "Int64":
+		return numberAppender[int64](col)
+	case "UInt16":
+		return numberAppender[uint16](col)
+	case "UInt32":
+		return numberAppender[uint32](col)
+	case "UInt64":
+		return numberAppender[uint64](col)
+	case "Float64":
+		return numberAppender[float64](col)
+	case "LowCardinality(String)":
+		if a, ok := col.(interface{ Append(string) }); ok {
+			return func(v any) error {
+				a.Append(v.(string))
+				return nil
+			}
+		}
+	}
+	if strings.HasPrefix(chType, "Date") {
+		if a, ok := col.(interface{ Append(time.Time) }); ok {
+			return func(v any) error {
+				a.Append(v.(time.Time))
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func numberAppender[T statsNumber](col column.ColumnCore) func(any) error {
+	a, ok := col.(interface{ Append(T) })
+	if !ok {
+		return nil
+	}
+	return func(v any) error {
+		switch v := v.(type) {
+		case int64:
+			a.Append(T(v))
+		case uint64:
+			a.Append(T(v))
+		case float64:
+			a.Append(T(v))
+		default:
+			return fmt.Errorf("unexpected value %T", v)
+		}
+		return nil
+	}
+}
+
+// fillDefault replaces the rows of dst with n default values: NULL for
+// Nullable types, the Unix epoch for dates and the zero value otherwise (0,
+// empty string, empty array or map).
+func fillDefault(dst column.ColumnCore, n int) error {
+	dst.Reset()
+	if _, nullable := unwrapNullable(string(dst.Type())); nullable {
+		if a, ok := dst.(interface{ AppendNil() }); ok {
+			for range n {
+				a.AppendNil()
+			}
+			return nil
+		}
+	}
+	m := reflect.ValueOf(dst).MethodByName("Append")
+	if !m.IsValid() || m.Type().NumIn() != 1 {
+		return fmt.Errorf("cannot fill missing column %q of type %s with defaults", dst.Name(), dst.Type())
+	}
+	arg := reflect.Zero(m.Type().In(0))
+	if arg.Type() == reflect.TypeFor[time.Time]() {
+		arg = reflect.ValueOf(time.Unix(0, 0).UTC())
+	}
+	args := []reflect.Value{arg}
+	for range n {
+		m.Call(args)
+	}
+	return nil
+}
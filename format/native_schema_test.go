@@ -0,0 +1,244 @@
+package format
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+// typedColumns builds empty columns from "name Type" pairs.
+func typedColumns(t *testing.T, defs ...string) []column.ColumnCore {
+	t.Helper()
+	var headers []column.ColumnHeader
+	for _, def := range defs {
+		name, chType, _ := strings.Cut(def, " ")
+		headers = append(headers, column.ColumnHeader{Name: []byte(name), ChType: []byte(chType)})
+	}
+	nw := NewNativeWriter(io.Discard)
+	if err := nw.SetColumns(headers); err != nil {
+		t.Fatalf("SetColumns: %v", err)
+	}
+	return nw.Columns()
+}
+
+// oldSchemaBlock returns a two-row block in the schema older writers used.
+func oldSchemaBlock(t *testing.T) []column.ColumnCore {
+	t.Helper()
+	cols := typedColumns(t,
+		"id Int32", "price Float32", "name String", "n Nullable(UInt16)",
+		"ts DateTime('UTC')", "tag String", "legacy String")
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := [][]any{
+		{int32(-1), float32(1.5), "a", nil, ts, "x", "old"},
+		{int32(2), float32(2.25), "b", uint16(7), ts.Add(time.Second), "y", "old"},
+	}
+	for _, row := range rows {
+		for i, v := range row {
+			if err := cols[i].AppendAny(v); err != nil {
+				t.Fatalf("AppendAny %s: %v", cols[i].Name(), err)
+			}
+		}
+	}
+	return cols
+}
+
+// newSchema is the current model: widened, reordered, with added columns.
+var newSchema = []string{
+	"tag String", "id Int64", "price Float64", "name LowCardinality(String)",
+	"n Nullable(Int64)", "ts DateTime64(3, 'UTC')",
+	"added UInt8", "added_null Nullable(String)", "added_arr Array(String)", "added_day Date",
+}
+
+func checkNewSchema(t *testing.T, cols []column.ColumnCore) {
+	t.Helper()
+	want := []string{
+		`"x"|"y"`, `"-1"|"2"`, `1.5|2.25`, `"a"|"b"`, `null|"7"`,
+		`"2024-01-02 03:04:05.000000000"|"2024-01-02 03:04:06.000000000"`,
+		`0|0`, `null|null`, `[]|[]`, `"1970-01-01"|"1970-01-01"`,
+	}
+	for i, col := range cols {
+		var rows []string
+		for row := range col.NumRow() {
+			rows = append(rows, string(col.ToJSON(row, false, nil)))
+		}
+		if got := strings.Join(rows, "|"); got != want[i] {
+			t.Errorf("column %s: got %s, want %s", col.Name(), got, want[i])
+		}
+	}
+}
+
+func TestFileReaderReadBlockAs(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{name: "plain"},
+		{name: "zstd", opts: []Option{WithZSTD()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "old.native")
+			if err := WriteFile(path, oldSchemaBlock(t), tc.opts...); err != nil {
+				t.Fatal(err)
+			}
+			fr, err := OpenFile(path, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer fr.Close()
+
+			cols := typedColumns(t, newSchema...)
+			numRows, err := fr.ReadBlockAs(cols...)
+			if err != nil {
+				t.Fatalf("ReadBlockAs: %v", err)
+			}
+			if numRows != 2 {
+				t.Fatalf("got %d rows, want 2", numRows)
+			}
+			checkNewSchema(t, cols)
+			if _, err := fr.ReadBlockAs(cols...); !errors.Is(err, io.EOF) {
+				t.Fatalf("got %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestReadBlockAsNarrowing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.native")
+	if err := WriteFile(path, oldSchemaBlock(t)); err != nil {
+		t.Fatal(err)
+	}
+	for _, def := range []string{"id Int16", "id UInt64", "n UInt16", "n Nullable(Int16)", "name FixedString(1)"} {
+		fr, err := OpenFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = fr.ReadBlockAs(typedColumns(t, def)...)
+		fr.Close()
+		if err == nil || !strings.Contains(err.Error(), "cannot read") {
+			t.Fatalf("%s: got %v, want a conversion error", def, err)
+		}
+	}
+}
+
+func TestReadBlockAsDateTime64Precision(t *testing.T) {
+	cols := typedColumns(t, "ts DateTime64(6, 'UTC')")
+	if err := cols[0].AppendAny(time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "ts.native")
+	if err := WriteFile(path, cols); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		def, want string
+	}{
+		{def: "ts DateTime64(6, 'UTC')", want: `"2024-01-02 03:04:05.123456000"`},
+		{def: "ts DateTime64(9, 'UTC')", want: `"2024-01-02 03:04:05.123456000"`},
+		{def: "ts DateTime64(3, 'UTC')"},
+		{def: "ts DateTime('UTC')"},
+	} {
+		fr, err := OpenFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := typedColumns(t, tc.def)
+		_, err = fr.ReadBlockAs(dst...)
+		fr.Close()
+		if tc.want == "" {
+			if err == nil || !strings.Contains(err.Error(), "cannot read") {
+				t.Fatalf("%s: got %v, want a conversion error", tc.def, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", tc.def, err)
+		}
+		if got := string(dst[0].ToJSON(0, false, nil)); got != tc.want {
+			t.Fatalf("%s: got %s, want %s", tc.def, got, tc.want)
+		}
+	}
+}
+
+func TestDirReadAs(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "ds")
+	if err := WriteDir(dir, oldSchemaBlock(t)); err != nil {
+		t.Fatal(err)
+	}
+	dr, err := OpenDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Remove a column the new schema drops: it must never be opened.
+	for _, meta := range dr.Columns() {
+		if meta.Name == "legacy" {
+			if err := os.Remove(filepath.Join(dir, meta.file)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	cols := typedColumns(t, newSchema...)
+	numRows, err := dr.OpenColumnsAs(cols...)
+	if err != nil {
+		t.Fatalf("OpenColumnsAs: %v", err)
+	}
+	if numRows != 2 {
+		t.Fatalf("got %d rows, want 2", numRows)
+	}
+	checkNewSchema(t, cols)
+}
+
+func TestDirBlockReaderReadBlockAs(t *testing.T) {
+	dir := t.TempDir()
+	for _, block := range [][]column.ColumnCore{
+		{int32Col("id", 1, 2), strCol("s", "a", "b")},
+		{int32Col("id", 3), strCol("s", "c")},
+	} {
+		if err := AppendDir(dir, block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dr, err := OpenDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	br, err := dr.OpenBlocks(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer br.Close()
+
+	var got []string
+	cols := typedColumns(t, "id Nullable(Int64)", "extra Float64")
+	for {
+		numRows, err := br.ReadBlockAs(cols...)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ReadBlockAs: %v", err)
+		}
+		for row := range numRows {
+			got = append(got, string(cols[0].ToJSON(row, true, nil))+":"+string(cols[1].ToJSON(row, true, nil)))
+		}
+		// Read the string column through ReadBlock on a block ReadBlockAs skipped.
+		if numRows == 2 {
+			_, blockCols, err := br.ReadBlock()
+			if err != nil {
+				t.Fatalf("ReadBlock: %v", err)
+			}
+			if s := blockCols[1].(*column.String).Row(0); s != "c" {
+				t.Fatalf("s = %q, want c", s)
+			}
+			break
+		}
+	}
+	if strings.Join(got, " ") != "1:0 2:0" {
+		t.Fatalf("got %q", strings.Join(got, " "))
+	}
+}
@@ -0,0 +1,298 @@
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/vahid-sohrabloo/chconn/v3/internal/helper"
+	"github.com/vahid-sohrabloo/chconn/v3/internal/readerwriter"
+)
+
+// columnSkipper reads past the encoded data of a column without decoding it,
+// so the data can be forwarded as stored or dropped.
+type columnSkipper struct {
+	r *readerwriter.Reader
+	// src, when set, is the in-memory block under r: skipped bytes are
+	// seeked over instead of read.
+	src *bytes.Reader
+	buf []byte
+}
+
+// skipType returns the type chType is stored as: geo and Nested types as
+// their arrays and tuples, SimpleAggregateFunction as its value type.
+func skipType(chType []byte) []byte {
+	chType = helper.NestedToArrayType(helper.FilterSimpleAggregate(chType))
+	switch {
+	case helper.IsPoint(chType):
+		return helper.PointMainTypeStr
+	case helper.IsRing(chType):
+		return helper.RingMainTypeStr
+	case helper.IsPolygon(chType):
+		return helper.PolygonMainTypeStr
+	case helper.IsMultiPolygon(chType):
+		return helper.MultiPolygonMainTypeStr
+	}
+	return chType
+}
+
+// fixedTypeSize returns the byte size of a value of chType, or 0 if the type
+// is not fixed size.
+func fixedTypeSize(chType []byte) int {
+	switch string(chType) {
+	case "Bool", "Int8", "UInt8":
+		return 1
+	case "Int16", "UInt16", "Date", "BFloat16":
+		return 2
+	case "Int32", "UInt32", "Float32", "Date32", "DateTime", "IPv4", "Time":
+		return 4
+	case "Int64", "UInt64", "Float64":
+		return 8
+	case "Int128", "UInt128", "UUID", "IPv6":
+		return 16
+	case "Int256", "UInt256":
+		return 32
+	}
+	switch {
+	case helper.IsEnum8(chType):
+		return 1
+	case helper.IsEnum16(chType):
+		return 2
+	case helper.IsDateTimeWithParam(chType):
+		return 4
+	case helper.IsDateTime64(chType), helper.IsTime64(chType):
+		return 8
+	case helper.IsFixedString(chType):
+		n, err := strconv.Atoi(string(chType[helper.FixedStringStrLen : len(chType)-1]))
+		if err != nil || n <= 0 {
+			return 0
+		}
+		return n
+	case helper.IsDecimal(chType):
+		end := helper.DecimalStrLen
+		for end < len(chType) && chType[end] >= '0' && chType[end] <= '9' {
+			end++
+		}
+		precision, err := strconv.Atoi(string(chType[helper.DecimalStrLen:end]))
+		if err != nil {
+			return 0
+		}
+		switch {
+		case precision <= 9:
+			return 4
+		case precision <= 18:
+			return 8
+		case precision <= 38:
+			return 16
+		case precision <= 76:
+			return 32
+		}
+	}
+	return 0
+}
+
+// lowCardinalityDict returns the type of the dictionary of a
+// LowCardinality(T) or LowCardinality(Nullable(T)) type: T.
+func lowCardinalityDict(chType []byte) []byte {
+	dict := chType[helper.LenLowCardinalityStr : len(chType)-1]
+	if helper.IsNullable(dict) {
+		dict = dict[helper.LenNullableStr : len(dict)-1]
+	}
+	return dict
+}
+
+// elementTypes returns the element types of a Tuple or the key and value
+// types of a Map.
+func elementTypes(chType []byte) ([]helper.ColumnData, error) {
+	prefix := helper.LenTupleStr
+	if helper.IsMap(chType) {
+		prefix = helper.LenMapStr
+	}
+	elems, err := helper.TypesInParentheses(chType[prefix : len(chType)-1])
+	if err != nil {
+		return nil, err
+	}
+	if helper.IsMap(chType) && len(elems) != 2 {
+		return nil, fmt.Errorf("invalid map type %s", chType)
+	}
+	return elems, nil
+}
+
+// skippable reports whether skip can read past a column of type chType.
+// Other types (Variant, Dynamic, JSON, Nothing, ...) must be decoded.
+func skippable(chType []byte) bool {
+	chType = skipType(chType)
+	switch {
+	case string(chType) == "String", fixedTypeSize(chType) > 0:
+		return true
+	case helper.IsNullable(chType):
+		return skippable(chType[helper.LenNullableStr : len(chType)-1])
+	case helper.IsLowCardinality(chType):
+		dict := lowCardinalityDict(chType)
+		return string(dict) == "String" || fixedTypeSize(dict) > 0
+	case helper.IsArray(chType):
+		return skippable(chType[helper.LenArrayStr : len(chType)-1])
+	case helper.IsMap(chType), helper.IsTuple(chType):
+		elems, err := elementTypes(chType)
+		if err != nil || len(elems) == 0 {
+			return false
+		}
+		for _, elem := range elems {
+			if !skippable(elem.ChType) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// skip reads past a column of type chType with rows rows: its serialization
+// prefix and its data. chType must be skippable.
+func (s *columnSkipper) skip(chType []byte, rows int) error {
+	if err := s.prefix(chType); err != nil {
+		return err
+	}
+	return s.data(chType, uint64(rows))
+}
+
+// prefix reads past the serialization prefix of a column, which comes before
+// the data of the column and of every nested column.
+func (s *columnSkipper) prefix(chType []byte) error {
+	chType = skipType(chType)
+	switch {
+	case helper.IsNullable(chType):
+		return s.prefix(chType[helper.LenNullableStr : len(chType)-1])
+	case helper.IsLowCardinality(chType):
+		// keys serialization version
+		return s.discard(8)
+	case helper.IsArray(chType):
+		return s.prefix(chType[helper.LenArrayStr : len(chType)-1])
+	case helper.IsMap(chType), helper.IsTuple(chType):
+		elems, err := elementTypes(chType)
+		if err != nil {
+			return err
+		}
+		for _, elem := range elems {
+			if err := s.prefix(elem.ChType); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// data reads past rows values of a column.
+func (s *columnSkipper) data(chType []byte, rows uint64) error {
+	chType = skipType(chType)
+	if size := fixedTypeSize(chType); size > 0 {
+		return s.discard(rows * uint64(size))
+	}
+	switch {
+	case string(chType) == "String":
+		for range rows {
+			n, err := s.r.Uvarint()
+			if err != nil {
+				return err
+			}
+			if err := s.discard(n); err != nil {
+				return err
+			}
+		}
+		return nil
+	case helper.IsNullable(chType):
+		if err := s.discard(rows); err != nil {
+			return err
+		}
+		return s.data(chType[helper.LenNullableStr:len(chType)-1], rows)
+	case helper.IsLowCardinality(chType):
+		return s.lowCardinality(lowCardinalityDict(chType), rows)
+	case helper.IsArray(chType):
+		n, err := s.offsets(rows)
+		if err != nil {
+			return err
+		}
+		return s.data(chType[helper.LenArrayStr:len(chType)-1], n)
+	case helper.IsMap(chType):
+		n, err := s.offsets(rows)
+		if err != nil {
+			return err
+		}
+		rows = n
+	}
+	elems, err := elementTypes(chType)
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		if err := s.data(elem.ChType, rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// offsets reads past the offsets of rows arrays or maps and returns the
+// number of nested values.
+func (s *columnSkipper) offsets(rows uint64) (uint64, error) {
+	if rows == 0 {
+		return 0, nil
+	}
+	if err := s.discard((rows - 1) * 8); err != nil {
+		return 0, err
+	}
+	return s.r.Uint64()
+}
+
+// lowCardinality reads past rows values of a LowCardinality column with a
+// dictionary of type dict.
+func (s *columnSkipper) lowCardinality(dict []byte, rows uint64) error {
+	if rows == 0 {
+		return nil
+	}
+	serializationType, err := s.r.Uint64()
+	if err != nil {
+		return err
+	}
+	dictSize, err := s.r.Uint64()
+	if err != nil {
+		return err
+	}
+	if err := s.data(dict, dictSize); err != nil {
+		return err
+	}
+	indices, err := s.r.Uint64()
+	if err != nil {
+		return err
+	}
+	intType := serializationType & 0xf
+	if intType > 3 {
+		return fmt.Errorf("invalid LowCardinality index type %d", intType)
+	}
+	return s.discard(indices << intType)
+}
+
+func (s *columnSkipper) discard(n uint64) error {
+	if n == 0 {
+		return nil
+	}
+	if s.src != nil {
+		if n > uint64(s.src.Len()) {
+			return io.ErrUnexpectedEOF
+		}
+		_, err := s.src.Seek(int64(n), io.SeekCurrent)
+		return err
+	}
+	if s.buf == nil {
+		s.buf = make([]byte, 32*1024)
+	}
+	for n > 0 {
+		chunk := min(n, uint64(len(s.buf)))
+		if _, err := s.r.Read(s.buf[:chunk]); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
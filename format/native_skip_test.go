@@ -0,0 +1,77 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v3/internal/readerwriter"
+	"github.com/vahid-sohrabloo/chconn/v3/types"
+)
+
+// TestColumnSkipper checks that skipping the columns of a Native block ends
+// exactly where the block ends, both in memory and on a stream.
+func TestColumnSkipper(t *testing.T) {
+	defs := []string{
+		"i Int32", "s String", "fs FixedString(3)", "e Enum8('a' = 1, 'b' = 2)",
+		"d Decimal(20, 2)", "ts DateTime64(3, 'UTC')", "n Nullable(Int64)",
+		"lc LowCardinality(String)", "lcn LowCardinality(Nullable(String))",
+		"arr Array(LowCardinality(String))", "nested Array(Array(Nullable(UInt8)))",
+		"m Map(String, Array(Int16))", "tp Tuple(a Int8, b String)", "p Point", "poly Polygon",
+	}
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	row := []any{
+		int32(1), "xy", [3]byte{'a', 'b', 'c'}, int8(2),
+		types.Decimal128(types.Int128From64(1234)), ts, int64(7),
+		"v", "w",
+		[]string{"a", "b"}, [][]*uint8{{nil}, {}},
+		map[string][]int16{"k": {1, 2}}, []any{int8(1), "z"}, types.Point{Col1: 1, Col2: 2},
+		[][]types.Point{{{Col1: 1, Col2: 2}, {Col1: 3, Col2: 4}}},
+	}
+	for _, rows := range []int{0, 1, 3} {
+		cols := typedColumns(t, defs...)
+		for range rows {
+			for i, v := range row {
+				if err := cols[i].AppendAny(v); err != nil {
+					t.Fatalf("AppendAny %s: %v", cols[i].Name(), err)
+				}
+			}
+		}
+		var buf bytes.Buffer
+		if err := NewNativeWriter(&buf).WriteBlock(cols...); err != nil {
+			t.Fatal(err)
+		}
+		block := buf.Bytes()
+		for _, inMemory := range []bool{true, false} {
+			src := bytes.NewReader(block)
+			s := &columnSkipper{r: readerwriter.NewReader(src)}
+			if inMemory {
+				s.src = src
+			}
+			numCols, _ := s.r.Uvarint()
+			numRows, _ := s.r.Uvarint()
+			if int(numCols) != len(defs) || int(numRows) != rows {
+				t.Fatalf("block has %d columns and %d rows", numCols, numRows)
+			}
+			for range numCols {
+				name, _ := s.r.ByteString()
+				chType, _ := s.r.ByteString()
+				if !skippable(chType) {
+					t.Fatalf("%s %s is not skippable", name, chType)
+				}
+				if err := s.skip(chType, rows); err != nil {
+					t.Fatalf("rows %d: skip %s: %v", rows, name, err)
+				}
+			}
+			if src.Len() != 0 {
+				t.Fatalf("rows %d, in memory %v: %d bytes left", rows, inMemory, src.Len())
+			}
+		}
+	}
+
+	for _, chType := range []string{"Variant(Int8, String)", "Dynamic", "JSON", "Nothing", "Array(Dynamic)"} {
+		if skippable([]byte(chType)) {
+			t.Errorf("%s is skippable", chType)
+		}
+	}
+}
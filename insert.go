@@ -3,6 +3,7 @@ package chconn
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/vahid-sohrabloo/chconn/v3/column"
 )
@@ -12,13 +13,23 @@ type InsertStmt interface {
 	// Write writes a columns (a block of data) to the clickhouse server
 	// after each write you need to reset the columns. it will not reset automatically
 	Write(ctx context.Context, columns ...column.ColumnCore) error
+	// WriteEncoded writes a block of numRows rows whose columns are already serialized in the Native format.
+	// data[i] holds the data of the i-th column of Columns (its serialization prefix followed by its values,
+	// without the column name and type) and is sent without decoding, so it must be encoded for exactly
+	// the column type the server sent.
+	WriteEncoded(ctx context.Context, numRows int, data ...[]byte) error
 	// Append values of a row to the insert statement.
 	Append(values ...any) error
+	// Columns returns the columns Append writes to, created from the block header the server sent.
+	// Values appended to them directly are sent on Flush together with the appended rows.
+	Columns() ([]column.ColumnCore, error)
 	// Flush flushes the data to the clickhouse server and close the statement
 	Flush(ctx context.Context) error
 	// Close close the statement and release the connection
 	// close will be called automatically after Flush
 	Close()
+	// Stats returns the statistics of the insert. They are complete after Flush.
+	Stats() *QueryStats
 }
 
 type insertStmt struct {
@@ -28,18 +39,34 @@ type insertStmt struct {
 	query        string
 	queryOptions *QueryOptions
 	clientInfo   *ClientInfo
+	traceCtx     context.Context
+	start        time.Time
+	rows         uint64
+	stats        *QueryStats
+	lastErr      error
 	hasError     bool
 	closed       bool
 	finishInsert bool
 }
 
 func (s *insertStmt) Flush(ctx context.Context) error {
-	defer s.Close()
+	err := s.flush(ctx)
+	if err != nil {
+		s.lastErr = err
+	}
+	s.Close()
+	return err
+}
+
+func (s *insertStmt) flush(ctx context.Context) error {
 	if s.columns != nil {
-		err := s.Write(ctx, s.columns...)
-		if err != nil {
-			s.hasError = true
-			return err
+		// the rows may already be sent by Write if the columns from Columns were used directly
+		if len(s.columns) > 0 && s.columns[0].NumRow() > 0 {
+			err := s.Write(ctx, s.columns...)
+			if err != nil {
+				s.hasError = true
+				return err
+			}
 		}
 
 		for _, col := range s.columns {
@@ -84,7 +111,7 @@ func (s *insertStmt) Flush(ctx context.Context) error {
 	}
 
 	s.hasError = true
-	return &unexpectedPacket{expected: "serverData", actual: res}
+	return s.conn.unexpected("serverData", res)
 }
 
 // Close close the statement and release the connection
@@ -100,10 +127,26 @@ func (s *insertStmt) Close() {
 		if s.hasError || !s.finishInsert {
 			s.conn.Close()
 		}
+		s.conn.endQuery(s.traceCtx, s.start, &QueryDone{
+			Query:   s.query,
+			QueryID: s.queryOptions.QueryID,
+			Insert:  true,
+			Rows:    s.rows,
+			Stats:   s.stats,
+			Err:     s.lastErr,
+		})
 	}
 }
 
 func (s *insertStmt) Write(ctx context.Context, columns ...column.ColumnCore) error {
+	err := s.write(ctx, columns...)
+	if err != nil {
+		s.lastErr = err
+	}
+	return err
+}
+
+func (s *insertStmt) write(ctx context.Context, columns ...column.ColumnCore) error {
 	if int(s.block.NumColumns) != len(columns) {
 		return &InsertError{
 			err: &ColumnNumberWriteError{
@@ -116,7 +159,7 @@ func (s *insertStmt) Write(ctx context.Context, columns ...column.ColumnCore) er
 
 	var err error
 	if len(columns[0].Name()) != 0 {
-		columns, err = s.block.reorderColumns(columns)
+		columns, _, err = s.block.reorderColumns(columns)
 		if err != nil {
 			s.hasError = true
 			return &InsertError{
@@ -163,17 +206,75 @@ func (s *insertStmt) Write(ctx context.Context, columns ...column.ColumnCore) er
 			remoteAddr: s.conn.RawConn().RemoteAddr(),
 		}
 	}
+	s.rows += uint64(columns[0].NumRow())
 	return nil
 }
 
-func (s *insertStmt) Append(values ...any) error {
+func (s *insertStmt) WriteEncoded(ctx context.Context, numRows int, data ...[]byte) error {
+	err := s.writeEncoded(ctx, numRows, data)
+	if err != nil {
+		s.lastErr = err
+	}
+	return err
+}
+
+func (s *insertStmt) writeEncoded(ctx context.Context, numRows int, data [][]byte) error {
+	if int(s.block.NumColumns) != len(data) {
+		return &InsertError{
+			err: &ColumnNumberWriteError{
+				WriteColumn: len(data),
+				NeedColumn:  s.block.NumColumns,
+			},
+			remoteAddr: s.conn.RawConn().RemoteAddr(),
+		}
+	}
+
+	if ctx != context.Background() {
+		select {
+		case <-ctx.Done():
+			return newContextAlreadyDoneError(ctx)
+		default:
+		}
+		s.conn.contextWatcher.Watch(ctx)
+		defer s.conn.contextWatcher.Unwatch()
+	}
+
+	err := s.conn.sendData(s.block, numRows)
+	if err != nil {
+		s.hasError = true
+		return &InsertError{
+			err:        err,
+			remoteAddr: s.conn.RawConn().RemoteAddr(),
+		}
+	}
+
+	err = s.block.writeEncodedColumns(data)
+	if err != nil {
+		s.hasError = true
+		return &InsertError{
+			err:        err,
+			remoteAddr: s.conn.RawConn().RemoteAddr(),
+		}
+	}
+	s.rows += uint64(numRows)
+	return nil
+}
+
+func (s *insertStmt) Columns() ([]column.ColumnCore, error) {
 	if s.columns == nil {
 		columns, err := s.block.getColumnsByChType()
 		if err != nil {
-			return fmt.Errorf("could not get columns for insert statement: %w", err)
+			return nil, fmt.Errorf("could not get columns for insert statement: %w", err)
 		}
 		s.columns = columns
 	}
+	return s.columns, nil
+}
+
+func (s *insertStmt) Append(values ...any) error {
+	if _, err := s.Columns(); err != nil {
+		return err
+	}
 
 	if len(values) != len(s.columns) {
 		return &InsertError{
@@ -242,8 +343,8 @@ func (ch *conn) InsertStreamWithOption(
 	ctx context.Context,
 	query string,
 	queryOptions *QueryOptions,
-) (InsertStmt, error) {
-	err := ch.lock()
+) (stmt InsertStmt, err error) {
+	err = ch.lock()
 	if err != nil {
 		return nil, err
 	}
@@ -269,11 +370,28 @@ func (ch *conn) InsertStreamWithOption(
 		queryOptions = emptyQueryOptions
 	}
 
-	err = ch.sendQueryWithOption(query, queryOptions.QueryID, queryOptions.Settings, queryOptions.Parameters)
+	start := time.Now()
+	traceCtx := ch.startQuery(ctx, query, queryOptions, true)
+	var stats *QueryStats
+	defer func() {
+		// Otherwise the query is finished by the statement.
+		if stmt == nil {
+			ch.endQuery(traceCtx, start, &QueryDone{
+				Query:   query,
+				QueryID: queryOptions.QueryID,
+				Insert:  true,
+				Stats:   stats,
+				Err:     err,
+			})
+		}
+	}()
+
+	err = ch.sendQueryWithOption(query, queryOptions)
 	if err != nil {
 		hasError = true
 		return nil, preferContextOverNetTimeoutError(ctx, err)
 	}
+	stats = ch.queryStats
 	var blockData *block
 	var res any
 	res, err = ch.receiveAndProcessData(queryOptions)
@@ -290,7 +408,7 @@ func (ch *conn) InsertStreamWithOption(
 		blockData = b
 	} else {
 		hasError = true
-		return nil, &unexpectedPacket{expected: "serverData", actual: res}
+		return nil, ch.unexpected("serverData", res)
 	}
 
 	err = blockData.readColumnsHeader()
@@ -305,7 +423,14 @@ func (ch *conn) InsertStreamWithOption(
 		block:        blockData,
 		queryOptions: queryOptions,
 		clientInfo:   nil,
+		traceCtx:     traceCtx,
+		start:        start,
+		stats:        stats,
 	}
 
 	return s, nil
 }
+
+func (s *insertStmt) Stats() *QueryStats {
+	return s.stats
+}
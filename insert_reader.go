@@ -0,0 +1,166 @@
+package chconn
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+func (ch *conn) InsertFromReader(ctx context.Context, query string, r io.Reader) error {
+	return ch.InsertFromReaderWithOption(ctx, query, r, nil)
+}
+
+func (ch *conn) InsertFromReaderWithOption(
+	ctx context.Context,
+	query string,
+	r io.Reader,
+	queryOptions *QueryOptions,
+) error {
+	size, ok := readerLen(r)
+	if !ok {
+		size = -1
+	}
+	return ch.insertFromReader(ctx, query, r, size, queryOptions)
+}
+
+func (ch *conn) InsertFromSizedReader(
+	ctx context.Context,
+	query string,
+	r io.Reader,
+	size int64,
+	queryOptions *QueryOptions,
+) error {
+	if size < 0 {
+		return fmt.Errorf("insert from reader: negative size %d", size)
+	}
+	return ch.insertFromReader(ctx, query, r, size, queryOptions)
+}
+
+// insertFromReader sends the size bytes of r as the data of an insert query. A negative size reads r into memory
+// until EOF first.
+func (ch *conn) insertFromReader(
+	ctx context.Context,
+	query string,
+	r io.Reader,
+	dataLen int64,
+	queryOptions *QueryOptions,
+) (err error) {
+	if queryOptions == nil {
+		queryOptions = emptyQueryOptions
+	}
+
+	err = ch.lock()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ch.unlock()
+		if err != nil {
+			ch.Close()
+		}
+	}()
+
+	if ctx != context.Background() {
+		select {
+		case <-ctx.Done():
+			return newContextAlreadyDoneError(ctx)
+		default:
+		}
+		ch.contextWatcher.Watch(ctx)
+		defer ch.contextWatcher.Unwatch()
+	}
+
+	if dataLen < 0 {
+		// The length of the query must be sent before the data.
+		data, err := io.ReadAll(ctxReader{ctx: ctx, r: r})
+		if err != nil {
+			return &readError{"insert from reader: read data", err}
+		}
+		r, dataLen = bytes.NewReader(data), int64(len(data))
+	}
+
+	start := time.Now()
+	traceCtx := ch.startQuery(ctx, query, queryOptions, true)
+	var stats *QueryStats
+	defer func() {
+		done := &QueryDone{Query: query, QueryID: queryOptions.QueryID, Insert: true, Stats: stats, Err: err}
+		if stats != nil {
+			// The rows are counted by the server.
+			done.Rows = stats.WrittenRows
+		}
+		ch.endQuery(traceCtx, start, done)
+	}()
+
+	err = ch.sendQueryWithData(query, r, dataLen, queryOptions)
+	if err != nil {
+		return preferContextOverNetTimeoutError(ctx, err)
+	}
+	stats = ch.queryStats
+
+	for {
+		res, err := ch.receiveAndProcessData(queryOptions)
+		if err != nil {
+			return preferContextOverNetTimeoutError(ctx, err)
+		}
+		if res == nil {
+			return nil
+		}
+		if _, ok := res.(*block); !ok {
+			return ch.unexpected("serverData", res)
+		}
+	}
+}
+
+// writeQueryData writes the query string of an insert query followed by its inline data. The data is copied from
+// data to the connection without buffering it.
+func (ch *conn) writeQueryData(query string, data io.Reader, dataLen int64) error {
+	query += "\n"
+	ch.writer.Uvarint(uint64(len(query)) + uint64(dataLen))
+	ch.writer.Write([]byte(query))
+	if _, err := ch.writer.WriteTo(ch.writerTo); err != nil {
+		return &writeError{"write query", err}
+	}
+	if _, err := io.CopyN(ch.writerTo, data, dataLen); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return &writeError{"write insert data", err}
+	}
+	return nil
+}
+
+// ctxReader stops reading r once ctx is done.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// readerLen returns the number of bytes left in r, if it can be known without reading r.
+func readerLen(r io.Reader) (int64, bool) {
+	switch r := r.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len()), true
+	case *os.File:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0, false
+		}
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		return info.Size() - offset, true
+	}
+	return 0, false
+}
@@ -0,0 +1,112 @@
+package chconn
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+func TestInsertFromReader(t *testing.T) {
+	t.Parallel()
+
+	c, err := Connect(context.Background(), os.Getenv("CHX_TEST_TCP_CONN_STRING"))
+	require.NoError(t, err)
+	defer c.Close()
+
+	err = c.ExecScript(context.Background(), `DROP TABLE IF EXISTS test_insert_from_reader;
+CREATE TABLE test_insert_from_reader (id UInt64, name String) ENGINE = Memory;`)
+	require.NoError(t, err)
+
+	var progressRows uint64
+	err = c.InsertFromReaderWithOption(context.Background(), "INSERT INTO test_insert_from_reader FORMAT CSV",
+		strings.NewReader("1,\"a,b\"\n2,c\n"), &QueryOptions{
+			OnProgress: func(p *Progress) {
+				progressRows += p.WriterRows
+			},
+		})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), progressRows)
+
+	// a reader of unknown length
+	err = c.InsertFromReader(context.Background(), "INSERT INTO test_insert_from_reader FORMAT JSONEachRow",
+		io.MultiReader(strings.NewReader(`{"id": 3, "name": "d"}`), strings.NewReader(`{"id": 4, "name": "e"}`)))
+	require.NoError(t, err)
+
+	// a reader of a size known to the caller, followed by more data
+	err = c.InsertFromSizedReader(context.Background(), "INSERT INTO test_insert_from_reader FORMAT CSV",
+		io.MultiReader(strings.NewReader("5,f\n"), strings.NewReader("6,g\n")), 4, nil)
+	require.NoError(t, err)
+
+	id := column.New[uint64]()
+	name := column.NewString()
+	stmt, err := c.Select(context.Background(), "SELECT id, name FROM test_insert_from_reader ORDER BY id", id, name)
+	require.NoError(t, err)
+	var ids []uint64
+	var names []string
+	for stmt.Next() {
+		ids = id.Read(ids)
+		names = name.Read(names)
+	}
+	require.NoError(t, stmt.Err())
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, ids)
+	assert.Equal(t, []string{"a,b", "c", "d", "e", "f"}, names)
+
+	err = c.InsertFromSizedReader(context.Background(), "INSERT INTO test_insert_from_reader FORMAT CSV",
+		io.MultiReader(strings.NewReader("7,h\n")), 10, nil)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.True(t, c.IsClosed())
+
+	c, err = Connect(context.Background(), os.Getenv("CHX_TEST_TCP_CONN_STRING"))
+	require.NoError(t, err)
+	defer c.Close()
+
+	err = c.InsertFromReader(context.Background(), "INSERT INTO test_insert_from_reader FORMAT CSV",
+		strings.NewReader("x,y\n"))
+	var chErr *ChError
+	require.ErrorAs(t, err, &chErr)
+	assert.True(t, c.IsClosed())
+}
+
+func TestReaderLen(t *testing.T) {
+	t.Parallel()
+
+	n, ok := readerLen(bytes.NewReader([]byte("abc")))
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), n)
+
+	path := filepath.Join(t.TempDir(), "data.csv")
+	require.NoError(t, os.WriteFile(path, []byte("1,a\n2,b\n"), 0o600))
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = f.Seek(4, io.SeekStart)
+	require.NoError(t, err)
+	n, ok = readerLen(f)
+	assert.True(t, ok)
+	assert.Equal(t, int64(4), n)
+
+	_, ok = readerLen(io.MultiReader(strings.NewReader("abc")))
+	assert.False(t, ok)
+}
+
+func TestCtxReader(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := ctxReader{ctx: ctx, r: strings.NewReader("abc")}
+	buf := make([]byte, 2)
+	n, err := r.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	cancel()
+	_, err = io.ReadAll(r)
+	require.ErrorIs(t, err, context.Canceled)
+}
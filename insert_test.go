@@ -2,6 +2,7 @@ package chconn
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"io"
 	"os"
@@ -662,3 +663,61 @@ func TestInsertIntoSelect(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestInsertStreamColumns(t *testing.T) {
+	t.Parallel()
+	conn := getConnection(t)
+
+	err := conn.Exec(context.Background(), "CREATE TEMPORARY TABLE test_insert_stream_columns (id UInt64, name String)")
+	require.NoError(t, err)
+
+	stmt, err := conn.InsertStream(context.Background(), "INSERT INTO test_insert_stream_columns VALUES")
+	require.NoError(t, err)
+	columns, err := stmt.Columns()
+	require.NoError(t, err)
+	require.Len(t, columns, 2)
+
+	columns[0].(*column.Base[uint64]).Append(1)
+	columns[1].(*column.String).Append("a")
+	require.NoError(t, stmt.Write(context.Background(), columns...))
+	for _, col := range columns {
+		col.Reset()
+	}
+	require.NoError(t, stmt.Append(uint64(2), "b"))
+	require.NoError(t, stmt.Flush(context.Background()))
+	assert.Equal(t, uint64(2), stmt.Stats().WrittenRows)
+
+	col := column.New[uint64]()
+	selectStmt, err := conn.Select(context.Background(), "SELECT sum(id) FROM test_insert_stream_columns", col)
+	require.NoError(t, err)
+	for selectStmt.Next() {
+	}
+	require.NoError(t, selectStmt.Err())
+	assert.Equal(t, []uint64{3}, col.Data())
+}
+
+func TestInsertStreamWriteEncoded(t *testing.T) {
+	t.Parallel()
+	conn := getConnection(t)
+
+	err := conn.Exec(context.Background(), "CREATE TEMPORARY TABLE test_insert_write_encoded (id UInt64, name String)")
+	require.NoError(t, err)
+
+	stmt, err := conn.InsertStream(context.Background(), "INSERT INTO test_insert_write_encoded VALUES")
+	require.NoError(t, err)
+	ids := binary.LittleEndian.AppendUint64(nil, 1)
+	ids = binary.LittleEndian.AppendUint64(ids, 2)
+	names := []byte{1, 'a', 2, 'b', 'c'}
+	require.NoError(t, stmt.WriteEncoded(context.Background(), 2, ids, names))
+	require.Error(t, stmt.WriteEncoded(context.Background(), 1, ids))
+	require.NoError(t, stmt.Flush(context.Background()))
+	assert.Equal(t, uint64(2), stmt.Stats().WrittenRows)
+
+	col := column.NewString()
+	selectStmt, err := conn.Select(context.Background(), "SELECT name FROM test_insert_write_encoded ORDER BY id", col)
+	require.NoError(t, err)
+	for selectStmt.Next() {
+	}
+	require.NoError(t, selectStmt.Err())
+	assert.Equal(t, []string{"a", "bc"}, col.Data())
+}
@@ -0,0 +1,102 @@
+package helper
+
+import "strings"
+
+// Statement is a statement of a script split by SplitScript.
+type Statement struct {
+	Query string
+	// Line is the line of the script the statement starts on, starting from 1.
+	Line int
+}
+
+// SplitStatements splits a script of semicolon-separated SQL statements. See SplitScript.
+func SplitStatements(script string) []string {
+	var stmts []string
+	for _, stmt := range SplitScript(script) {
+		stmts = append(stmts, stmt.Query)
+	}
+	return stmts
+}
+
+// SplitScript splits a script of semicolon-separated SQL statements. Semicolons in
+// quoted strings, quoted identifiers, heredocs ($$...$$ or $tag$...$tag$) and comments
+// don't end a statement. Statements are trimmed, and statements that contain only
+// whitespace and comments are dropped.
+func SplitScript(script string) []Statement {
+	var stmts []Statement
+	start := 0
+	hasCode := false
+	// line is the line of lineOffset, the offset up to which the newlines have been counted.
+	line, lineOffset := 1, 0
+	add := func(end int) {
+		if hasCode {
+			query := strings.TrimLeft(script[start:end], " \t\n\r")
+			offset := end - len(query)
+			query = strings.TrimSpace(query)
+			line += strings.Count(script[lineOffset:offset], "\n")
+			lineOffset = offset
+			stmts = append(stmts, Statement{Query: query, Line: line})
+		}
+		start = end + 1
+		hasCode = false
+	}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			hasCode = true
+			for i++; i < len(script) && script[i] != c; i++ {
+				if script[i] == '\\' {
+					i++
+				}
+			}
+		case c == '-' && i+1 < len(script) && script[i+1] == '-',
+			c == '#' && i+1 < len(script) && (script[i+1] == ' ' || script[i+1] == '!'):
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(script) && script[i+1] == '*':
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end + 3
+			}
+		case c == '$':
+			hasCode = true
+			if tag := heredocTag(script[i:]); tag != "" {
+				end := strings.Index(script[i+len(tag):], tag)
+				if end < 0 {
+					i = len(script)
+				} else {
+					i += len(tag) + end + len(tag) - 1
+				}
+			}
+		case c == ';':
+			add(i)
+		case c != ' ' && c != '\t' && c != '\n' && c != '\r':
+			hasCode = true
+		}
+	}
+	if start < len(script) {
+		add(len(script))
+	}
+	return stmts
+}
+
+// heredocTag returns the opening tag of the heredoc s starts with, e.g. "$$" or "$doc$", or ""
+// if s doesn't start with a heredoc.
+func heredocTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '$':
+			return s[:i+1]
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 1 && c >= '0' && c <= '9':
+		default:
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"empty", "", nil},
+		{"single without semicolon", "SELECT 1", []string{"SELECT 1"}},
+		{"two statements", "SELECT 1;\nSELECT 2;\n", []string{"SELECT 1", "SELECT 2"}},
+		{"empty statements", ";; SELECT 1 ;;", []string{"SELECT 1"}},
+		{"semicolon in string", "SELECT 'a;b', 'it\\'s;'; SELECT 2", []string{"SELECT 'a;b', 'it\\'s;'", "SELECT 2"}},
+		{"semicolon in identifiers", "SELECT `a;b`, \"c;d\" FROM t", []string{"SELECT `a;b`, \"c;d\" FROM t"}},
+		{"line comments", "-- first; comment\nSELECT 1; # second; comment\nSELECT 2", []string{
+			"-- first; comment\nSELECT 1", "# second; comment\nSELECT 2",
+		}},
+		{"block comment", "SELECT /* ; */ 1;", []string{"SELECT /* ; */ 1"}},
+		{"comment only", "SELECT 1;\n-- trailing; comment\n/* done */", []string{"SELECT 1"}},
+		{"hash without space", "SELECT 1 #; 2", []string{"SELECT 1 #", "2"}},
+		{"heredoc", "SELECT $$a;b$$; SELECT $doc$it's; $$ $doc$", []string{"SELECT $$a;b$$", "SELECT $doc$it's; $$ $doc$"}},
+		{"dollar without heredoc", "SELECT $1; SELECT 2", []string{"SELECT $1", "SELECT 2"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SplitStatements(tc.input)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("SplitStatements(%q) = %q, want %q", tc.input, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSplitScriptLines(t *testing.T) {
+	script := "CREATE DATABASE db;\n\n-- table\nCREATE TABLE db.t (\n  s String\n) ENGINE = Memory;\n  INSERT INTO db.t SELECT 'a;\nb'; SELECT 1"
+	expected := []Statement{
+		{Query: "CREATE DATABASE db", Line: 1},
+		{Query: "-- table\nCREATE TABLE db.t (\n  s String\n) ENGINE = Memory", Line: 3},
+		{Query: "INSERT INTO db.t SELECT 'a;\nb'", Line: 7},
+		{Query: "SELECT 1", Line: 8},
+	}
+	got := SplitScript(script)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("SplitScript(%q) = %+v, want %+v", script, got, expected)
+	}
+}
@@ -20,7 +20,7 @@ func ExtractEnum(data []byte) (intToStringMap map[int16]string, stringToIntMap m
 			return nil, nil, fmt.Errorf("invalid enum: %s", enum)
 		}
 
-		id, err := strconv.ParseInt(string(parts[1]), 10, 8)
+		id, err := strconv.ParseInt(string(parts[1]), 10, 16)
 		if err != nil {
 			return nil, nil, fmt.Errorf("invalid enum id: %s", parts[1])
 		}
@@ -209,9 +209,9 @@ func SplitNameType(b []byte) (ColumnData, error) {
 	if b[0] == '`' {
 		b = b[1:]
 		for i, char := range b {
-			if char == '`' && b[i-1] != '\\' {
+			if char == '`' && (i == 0 || b[i-1] != '\\') {
 				return ColumnData{
-					Name:   b[1 : i+1],
+					Name:   b[:i],
 					ChType: b[i+2:],
 				}, nil
 			}
@@ -224,7 +224,7 @@ func SplitNameType(b []byte) (ColumnData, error) {
 		}
 		if char == ' ' {
 			return ColumnData{
-				Name:   b[1 : i+1],
+				Name:   b[:i],
 				ChType: b[i+1:],
 			}, nil
 		}
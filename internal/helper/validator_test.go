@@ -0,0 +1,44 @@
+package helper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTypesInParentheses(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []ColumnData
+	}{
+		{"Int32, String", []ColumnData{{ChType: []byte("Int32")}, {ChType: []byte("String")}}},
+		{"a Int32, bc Map(String, Array(UInt8))", []ColumnData{
+			{Name: []byte("a"), ChType: []byte("Int32")},
+			{Name: []byte("bc"), ChType: []byte("Map(String, Array(UInt8))")},
+		}},
+		{"`x, y` Nullable(String), `z` Tuple(a Int8)", []ColumnData{
+			{Name: []byte("x, y"), ChType: []byte("Nullable(String)")},
+			{Name: []byte("z"), ChType: []byte("Tuple(a Int8)")},
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := TypesInParentheses([]byte(tc.input))
+			if err != nil {
+				t.Fatalf("TypesInParentheses(%q): %v", tc.input, err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("TypesInParentheses(%q) = %q, expected %q", tc.input, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestExtractEnum16(t *testing.T) {
+	intToString, stringToInt, err := ExtractEnum([]byte("'a' = 1000, 'b' = -5"))
+	if err != nil {
+		t.Fatalf("ExtractEnum: %v", err)
+	}
+	if intToString[1000] != "a" || stringToInt["b"] != -5 {
+		t.Errorf("ExtractEnum = %v, %v", intToString, stringToInt)
+	}
+}
@@ -0,0 +1,77 @@
+package readerwriter
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+)
+
+// maxChunkSize is the largest chunk sent by ChunkedWriter.
+const maxChunkSize = 1 << 30
+
+// chunkedReader reads the chunked packet framing of the native protocol. Each chunk is its
+// size as a little-endian UInt32 followed by the data, and a packet ends with an empty chunk.
+type chunkedReader struct {
+	reader io.Reader
+	left   uint32
+	header [4]byte
+}
+
+// NewChunkedReader returns a reader of the data of the chunks read from r.
+func NewChunkedReader(r io.Reader) io.Reader {
+	return &chunkedReader{reader: r}
+}
+
+func (r *chunkedReader) Read(buf []byte) (int, error) {
+	if len(buf) == 0 {
+		return 0, nil
+	}
+	// Empty chunks only mark the end of a packet.
+	for r.left == 0 {
+		if _, err := io.ReadFull(r.reader, r.header[:]); err != nil {
+			return 0, err
+		}
+		r.left = binary.LittleEndian.Uint32(r.header[:])
+	}
+	if uint64(len(buf)) > uint64(r.left) {
+		buf = buf[:r.left]
+	}
+	n, err := r.reader.Read(buf)
+	r.left -= uint32(n)
+	return n, err
+}
+
+// ChunkedWriter writes the chunked packet framing of the native protocol. Each Write is sent as
+// one or more chunks, and EndPacket terminates the current packet.
+type ChunkedWriter struct {
+	writer io.Writer
+	header [4]byte
+}
+
+// NewChunkedWriter returns a ChunkedWriter that writes chunks to w.
+func NewChunkedWriter(w io.Writer) *ChunkedWriter {
+	return &ChunkedWriter{writer: w}
+}
+
+func (cw *ChunkedWriter) Write(buf []byte) (int, error) {
+	var n int
+	for len(buf) > 0 {
+		size := min(len(buf), maxChunkSize)
+		binary.LittleEndian.PutUint32(cw.header[:], uint32(size))
+		// net.Buffers sends the header and the data with a single writev on a net.Conn.
+		bufs := net.Buffers{cw.header[:], buf[:size]}
+		if _, err := bufs.WriteTo(cw.writer); err != nil {
+			return n, err
+		}
+		n += size
+		buf = buf[size:]
+	}
+	return n, nil
+}
+
+// EndPacket writes the empty chunk that ends a packet.
+func (cw *ChunkedWriter) EndPacket() error {
+	binary.LittleEndian.PutUint32(cw.header[:], 0)
+	_, err := cw.writer.Write(cw.header[:])
+	return err
+}
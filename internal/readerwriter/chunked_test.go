@@ -0,0 +1,48 @@
+package readerwriter
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestChunkedRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewChunkedWriter(&buf)
+	if _, err := w.Write([]byte("hello ")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("world")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.EndPacket(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("!")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.EndPacket(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{6, 0, 0, 0, 'h', 'e', 'l', 'l', 'o', ' ', 5, 0, 0, 0, 'w', 'o', 'r', 'l', 'd', 0, 0, 0, 0, 1, 0, 0, 0, '!', 0, 0, 0, 0}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("written %v, want %v", buf.Bytes(), want)
+	}
+
+	r := NewReader(NewChunkedReader(&buf))
+	got, err := r.FixedString(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "hello world!" {
+		t.Fatalf("read %q, want %q", got, "hello world!")
+	}
+	// Only the terminator of the last packet is left.
+	if _, err := r.ReadByte(); err != io.EOF {
+		t.Fatalf("read after the last packet: got %v, want EOF", err)
+	}
+}
@@ -27,6 +27,10 @@ type compressReader struct {
 	raw    []byte
 	header []byte
 	zstd   *zstd.Decoder
+
+	// compressed and uncompressed are incremented by the size of every block read, if not nil.
+	compressed   *uint64
+	uncompressed *uint64
 }
 
 // NewCompressReader wrap the io.Reader
@@ -90,6 +94,12 @@ func (r *compressReader) readBlock() error {
 			DataSize:  dataSize,
 		}
 	}
+	if r.compressed != nil {
+		*r.compressed += uint64(len(r.raw))
+	}
+	if r.uncompressed != nil {
+		*r.uncompressed += uint64(dataSize)
+	}
 	//nolint:exhaustive
 	switch m := CompressMethod(r.header[hMethod]); m {
 	case CompressLZ4:
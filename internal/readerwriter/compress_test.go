@@ -0,0 +1,84 @@
+package readerwriter
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestCompressRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("compressible data "), 10000)
+	for _, tc := range []struct {
+		name   string
+		method CompressMethod
+		level  int
+		wire   CompressMethod
+	}{
+		{name: "checksum", method: CompressChecksum, wire: CompressChecksum},
+		{name: "lz4", method: CompressLZ4, wire: CompressLZ4},
+		{name: "lz4hc", method: CompressLZ4HC, wire: CompressLZ4},
+		{name: "lz4hc level 3", method: CompressLZ4HC, level: 3, wire: CompressLZ4},
+		{name: "zstd", method: CompressZSTD, wire: CompressZSTD},
+		{name: "zstd level 19", method: CompressZSTD, level: 19, wire: CompressZSTD},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			var sentCompressed, sentRaw uint64
+			w := NewCompressWriter(&buf, byte(CompressNone)).(*compressWriter)
+			w.SetMethod(byte(tc.method), tc.level)
+			w.SetCounters(&sentCompressed, &sentRaw)
+			if _, err := w.Write(data); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if got := CompressMethod(buf.Bytes()[hMethod]); got != tc.wire {
+				t.Fatalf("method byte 0x%02x, want 0x%02x", byte(got), byte(tc.wire))
+			}
+			if sentRaw != uint64(len(data)) || sentCompressed != uint64(buf.Len()) {
+				t.Fatalf("counted %d/%d bytes, want %d/%d", sentCompressed, sentRaw, buf.Len(), len(data))
+			}
+
+			var readCompressed, readRaw uint64
+			r := NewReader(&buf)
+			r.SetCompressCounters(&readCompressed, &readRaw)
+			r.SetCompress(true)
+			got := make([]byte, len(data))
+			if _, err := io.ReadFull(r, got); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatal("data mismatch")
+			}
+			if readCompressed != sentCompressed || readRaw != sentRaw {
+				t.Fatalf("read %d/%d bytes, want %d/%d", readCompressed, readRaw, sentCompressed, sentRaw)
+			}
+		})
+	}
+}
+
+func TestCompressZSTDEncoderPerSpeed(t *testing.T) {
+	w := NewCompressWriter(io.Discard, byte(CompressZSTD)).(*compressWriter)
+	encoders := func() int {
+		n := 0
+		for _, e := range w.zstd {
+			if e != nil {
+				n++
+			}
+		}
+		return n
+	}
+	for _, tc := range []struct{ level, want int }{{0, 1}, {3, 1}, {5, 1}, {19, 2}, {22, 2}, {1, 3}, {4, 3}} {
+		w.SetMethod(byte(CompressZSTD), tc.level)
+		if _, err := w.Write([]byte("data")); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if got := encoders(); got != tc.want {
+			t.Fatalf("level %d: %d encoders, want %d", tc.level, got, tc.want)
+		}
+	}
+}
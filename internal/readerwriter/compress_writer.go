@@ -23,9 +23,18 @@ type compressWriter struct {
 	zdata []byte
 	// compression method
 	method CompressMethod
+	// compression level, 0 for the default of the method
+	level int
 
-	lz4  *lz4.Compressor
-	zstd *zstd.Encoder
+	lz4   *lz4.Compressor
+	lz4hc *lz4.CompressorHC
+	// zstd holds one encoder per encoder speed, built on first use: ZSTD levels map onto the
+	// four speeds of the encoder, so switching levels reuses the encoder of the speed.
+	zstd [zstd.SpeedBestCompression + 1]*zstd.Encoder
+
+	// compressed and uncompressed are incremented by the size of every block written, if not nil.
+	compressed   *uint64
+	uncompressed *uint64
 }
 
 // NewCompressWriter wrap the io.Writer
@@ -38,6 +47,30 @@ func NewCompressWriter(w io.Writer, method byte) io.Writer {
 	return p
 }
 
+// SetMethod sets the compression method and level of the next blocks. Level 0 uses the default
+// level of the method; it is ignored by LZ4 and checksum-only blocks. Buffered data must have
+// been flushed.
+func (cw *compressWriter) SetMethod(method byte, level int) {
+	cw.method = CompressMethod(method)
+	cw.level = level
+}
+
+// SetCounters sets the counters incremented by the compressed size of every block written and
+// by its size before compression. Nil counters are not updated.
+func (cw *compressWriter) SetCounters(compressed, uncompressed *uint64) {
+	cw.compressed = compressed
+	cw.uncompressed = uncompressed
+}
+
+// lz4hcLevel returns the lz4 search depth of an LZ4HC level from 1 to 9 (9 for 0 or above 9, as in
+// ClickHouse).
+func lz4hcLevel(level int) lz4.CompressionLevel {
+	if level <= 0 || level > 9 {
+		level = 9
+	}
+	return lz4.CompressionLevel(1 << (8 + level))
+}
+
 func (cw *compressWriter) Write(buf []byte) (int, error) {
 	var n int
 	for len(buf) > 0 {
@@ -66,6 +99,9 @@ func (cw *compressWriter) Flush() error {
 	cw.zdata = append(cw.zdata[:0], make([]byte, maxSize+headerSize)...)
 	_ = cw.zdata[:headerSize]
 	cw.zdata[hMethod] = byte(cw.method)
+	if cw.method == CompressLZ4HC {
+		cw.zdata[hMethod] = byte(CompressLZ4)
+	}
 
 	var n int
 	//nolint:exhaustive
@@ -79,19 +115,35 @@ func (cw *compressWriter) Flush() error {
 			return fmt.Errorf("lz4 compress error: %v", err)
 		}
 		n = compressedSize
+	case CompressLZ4HC:
+		if cw.lz4hc == nil {
+			cw.lz4hc = &lz4.CompressorHC{}
+		}
+		cw.lz4hc.Level = lz4hcLevel(cw.level)
+		compressedSize, err := cw.lz4hc.CompressBlock(cw.data[:cw.pos], cw.zdata[headerSize:])
+		if err != nil {
+			return fmt.Errorf("lz4hc compress error: %v", err)
+		}
+		n = compressedSize
 	case CompressZSTD:
-		if cw.zstd == nil {
-			zw, err := zstd.NewWriter(nil,
-				zstd.WithEncoderLevel(zstd.SpeedDefault),
+		encoderLevel := zstd.SpeedDefault
+		if cw.level != 0 {
+			encoderLevel = zstd.EncoderLevelFromZstd(cw.level)
+		}
+		zw := cw.zstd[encoderLevel]
+		if zw == nil {
+			var err error
+			zw, err = zstd.NewWriter(nil,
+				zstd.WithEncoderLevel(encoderLevel),
 				zstd.WithEncoderConcurrency(1),
 				zstd.WithLowerEncoderMem(true),
 			)
 			if err != nil {
 				return fmt.Errorf("zstd new error: %v", err)
 			}
-			cw.zstd = zw
+			cw.zstd[encoderLevel] = zw
 		}
-		cw.zdata = cw.zstd.EncodeAll(cw.data[:cw.pos], cw.zdata[:headerSize])
+		cw.zdata = zw.EncodeAll(cw.data[:cw.pos], cw.zdata[:headerSize])
 		n = len(cw.zdata) - headerSize
 	case CompressChecksum:
 		n = copy(cw.zdata[headerSize:], cw.data[:cw.pos])
@@ -105,6 +157,13 @@ func (cw *compressWriter) Flush() error {
 	binary.LittleEndian.PutUint64(cw.zdata[0:8], h.Low)
 	binary.LittleEndian.PutUint64(cw.zdata[8:16], h.High)
 
+	if cw.compressed != nil {
+		*cw.compressed += uint64(len(cw.zdata))
+	}
+	if cw.uncompressed != nil {
+		*cw.uncompressed += uint64(cw.pos)
+	}
+
 	_, err := cw.writer.Write(cw.zdata)
 	cw.pos = 0
 	return err
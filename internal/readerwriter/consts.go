@@ -27,6 +27,9 @@ const (
 	CompressChecksum CompressMethod = 0x02
 	CompressLZ4      CompressMethod = 0x82
 	CompressZSTD     CompressMethod = 0x90
+	// CompressLZ4HC compresses with the high-compression LZ4 encoder. It is not sent on the wire:
+	// its blocks are LZ4 blocks, marked CompressLZ4.
+	CompressLZ4HC CompressMethod = 0x83
 )
 
 // Constants for compression encoding.
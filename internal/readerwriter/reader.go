@@ -10,8 +10,11 @@ import (
 type Reader struct {
 	mainReader     io.Reader
 	input          io.Reader
-	compressReader io.Reader
+	compressReader *compressReader
 	scratch        [binary.MaxVarintLen64]byte
+
+	compressed   *uint64
+	uncompressed *uint64
 }
 
 // NewReader get new Reader
@@ -22,11 +25,25 @@ func NewReader(input io.Reader) *Reader {
 	}
 }
 
+// SetMainReader replaces the underlying reader, e.g. to read chunked packets after the handshake.
+// It must only be called between packets.
+func (r *Reader) SetMainReader(input io.Reader) {
+	r.mainReader = input
+	r.input = input
+	r.compressReader = nil
+}
+
+// MainReader returns the underlying reader.
+func (r *Reader) MainReader() io.Reader {
+	return r.mainReader
+}
+
 // SetCompress set compress status
 func (r *Reader) SetCompress(c bool) {
 	if c {
 		if r.compressReader == nil {
-			r.compressReader = NewCompressReader(r.mainReader)
+			r.compressReader = NewCompressReader(r.mainReader).(*compressReader)
+			r.compressReader.compressed, r.compressReader.uncompressed = r.compressed, r.uncompressed
 		}
 		r.input = r.compressReader
 		return
@@ -34,6 +51,15 @@ func (r *Reader) SetCompress(c bool) {
 	r.input = r.mainReader
 }
 
+// SetCompressCounters sets the counters incremented by the compressed size of every compressed
+// block read and by its size after decompression. Nil counters are not updated.
+func (r *Reader) SetCompressCounters(compressed, uncompressed *uint64) {
+	r.compressed, r.uncompressed = compressed, uncompressed
+	if r.compressReader != nil {
+		r.compressReader.compressed, r.compressReader.uncompressed = compressed, uncompressed
+	}
+}
+
 // Uvarint read variable uint64 value
 func (r *Reader) Uvarint() (uint64, error) {
 	return binary.ReadUvarint(r)
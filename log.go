@@ -0,0 +1,22 @@
+package chconn
+
+import (
+	"context"
+	"log/slog"
+)
+
+// log writes a record to Config.Logger. It does nothing if the logger is not set.
+func (c *Config) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if c.Logger == nil || !c.Logger.Enabled(ctx, level) {
+		return
+	}
+	c.Logger.Log(ctx, level, msg, args...)
+}
+
+// unexpected logs an unexpected packet received from the server and returns its error.
+func (ch *conn) unexpected(expected string, actual any) error {
+	err := &unexpectedPacket{expected: expected, actual: actual}
+	ch.config.log(context.Background(), slog.LevelWarn, "unexpected packet from server",
+		"expected", expected, "err", err)
+	return err
+}
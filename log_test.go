@@ -0,0 +1,35 @@
+package chconn
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogConnectError(t *testing.T) {
+	t.Parallel()
+
+	for _, connString := range []string{
+		"host=127.0.0.1 port=1 user=u password=secret",
+		"host=127.0.0.1 port=1 user=u jwt=secret",
+		"clickhouse://u@127.0.0.1:1/default?jwt=secret",
+	} {
+		config, err := ParseConfig(connString)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		config.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		_, err = ConnectConfig(context.Background(), config)
+		require.Error(t, err)
+
+		logs := buf.String()
+		assert.Contains(t, logs, `level=DEBUG msg=connecting host=127.0.0.1 port=1 user=u database=default`)
+		assert.Contains(t, logs, `level=WARN msg="connect attempt failed" host=127.0.0.1 port=1`)
+		assert.Contains(t, logs, `level=ERROR msg="connect failed" host=127.0.0.1 port=1 user=u`)
+		assert.NotContains(t, logs, "secret", connString)
+	}
+}
@@ -0,0 +1,395 @@
+// Package migrate applies versioned SQL migrations to ClickHouse over the native protocol.
+//
+// Migrations are loaded with [Load] from a directory of .sql files, usually with os.DirFS or
+// embed.FS, and applied in version order with [Migrator.Up]. A migration file can hold several
+// statements separated by semicolons. Before they are run, migration scripts are executed as
+// text/template templates, so a file can use {{.OnCluster}} to run its DDL on the cluster set in
+// [Options.Cluster]:
+//
+//	CREATE TABLE events {{.OnCluster}} (id UInt64) ENGINE = ReplicatedMergeTree ORDER BY id;
+//	ALTER TABLE events {{.OnCluster}} ADD COLUMN name String;
+//
+// The state of each version is appended to a versions table. A migration is marked dirty before
+// its first statement and clean after its last one, so a migration that fails half way leaves the
+// version dirty. A dirty version stops [Migrator.Up] and [Migrator.Down] until it is fixed by hand
+// and marked with [Migrator.Force].
+//
+// ClickHouse has no DDL transactions or locks, so only one migrator should run at a time.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v3"
+	"github.com/vahid-sohrabloo/chconn/v3/internal/helper"
+)
+
+// DefaultTable is the name of the versions table when Options.Table is empty.
+const DefaultTable = "schema_migrations"
+
+// ErrNoChange is returned by Down when there is no applied migration to revert.
+var ErrNoChange = errors.New("migrate: no change")
+
+// DB is the connection used to run migrations. It is satisfied by chconn.Conn and chpool.Pool.
+type DB interface {
+	chconn.Querier
+	ExecWithOption(ctx context.Context, query string, queryOptions *chconn.QueryOptions) error
+}
+
+// Options configures a Migrator.
+type Options struct {
+	// Table is the versions table. It may be qualified with a database. Default: schema_migrations.
+	Table string
+	// Cluster is the cluster used by {{.OnCluster}} in migrations and to create the versions table.
+	Cluster string
+	// TableEngine is the engine of the versions table. Default: MergeTree, or ReplicatedMergeTree
+	// when Cluster is set.
+	TableEngine string
+	// Vars are available to migration templates as {{.Vars.name}}.
+	Vars map[string]string
+	// Settings are sent with each migration statement.
+	Settings chconn.Settings
+	// Logf, if set, is called for each migration applied or reverted.
+	Logf func(format string, args ...any)
+}
+
+// Migrator applies migrations to a database.
+type Migrator struct {
+	db         DB
+	migrations []Migration
+	opts       Options
+	sequence   uint64
+}
+
+// New returns a Migrator that applies migrations to db. migrations must be sorted by version,
+// as returned by Load.
+func New(db DB, migrations []Migration, opts *Options) *Migrator {
+	m := &Migrator{db: db, migrations: migrations}
+	if opts != nil {
+		m.opts = *opts
+	}
+	if m.opts.Table == "" {
+		m.opts.Table = DefaultTable
+	}
+	if m.opts.TableEngine == "" {
+		m.opts.TableEngine = "MergeTree"
+		if m.opts.Cluster != "" {
+			m.opts.TableEngine = "ReplicatedMergeTree"
+		}
+	}
+	return m
+}
+
+// Status is the state of a migration version.
+type Status struct {
+	Version uint64
+	Name    string
+	// Applied reports whether the migration is applied. For a dirty version, it reports whether
+	// the migration was being applied (true) or reverted (false).
+	Applied bool
+	// Dirty reports whether the last run of the migration failed.
+	Dirty bool
+	// Pending reports whether the migration is known but was never applied.
+	Pending bool
+	// UpdatedAt is when the state of the version last changed.
+	UpdatedAt time.Time
+}
+
+// DirtyError is returned when a migration is dirty after a failed run.
+type DirtyError struct {
+	Version uint64
+}
+
+func (e *DirtyError) Error() string {
+	return fmt.Sprintf("migrate: version %d is dirty, fix the database and run force", e.Version)
+}
+
+// MigrationError is returned when a statement of a migration fails.
+type MigrationError struct {
+	Version uint64
+	Name    string
+	// Statement is the index of the failed statement in the migration script.
+	Statement int
+	Query     string
+	Err       error
+}
+
+func (e *MigrationError) Error() string {
+	return fmt.Sprintf("migrate: migration %d_%s: statement %d: %v", e.Version, e.Name, e.Statement+1, e.Err)
+}
+
+func (e *MigrationError) Unwrap() error {
+	return e.Err
+}
+
+func (m *Migrator) onCluster() string {
+	if m.opts.Cluster == "" {
+		return ""
+	}
+	return "ON CLUSTER '" + strings.ReplaceAll(m.opts.Cluster, "'", `\'`) + "'"
+}
+
+// ensureTable creates the versions table if it doesn't exist.
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	err := m.db.ExecWithOption(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s %s (
+	version UInt64,
+	name String,
+	applied UInt8,
+	dirty UInt8,
+	sequence UInt64,
+	updated_at DateTime64(3) DEFAULT now64(3)
+) ENGINE = %s ORDER BY (version, sequence)`, m.opts.Table, m.onCluster(), m.opts.TableEngine), nil)
+	if err != nil {
+		return fmt.Errorf("migrate: creating versions table: %w", err)
+	}
+	return nil
+}
+
+type versionRow struct {
+	Version   uint64    `db:"version"`
+	Name      string    `db:"name"`
+	Applied   uint8     `db:"applied"`
+	Dirty     uint8     `db:"dirty"`
+	Sequence  uint64    `db:"sequence"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Status returns the state of every known or recorded version, sorted by version.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := chconn.QueryAll[versionRow](ctx, m.db, fmt.Sprintf(`SELECT
+	version,
+	argMax(name, sequence) AS name,
+	argMax(applied, sequence) AS applied,
+	argMax(dirty, sequence) AS dirty,
+	max(sequence) AS sequence,
+	max(updated_at) AS updated_at
+FROM %s
+GROUP BY version
+ORDER BY version`, m.opts.Table))
+	if err != nil {
+		return nil, fmt.Errorf("migrate: reading versions: %w", err)
+	}
+
+	var status []Status
+	i := 0
+	for _, r := range rows {
+		for ; i < len(m.migrations) && m.migrations[i].Version < r.Version; i++ {
+			status = append(status, Status{Version: m.migrations[i].Version, Name: m.migrations[i].Name, Pending: true})
+		}
+		if i < len(m.migrations) && m.migrations[i].Version == r.Version {
+			i++
+		}
+		status = append(status, Status{
+			Version:   r.Version,
+			Name:      r.Name,
+			Applied:   r.Applied != 0,
+			Dirty:     r.Dirty != 0,
+			UpdatedAt: r.UpdatedAt,
+		})
+		m.sequence = max(m.sequence, r.Sequence)
+	}
+	for ; i < len(m.migrations); i++ {
+		status = append(status, Status{Version: m.migrations[i].Version, Name: m.migrations[i].Name, Pending: true})
+	}
+	return status, nil
+}
+
+// Version returns the highest applied version and whether any version is dirty. If a version is
+// dirty, it is returned instead.
+func (m *Migrator) Version(ctx context.Context) (version uint64, dirty bool, err error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, s := range status {
+		if s.Dirty {
+			return s.Version, true, nil
+		}
+		if s.Applied {
+			version = s.Version
+		}
+	}
+	return version, false, nil
+}
+
+// Up applies all pending migrations in version order.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.UpTo(ctx, ^uint64(0))
+}
+
+// UpTo applies the pending migrations with a version up to and including version.
+func (m *Migrator) UpTo(ctx context.Context, version uint64) error {
+	status, err := m.checkedStatus(ctx)
+	if err != nil {
+		return err
+	}
+	applied := map[uint64]bool{}
+	for _, s := range status {
+		applied[s.Version] = s.Applied
+	}
+	for i := range m.migrations {
+		mig := &m.migrations[i]
+		if mig.Version > version {
+			break
+		}
+		if applied[mig.Version] {
+			continue
+		}
+		if err := m.run(ctx, mig, mig.Up, true); err != nil {
+			return err
+		}
+		m.logf("applied %d_%s", mig.Version, mig.Name)
+	}
+	return nil
+}
+
+// Down reverts the last steps applied migrations in reverse version order. It returns
+// ErrNoChange if no migration is applied.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	status, err := m.checkedStatus(ctx)
+	if err != nil {
+		return err
+	}
+	reverted := 0
+	for i := len(status) - 1; i >= 0 && reverted < steps; i-- {
+		if !status[i].Applied {
+			continue
+		}
+		mig := m.migration(status[i].Version)
+		if mig == nil {
+			return fmt.Errorf("migrate: applied version %d has no migration file", status[i].Version)
+		}
+		if strings.TrimSpace(mig.Down) == "" {
+			return fmt.Errorf("migrate: migration %d_%s has no down script", mig.Version, mig.Name)
+		}
+		if err := m.run(ctx, mig, mig.Down, false); err != nil {
+			return err
+		}
+		m.logf("reverted %d_%s", mig.Version, mig.Name)
+		reverted++
+	}
+	if reverted == 0 {
+		return ErrNoChange
+	}
+	return nil
+}
+
+// Force records version as applied or not applied and clears its dirty flag, without running
+// any migration. Use it after a failed migration was completed or rolled back by hand.
+func (m *Migrator) Force(ctx context.Context, version uint64, applied bool) error {
+	if _, err := m.Status(ctx); err != nil {
+		return err
+	}
+	name := ""
+	if mig := m.migration(version); mig != nil {
+		name = mig.Name
+	}
+	return m.record(ctx, version, name, applied, false)
+}
+
+func (m *Migrator) checkedStatus(ctx context.Context) ([]Status, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range status {
+		if s.Dirty {
+			return nil, &DirtyError{Version: s.Version}
+		}
+	}
+	return status, nil
+}
+
+func (m *Migrator) migration(version uint64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// run executes the statements of script, marking the version dirty while it runs.
+func (m *Migrator) run(ctx context.Context, mig *Migration, script string, up bool) error {
+	rendered, err := m.render(mig, script)
+	if err != nil {
+		return err
+	}
+	if err := m.record(ctx, mig.Version, mig.Name, up, true); err != nil {
+		return err
+	}
+	opts := &chconn.QueryOptions{Settings: m.opts.Settings}
+	for i, query := range helper.SplitStatements(rendered) {
+		if err := m.db.ExecWithOption(ctx, query, opts); err != nil {
+			return &MigrationError{Version: mig.Version, Name: mig.Name, Statement: i, Query: query, Err: err}
+		}
+	}
+	return m.record(ctx, mig.Version, mig.Name, up, false)
+}
+
+type templateData struct {
+	Cluster   string
+	OnCluster string
+	Vars      map[string]string
+}
+
+// render executes script as a template.
+func (m *Migrator) render(mig *Migration, script string) (string, error) {
+	tmpl, err := template.New(mig.Name).Option("missingkey=error").Parse(script)
+	if err != nil {
+		return "", fmt.Errorf("migrate: migration %d_%s: %w", mig.Version, mig.Name, err)
+	}
+	var b strings.Builder
+	err = tmpl.Execute(&b, templateData{
+		Cluster:   m.opts.Cluster,
+		OnCluster: m.onCluster(),
+		Vars:      m.opts.Vars,
+	})
+	if err != nil {
+		return "", fmt.Errorf("migrate: migration %d_%s: %w", mig.Version, mig.Name, err)
+	}
+	return b.String(), nil
+}
+
+// record appends the state of a version to the versions table.
+func (m *Migrator) record(ctx context.Context, version uint64, name string, applied, dirty bool) error {
+	// The sequence orders the states of a version. It must grow even if the clock goes back.
+	m.sequence = max(m.sequence+1, uint64(time.Now().UnixNano()))
+	err := m.db.ExecWithOption(ctx, fmt.Sprintf(
+		`INSERT INTO %s (version, name, applied, dirty, sequence)
+SELECT {version:UInt64}, {name:String}, {applied:UInt8}, {dirty:UInt8}, {sequence:UInt64}`, m.opts.Table),
+		&chconn.QueryOptions{
+			Parameters: chconn.NewParameters(
+				chconn.UintParameter("version", version),
+				chconn.StringParameter("name", name),
+				chconn.UintParameter("applied", boolToUint8(applied)),
+				chconn.UintParameter("dirty", boolToUint8(dirty)),
+				chconn.UintParameter("sequence", m.sequence),
+			),
+		})
+	if err != nil {
+		return fmt.Errorf("migrate: recording version %d: %w", version, err)
+	}
+	return nil
+}
+
+func (m *Migrator) logf(format string, args ...any) {
+	if m.opts.Logf != nil {
+		m.opts.Logf(format, args...)
+	}
+}
+
+func boolToUint8(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,107 @@
+package migrate_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v3"
+	"github.com/vahid-sohrabloo/chconn/v3/migrate"
+)
+
+func TestMigrator(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := chconn.Connect(context.Background(), connString)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx := context.Background()
+	for _, table := range []string{"test_migrate_versions", "test_migrate_events"} {
+		require.NoError(t, conn.Exec(ctx, "DROP TABLE IF EXISTS "+table))
+	}
+
+	migrations := []migrate.Migration{
+		{
+			Version: 1,
+			Name:    "create_events",
+			Up: `-- the events table; with a comment
+CREATE TABLE test_migrate_events {{.OnCluster}} (id UInt64) ENGINE = Memory;
+INSERT INTO test_migrate_events SELECT number FROM numbers({{.Vars.rows}});`,
+			Down: "DROP TABLE test_migrate_events {{.OnCluster}}",
+		},
+		{
+			Version: 2,
+			Name:    "add_name",
+			Up:      "ALTER TABLE test_migrate_events ADD COLUMN name String DEFAULT 'x'",
+			Down:    "ALTER TABLE test_migrate_events DROP COLUMN name",
+		},
+	}
+	opts := &migrate.Options{
+		Table: "test_migrate_versions",
+		Vars:  map[string]string{"rows": "3"},
+	}
+
+	m := migrate.New(conn, migrations[:1], opts)
+	require.NoError(t, m.Up(ctx))
+	version, dirty, err := m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), version)
+	assert.False(t, dirty)
+
+	count, err := chconn.QueryOne[uint64](ctx, conn, "SELECT count() FROM test_migrate_events")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	// A new migration is applied on the next run; applied ones are skipped.
+	m = migrate.New(conn, migrations, opts)
+	status, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, status, 2)
+	assert.True(t, status[0].Applied)
+	assert.True(t, status[1].Pending)
+	require.NoError(t, m.Up(ctx))
+	version, _, err = m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), version)
+
+	require.NoError(t, m.Down(ctx, 1))
+	version, _, err = m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), version)
+
+	// A failed statement leaves the version dirty.
+	broken := append(migrations[:1:1], migrate.Migration{
+		Version: 3,
+		Name:    "broken",
+		Up:      "ALTER TABLE test_migrate_events ADD COLUMN ok UInt8; ALTER TABLE test_migrate_missing ADD COLUMN x UInt8",
+	})
+	m = migrate.New(conn, broken, opts)
+	err = m.Up(ctx)
+	var migErr *migrate.MigrationError
+	require.ErrorAs(t, err, &migErr)
+	assert.Equal(t, uint64(3), migErr.Version)
+	assert.Equal(t, 1, migErr.Statement)
+
+	version, dirty, err = m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), version)
+	assert.True(t, dirty)
+
+	var dirtyErr *migrate.DirtyError
+	require.ErrorAs(t, m.Up(ctx), &dirtyErr)
+	assert.Equal(t, uint64(3), dirtyErr.Version)
+
+	require.NoError(t, m.Force(ctx, 3, false))
+	require.NoError(t, m.Down(ctx, 5))
+	require.True(t, errors.Is(m.Down(ctx, 1), migrate.ErrNoChange))
+
+	exists, err := chconn.QueryOne[uint8](ctx, conn, "EXISTS TABLE test_migrate_events")
+	require.NoError(t, err)
+	assert.Equal(t, uint8(0), exists)
+}
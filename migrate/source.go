@@ -0,0 +1,94 @@
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Migration is a versioned schema change.
+type Migration struct {
+	// Version orders the migrations. It is the numeric prefix of the file name.
+	Version uint64
+	// Name is the file name without the version prefix and the extension.
+	Name string
+	// Up is the script that applies the migration.
+	Up string
+	// Down is the script that reverts the migration. It is empty if the migration can't be reverted.
+	Down string
+}
+
+// Load reads the migrations in the root directory of fsys, sorted by version.
+//
+// Migration files are named <version>_<name>.sql or <version>_<name>.up.sql, with an optional
+// <version>_<name>.down.sql to revert the migration. Other files are ignored.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("migrate: reading migrations: %w", err)
+	}
+
+	byVersion := map[uint64]*Migration{}
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(fileName, ".sql") {
+			continue
+		}
+		version, name, down, err := parseFileName(fileName)
+		if err != nil {
+			return nil, err
+		}
+		data, err := fs.ReadFile(fsys, fileName)
+		if err != nil {
+			return nil, fmt.Errorf("migrate: reading %s: %w", fileName, err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migrate: version %d is used by %s and %s", version, m.Name, name)
+		}
+		script := &m.Up
+		if down {
+			script = &m.Down
+		}
+		if *script != "" {
+			return nil, fmt.Errorf("migrate: duplicate migration file %s", fileName)
+		}
+		*script = string(data)
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migrate: migration %d_%s has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// parseFileName parses <version>_<name>[.up|.down].sql.
+func parseFileName(fileName string) (version uint64, name string, down bool, err error) {
+	base := strings.TrimSuffix(fileName, path.Ext(fileName))
+	switch {
+	case strings.HasSuffix(base, ".down"):
+		base, down = strings.TrimSuffix(base, ".down"), true
+	case strings.HasSuffix(base, ".up"):
+		base = strings.TrimSuffix(base, ".up")
+	}
+	v, name, _ := strings.Cut(base, "_")
+	version, err = strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, "", false, fmt.Errorf("migrate: invalid migration file name %s: want <version>_<name>.sql", fileName)
+	}
+	return version, name, down, nil
+}
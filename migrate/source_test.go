@@ -0,0 +1,69 @@
+package migrate_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v3/migrate"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"10_add_name.up.sql":       {Data: []byte("ALTER TABLE t ADD COLUMN name String")},
+		"10_add_name.down.sql":     {Data: []byte("ALTER TABLE t DROP COLUMN name")},
+		"2_create_table.sql":       {Data: []byte("CREATE TABLE t (id UInt64) ENGINE = Memory")},
+		"README.md":                {Data: []byte("not a migration")},
+		"subdir/3_ignored.sql":     {Data: []byte("SELECT 1")},
+		"0001_first_migration.sql": {Data: []byte("SELECT 1")},
+	}
+	migrations, err := migrate.Load(fsys)
+	require.NoError(t, err)
+	require.Equal(t, []migrate.Migration{
+		{Version: 1, Name: "first_migration", Up: "SELECT 1"},
+		{Version: 2, Name: "create_table", Up: "CREATE TABLE t (id UInt64) ENGINE = Memory"},
+		{Version: 10, Name: "add_name", Up: "ALTER TABLE t ADD COLUMN name String", Down: "ALTER TABLE t DROP COLUMN name"},
+	}, migrations)
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{
+			name: "invalid name",
+			fsys: fstest.MapFS{"create.sql": {Data: []byte("SELECT 1")}},
+			want: "migrate: invalid migration file name create.sql: want <version>_<name>.sql",
+		},
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"1_a.sql": {Data: []byte("SELECT 1")},
+				"1_b.sql": {Data: []byte("SELECT 1")},
+			},
+			want: "migrate: version 1 is used by a and b",
+		},
+		{
+			name: "duplicate up",
+			fsys: fstest.MapFS{
+				"1_a.sql":    {Data: []byte("SELECT 1")},
+				"1_a.up.sql": {Data: []byte("SELECT 1")},
+			},
+			want: "migrate: duplicate migration file 1_a.up.sql",
+		},
+		{
+			name: "down only",
+			fsys: fstest.MapFS{"1_a.down.sql": {Data: []byte("SELECT 1")}},
+			want: "migrate: migration 1_a has no up script",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := migrate.Load(tt.fsys)
+			require.EqualError(t, err, tt.want)
+		})
+	}
+}
@@ -28,6 +28,10 @@ func (ch *conn) Ping(ctx context.Context) error {
 		hasError = true
 		return &writeError{"ping: write packet type", preferContextOverNetTimeoutError(ctx, err)}
 	}
+	if err := ch.endPacket(); err != nil {
+		hasError = true
+		return preferContextOverNetTimeoutError(ctx, err)
+	}
 
 	res, err := ch.receiveAndProcessData(emptyQueryOptions)
 	if err != nil {
@@ -36,7 +40,7 @@ func (ch *conn) Ping(ctx context.Context) error {
 	}
 	if _, ok := res.(*pong); !ok {
 		hasError = true
-		return &unexpectedPacket{expected: "serverPong", actual: res}
+		return ch.unexpected("serverPong", res)
 	}
 
 	return nil
@@ -0,0 +1,142 @@
+package chconn
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Types of the profile events sent by the server.
+const (
+	profileEventIncrement = 1
+	profileEventGauge     = 2
+)
+
+// QueryStats is the statistics of a query, accumulated from the progress, profile and profile
+// events packets sent by the server. It is complete once the query is finished: after Next
+// returns false on SelectStmt or Rows, or after Flush on InsertStmt.
+type QueryStats struct {
+	ReadRows         uint64
+	ReadBytes        uint64
+	TotalRowsToRead  uint64
+	TotalBytesToRead uint64
+	WrittenRows      uint64
+	WrittenBytes     uint64
+	// Elapsed is the execution time reported by the server.
+	Elapsed time.Duration
+
+	// ResultRows, ResultBlocks and ResultBytes are the size of the result sent by the server.
+	ResultRows   uint64
+	ResultBlocks uint64
+	ResultBytes  uint64
+	// RowsBeforeLimit is the number of rows of the result without LIMIT, if the server calculated it.
+	RowsBeforeLimit uint64
+	// RowsBeforeAggregation is the number of rows read before aggregation, if the server sent it.
+	RowsBeforeAggregation uint64
+
+	// CompressedBytesSent and UncompressedBytesSent are the size of the compressed blocks sent for
+	// the query, with their headers, and of their data before compression. CompressedBytesReceived
+	// and UncompressedBytesReceived are the same for the blocks received. They are zero if the
+	// query is not compressed.
+	CompressedBytesSent       uint64
+	UncompressedBytesSent     uint64
+	CompressedBytesReceived   uint64
+	UncompressedBytesReceived uint64
+
+	// ProfileEvents are the query-level profile event counters by name, e.g. SelectedMarks or
+	// RealTimeMicroseconds, summed over the hosts of a distributed query. Gauges such as
+	// MemoryTrackerPeak hold their largest value. Nil if the server sent no profile events.
+	ProfileEvents map[string]int64
+}
+
+func (s *QueryStats) addProgress(p *Progress) {
+	s.ReadRows += p.ReadRows
+	s.ReadBytes += p.ReadBytes
+	s.TotalRowsToRead += p.TotalRows
+	s.TotalBytesToRead += p.TotalBytes
+	s.WrittenRows += p.WriterRows
+	s.WrittenBytes += p.WrittenBytes
+	s.Elapsed += time.Duration(p.ElapsedNS)
+}
+
+func (s *QueryStats) addProfile(p *Profile) {
+	s.ResultRows += p.Rows
+	s.ResultBlocks += p.Blocks
+	s.ResultBytes += p.Bytes
+	if p.CalculatedRowsBeforeLimit != 0 {
+		s.RowsBeforeLimit = p.RowsBeforeLimit
+	}
+	if p.AppliedAggregation != 0 {
+		s.RowsBeforeAggregation = p.RowsBeforeAggregation
+	}
+}
+
+// addProfileEvents adds the query-level rows of p. Rows of a single thread (ThreadID other than 0)
+// are already included in the query-level rows of their host.
+func (s *QueryStats) addProfileEvents(p *ProfileEvent) {
+	for i := range p.Name.NumRow() {
+		if p.ThreadID.Row(i) != 0 {
+			continue
+		}
+		if s.ProfileEvents == nil {
+			s.ProfileEvents = make(map[string]int64)
+		}
+		name := p.Name.Row(i)
+		switch value := p.Value.Row(i); p.Type.Row(i) {
+		case profileEventIncrement:
+			s.ProfileEvents[name] += value
+		case profileEventGauge:
+			s.ProfileEvents[name] = max(s.ProfileEvents[name], value)
+		}
+	}
+}
+
+// QueryDone describes a finished query. See Config.OnQueryDone.
+type QueryDone struct {
+	Query   string
+	QueryID string
+	// Insert is true for the queries of Insert and InsertStream.
+	Insert bool
+	// Duration is the time from sending the query to its end, including the time the caller spent
+	// between reading or writing blocks.
+	Duration time.Duration
+	// Rows is the number of rows received, or sent for an insert.
+	Rows uint64
+	// Stats is nil if the query could not be sent.
+	Stats *QueryStats
+	// Err is the error of the query, nil if it succeeded. Server errors are *ChError.
+	Err error
+}
+
+// LogValue implements slog.LogValuer.
+func (s *QueryStats) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Uint64("read_rows", s.ReadRows),
+		slog.Uint64("read_bytes", s.ReadBytes),
+		slog.Uint64("written_rows", s.WrittenRows),
+		slog.Uint64("written_bytes", s.WrittenBytes),
+		slog.Duration("elapsed", s.Elapsed),
+		slog.Uint64("result_rows", s.ResultRows),
+		slog.Uint64("result_bytes", s.ResultBytes),
+	}
+	if s.CompressedBytesSent != 0 || s.CompressedBytesReceived != 0 {
+		attrs = append(attrs,
+			slog.Uint64("compressed_bytes_sent", s.CompressedBytesSent),
+			slog.Uint64("uncompressed_bytes_sent", s.UncompressedBytesSent),
+			slog.Uint64("compressed_bytes_received", s.CompressedBytesReceived),
+			slog.Uint64("uncompressed_bytes_received", s.UncompressedBytesReceived),
+		)
+	}
+	if s.RowsBeforeLimit != 0 {
+		attrs = append(attrs, slog.Uint64("rows_before_limit", s.RowsBeforeLimit))
+	}
+	if len(s.ProfileEvents) > 0 {
+		events := make([]any, 0, len(s.ProfileEvents))
+		for _, name := range slices.Sorted(maps.Keys(s.ProfileEvents)) {
+			events = append(events, slog.Int64(name, s.ProfileEvents[name]))
+		}
+		attrs = append(attrs, slog.Group("profile_events", events...))
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,164 @@
+package chconn
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+func TestQueryStatsAdd(t *testing.T) {
+	t.Parallel()
+
+	var stats QueryStats
+	stats.addProgress(&Progress{ReadRows: 10, ReadBytes: 80, TotalRows: 100, ElapsedNS: 1000})
+	stats.addProgress(&Progress{ReadRows: 5, ReadBytes: 40, WriterRows: 3, WrittenBytes: 24, ElapsedNS: 500})
+	stats.addProfile(&Profile{Rows: 7, Blocks: 1, Bytes: 56, RowsBeforeLimit: 15, CalculatedRowsBeforeLimit: 1})
+
+	events := newProfileEvent()
+	for _, e := range []struct {
+		name     string
+		threadID uint64
+		typ      int8
+		value    int64
+	}{
+		{"SelectedMarks", 0, profileEventIncrement, 2},
+		{"SelectedMarks", 42, profileEventIncrement, 2},
+		{"MemoryTrackerPeak", 0, profileEventGauge, 1024},
+	} {
+		events.Host.Append("host")
+		events.Time.Append(0)
+		events.ThreadID.Append(e.threadID)
+		events.Type.Append(e.typ)
+		events.Name.Append(e.name)
+		events.Value.Append(e.value)
+	}
+	stats.addProfileEvents(events)
+	stats.addProfileEvents(events)
+
+	assert.Equal(t, QueryStats{
+		ReadRows:        15,
+		ReadBytes:       120,
+		TotalRowsToRead: 100,
+		WrittenRows:     3,
+		WrittenBytes:    24,
+		Elapsed:         1500 * time.Nanosecond,
+		ResultRows:      7,
+		ResultBlocks:    1,
+		ResultBytes:     56,
+		RowsBeforeLimit: 15,
+		ProfileEvents: map[string]int64{
+			"SelectedMarks":     4,
+			"MemoryTrackerPeak": 1024,
+		},
+	}, stats)
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("query", "stats", &stats)
+	assert.Contains(t, buf.String(), "stats.read_rows=15")
+	assert.Contains(t, buf.String(), "stats.profile_events.SelectedMarks=4")
+}
+
+func TestSelectStats(t *testing.T) {
+	t.Parallel()
+	conn := getConnection(t)
+
+	col := column.New[uint64]()
+	stmt, err := conn.Select(context.Background(), "SELECT number FROM system.numbers LIMIT 1000", col)
+	require.NoError(t, err)
+	for stmt.Next() {
+	}
+	require.NoError(t, stmt.Err())
+	stats := stmt.Stats()
+	assert.Equal(t, uint64(1000), stats.ResultRows)
+	assert.NotZero(t, stats.ReadRows)
+	assert.NotZero(t, stats.ProfileEvents["RealTimeMicroseconds"])
+
+	rows, err := conn.Query(context.Background(), "SELECT number FROM numbers(100) LIMIT 10")
+	require.NoError(t, err)
+	rows.Close()
+	require.NoError(t, rows.Err())
+	assert.Equal(t, uint64(10), rows.Stats().ResultRows)
+
+	require.NoError(t, conn.Exec(context.Background(), "CREATE TEMPORARY TABLE test_insert_stats (n UInt64)"))
+	insertStmt, err := conn.InsertStream(context.Background(), "INSERT INTO test_insert_stats VALUES")
+	require.NoError(t, err)
+	require.NoError(t, insertStmt.Append(uint64(1)))
+	require.NoError(t, insertStmt.Append(uint64(2)))
+	require.NoError(t, insertStmt.Flush(context.Background()))
+	assert.Equal(t, uint64(2), insertStmt.Stats().WrittenRows)
+}
+
+func TestOnQueryDone(t *testing.T) {
+	t.Parallel()
+
+	config, err := ParseConfig(os.Getenv("CHX_TEST_TCP_CONN_STRING"))
+	require.NoError(t, err)
+	var done []*QueryDone
+	config.OnQueryDone = func(_ Conn, d *QueryDone) {
+		done = append(done, d)
+	}
+	c, err := ConnectConfig(context.Background(), config)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Exec(context.Background(), "SELECT 1"))
+	require.Len(t, done, 1)
+	assert.Equal(t, "SELECT 1", done[0].Query)
+	assert.False(t, done[0].Insert)
+	assert.NotNil(t, done[0].Stats)
+	assert.NoError(t, done[0].Err)
+
+	err = c.Insert(context.Background(), "INSERT INTO table_does_not_exist VALUES")
+	require.Error(t, err)
+	require.Len(t, done, 2)
+	assert.True(t, done[1].Insert)
+	var chErr *ChError
+	require.ErrorAs(t, done[1].Err, &chErr)
+	assert.Equal(t, ChErrorUnknownTable, chErr.Code)
+}
+
+func TestQueryCompression(t *testing.T) {
+	t.Parallel()
+	conn := getConnection(t)
+
+	require.NoError(t, conn.Exec(context.Background(), "CREATE TEMPORARY TABLE test_query_compression (s String)"))
+	for _, compression := range []*Compression{
+		{Method: CompressZSTD, Level: 3},
+		{Method: CompressLZ4HC},
+		{Method: CompressNone},
+	} {
+		col := column.NewString()
+		for range 1000 {
+			col.Append("compressible compressible compressible")
+		}
+		stmt, err := conn.InsertStreamWithOption(context.Background(), "INSERT INTO test_query_compression VALUES",
+			&QueryOptions{Compression: compression})
+		require.NoError(t, err)
+		require.NoError(t, stmt.Write(context.Background(), col))
+		require.NoError(t, stmt.Flush(context.Background()))
+		stats := stmt.Stats()
+		if compression.Method == CompressNone {
+			assert.Zero(t, stats.CompressedBytesSent)
+			continue
+		}
+		assert.Less(t, stats.CompressedBytesSent, stats.UncompressedBytesSent)
+		assert.Greater(t, stats.UncompressedBytesSent, uint64(39000))
+	}
+
+	col := column.New[uint64]()
+	stmt, err := conn.SelectWithOption(context.Background(), "SELECT count() FROM test_query_compression",
+		&QueryOptions{Compression: &Compression{Method: CompressLZ4}}, col)
+	require.NoError(t, err)
+	for stmt.Next() {
+	}
+	require.NoError(t, stmt.Err())
+	assert.Equal(t, []uint64{3000}, col.Data())
+	assert.NotZero(t, stmt.Stats().CompressedBytesReceived)
+	assert.NotZero(t, stmt.Stats().UncompressedBytesReceived)
+}
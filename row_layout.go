@@ -0,0 +1,104 @@
+package chconn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+// RowLayout caches a value derived from the columns of a result set, such as the position and
+// concrete type of each column a scanner reads. It is used by the scanners generated by
+// `chgen columns --with-scan` so the layout is resolved on the first row of a query and
+// reused for the following rows without reflection.
+//
+// Layouts are kept by the result set, so a RowLayout can be shared by any number of concurrent
+// queries. Rows that do not keep layouts (see RowLayouts) get a layout built on every call.
+type RowLayout[L any] struct {
+	build func(columns []column.ColumnCore) (*L, error)
+}
+
+// NewRowLayout returns a RowLayout that calls build to resolve the layout of each new result set.
+func NewRowLayout[L any](build func(columns []column.ColumnCore) (*L, error)) *RowLayout[L] {
+	return &RowLayout[L]{build: build}
+}
+
+// Get returns the layout for the columns of row. The layout is built once per result set when
+// row implements `RowLayouts() *RowLayouts`, as the Rows returned by Query and chpool do.
+func (l *RowLayout[L]) Get(row CollectableRow) (*L, error) {
+	var cache *RowLayouts
+	if h, ok := row.(interface{ RowLayouts() *RowLayouts }); ok {
+		cache = h.RowLayouts()
+	}
+	if cache != nil {
+		if layout, ok := cache.layouts[l]; ok {
+			return layout.(*L), nil
+		}
+	}
+	layout, err := l.build(row.Columns())
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		if cache.layouts == nil {
+			cache.layouts = make(map[any]any)
+		}
+		cache.layouts[l] = layout
+	}
+	return layout, nil
+}
+
+// RowLayouts holds the layouts RowLayout.Get has built for one result set. It is dropped
+// whenever the result columns change order.
+type RowLayouts struct {
+	layouts map[any]any
+}
+
+func (c *RowLayouts) reset() {
+	clear(c.layouts)
+}
+
+// errNoCurrentRow is returned by CurrentRow for rows that do not report their position.
+var errNoCurrentRow = errors.New("row does not implement CurrentRow() int")
+
+// CurrentRow returns the index of the current row of row in its block's columns, for
+// scanners that read the columns directly. row must implement `CurrentRow() int`, as Rows does.
+func CurrentRow(row CollectableRow) (int, error) {
+	if r, ok := row.(interface{ CurrentRow() int }); ok {
+		return r.CurrentRow(), nil
+	}
+	return 0, errNoCurrentRow
+}
+
+// LookupColumns returns the index in columns of each of names. It returns an error if a name is
+// not in columns or if columns has a column that is not in names.
+func LookupColumns(columns []column.ColumnCore, names ...string) ([]int, error) {
+	idx := make([]int, len(names))
+	for i, name := range names {
+		idx[i] = -1
+		for j, col := range columns {
+			if string(col.Name()) == name {
+				idx[i] = j
+				break
+			}
+		}
+		if idx[i] < 0 {
+			return nil, fmt.Errorf("cannot find field %s in returned row", name)
+		}
+	}
+	if len(columns) > len(names) {
+		for _, col := range columns {
+			found := false
+			for _, name := range names {
+				if string(col.Name()) == name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("struct doesn't have corresponding row field %s", string(col.Name()))
+			}
+		}
+	}
+	return idx, nil
+}
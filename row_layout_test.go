@@ -0,0 +1,67 @@
+package chconn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+type layoutRows struct {
+	columns []column.ColumnCore
+	layouts *RowLayouts
+}
+
+func (r *layoutRows) Scan(...any) error            { return nil }
+func (r *layoutRows) Columns() []column.ColumnCore { return r.columns }
+func (r *layoutRows) RowLayouts() *RowLayouts      { return r.layouts }
+
+func TestRowLayout(t *testing.T) {
+	t.Parallel()
+
+	builds := 0
+	layout := NewRowLayout(func(cols []column.ColumnCore) (*[]int, error) {
+		builds++
+		idx, err := LookupColumns(cols, "a", "b")
+		return &idx, err
+	})
+	a, b := column.New[int32](), column.New[int32]()
+	a.SetName([]byte("a"))
+	b.SetName([]byte("b"))
+
+	// Each result set keeps its own layout.
+	rows1 := &layoutRows{columns: []column.ColumnCore{a, b}, layouts: &RowLayouts{}}
+	rows2 := &layoutRows{columns: []column.ColumnCore{b, a}, layouts: &RowLayouts{}}
+	for range 3 {
+		got, err := layout.Get(rows1)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1}, *got)
+		got, err = layout.Get(rows2)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 0}, *got)
+	}
+	assert.Equal(t, 2, builds)
+
+	// Reordering the columns in place drops the cached layout.
+	rows1.columns[0], rows1.columns[1] = rows1.columns[1], rows1.columns[0]
+	rows1.layouts.reset()
+	got, err := layout.Get(rows1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 0}, *got)
+	assert.Equal(t, 3, builds)
+
+	// Rows without a cache build the layout on every call.
+	rows3 := &blockRowsNoCache{columns: []column.ColumnCore{a}}
+	_, err = layout.Get(rows3)
+	require.EqualError(t, err, "cannot find field b in returned row")
+	_, err = CurrentRow(rows3)
+	require.ErrorIs(t, err, errNoCurrentRow)
+}
+
+type blockRowsNoCache struct {
+	columns []column.ColumnCore
+}
+
+func (r *blockRowsNoCache) Scan(...any) error            { return nil }
+func (r *blockRowsNoCache) Columns() []column.ColumnCore { return r.columns }
@@ -0,0 +1,219 @@
+package chconn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+// formattedRowFormats are the output formats of SelectFormatted. The server sends the result in the Native format
+// whatever the FORMAT clause of the query, so the rows are formatted one by one by formatRow on the server. Only
+// the row formats without a prefix, a suffix or a delimiter between the rows are supported, as their output is the
+// concatenation of the formatted rows.
+var formattedRowFormats = map[string]bool{
+	"CSV":                       true,
+	"TSV":                       true,
+	"TabSeparated":              true,
+	"TSVRaw":                    true,
+	"TabSeparatedRaw":           true,
+	"JSONEachRow":               true,
+	"JSONLines":                 true,
+	"NDJSON":                    true,
+	"JSONStringsEachRow":        true,
+	"JSONCompactEachRow":        true,
+	"JSONCompactStringsEachRow": true,
+	"TSKV":                      true,
+	"RawBLOB":                   true,
+}
+
+// formattedHeaderFormats are the formats whose output starts with a header row of the column names, or of the
+// names and then the types, mapped to the row format of the following rows. The header is written by
+// SelectFormatted from the result columns, as the server does not write it with formatRow.
+var formattedHeaderFormats = map[string]string{
+	"CSVWithNames":                               "CSV",
+	"CSVWithNamesAndTypes":                       "CSV",
+	"TSVWithNames":                               "TSV",
+	"TSVWithNamesAndTypes":                       "TSV",
+	"TabSeparatedWithNames":                      "TSV",
+	"TabSeparatedWithNamesAndTypes":              "TSV",
+	"TSVRawWithNames":                            "TSVRaw",
+	"TSVRawWithNamesAndTypes":                    "TSVRaw",
+	"TabSeparatedRawWithNames":                   "TSVRaw",
+	"TabSeparatedRawWithNamesAndTypes":           "TSVRaw",
+	"JSONCompactEachRowWithNames":                "JSONCompactEachRow",
+	"JSONCompactEachRowWithNamesAndTypes":        "JSONCompactEachRow",
+	"JSONCompactStringsEachRowWithNames":         "JSONCompactStringsEachRow",
+	"JSONCompactStringsEachRowWithNamesAndTypes": "JSONCompactStringsEachRow",
+}
+
+var formatClauseRegexp = regexp.MustCompile(`(?is)^(.*)\s+FORMAT\s+(\w+)(\s+SETTINGS\s+.*?)?[\s;]*$`)
+
+func (ch *conn) SelectFormatted(ctx context.Context, query string, w io.Writer) error {
+	return ch.SelectFormattedWithOption(ctx, query, w, nil)
+}
+
+func (ch *conn) SelectFormattedWithOption(
+	ctx context.Context,
+	query string,
+	w io.Writer,
+	queryOptions *QueryOptions,
+) error {
+	query, header, err := formattedQuery(query)
+	if err != nil {
+		return err
+	}
+	if header != nil {
+		if err := ch.writeFormattedHeader(ctx, header, w, queryOptions); err != nil {
+			return err
+		}
+	}
+	col := column.NewString()
+	stmt, err := ch.SelectWithOption(ctx, query, queryOptions, col)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	var buf []byte
+	for stmt.Next() {
+		buf = buf[:0]
+		col.Each(func(_ int, b []byte) bool {
+			buf = append(buf, b...)
+			return true
+		})
+		if _, err := w.Write(buf); err != nil {
+			return fmt.Errorf("select formatted: write: %w", err)
+		}
+	}
+	return stmt.Err()
+}
+
+// formattedHeader is the header of a *WithNames or *WithNamesAndTypes format.
+type formattedHeader struct {
+	format   string // row format of the header
+	types    bool   // the names are followed by a row of types
+	describe string // query that describes the result columns
+}
+
+// formattedQuery rewrites a query with a FORMAT clause to a query that returns its rows formatted by the server.
+// header is not nil for the formats that start with a header.
+func formattedQuery(query string) (string, *formattedHeader, error) {
+	m := formatClauseRegexp.FindStringSubmatch(query)
+	if m == nil {
+		return "", nil, errors.New("select formatted: query has no FORMAT clause")
+	}
+	format := m[2]
+	var header *formattedHeader
+	if base, ok := formattedHeaderFormats[format]; ok {
+		header = &formattedHeader{
+			format:   base,
+			types:    strings.HasSuffix(format, "AndTypes"),
+			describe: "DESCRIBE TABLE (" + m[1] + "\n)" + m[3],
+		}
+		format = base
+	}
+	if !formattedRowFormats[format] {
+		return "", nil, fmt.Errorf("select formatted: format %s is not supported: only row formats without prefix, "+
+			"suffix or row delimiter, and their *WithNames variants, can be formatted over the native protocol", m[2])
+	}
+	// The subquery may end with a -- comment, so ")" goes on a new line.
+	return "SELECT formatRow('" + format + "', *) FROM (" + m[1] + "\n)" + m[3], header, nil
+}
+
+// writeFormattedHeader describes the result columns of the query and writes the header rows to w.
+func (ch *conn) writeFormattedHeader(
+	ctx context.Context,
+	h *formattedHeader,
+	w io.Writer,
+	queryOptions *QueryOptions,
+) error {
+	var describeOptions *QueryOptions
+	if queryOptions != nil {
+		describeOptions = &QueryOptions{Settings: queryOptions.Settings, Parameters: queryOptions.Parameters}
+	}
+	stmt, err := ch.SelectWithOption(ctx, h.describe, describeOptions)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	var names, types []string
+	for stmt.Next() {
+		cols := stmt.Columns()
+		if len(cols) < 2 {
+			return fmt.Errorf("select formatted: describe returned %d columns", len(cols))
+		}
+		nameCol, ok1 := cols[0].(*column.String)
+		typeCol, ok2 := cols[1].(*column.String)
+		if !ok1 || !ok2 {
+			return errors.New("select formatted: describe returned unexpected column types")
+		}
+		names = nameCol.Read(names)
+		types = typeCol.Read(types)
+	}
+	if err := stmt.Err(); err != nil {
+		return err
+	}
+	buf := appendHeaderRow(nil, h.format, names)
+	if h.types {
+		buf = appendHeaderRow(buf, h.format, types)
+	}
+	if _, err := w.Write(buf); err != nil {
+		return fmt.Errorf("select formatted: write: %w", err)
+	}
+	return nil
+}
+
+// appendHeaderRow appends a row of the string values in the row format to buf, escaped as the server escapes
+// strings in the format with the default settings.
+func appendHeaderRow(buf []byte, format string, values []string) []byte {
+	switch format {
+	case "JSONCompactEachRow", "JSONCompactStringsEachRow":
+		buf = append(buf, '[')
+		for i, v := range values {
+			if i > 0 {
+				buf = append(buf, ", "...)
+			}
+			buf = appendJSONString(buf, v)
+		}
+		return append(buf, "]\n"...)
+	}
+	for i, v := range values {
+		switch {
+		case i > 0 && format == "CSV":
+			buf = append(buf, ',')
+		case i > 0:
+			buf = append(buf, '\t')
+		}
+		switch format {
+		case "CSV":
+			buf = append(buf, '"')
+			buf = append(buf, strings.ReplaceAll(v, `"`, `""`)...)
+			buf = append(buf, '"')
+		case "TSV":
+			buf = append(buf, tsvEscaper.Replace(v)...)
+		default:
+			buf = append(buf, v...)
+		}
+	}
+	return append(buf, '\n')
+}
+
+var tsvEscaper = strings.NewReplacer(
+	"\\", `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`, "\b", `\b`, "\f", `\f`, "\x00", `\0`, "'", `\'`,
+)
+
+// appendJSONString appends s as a JSON string, escaping "/" as the server does by default.
+func appendJSONString(buf []byte, s string) []byte {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s) // a string always encodes
+	return append(buf, strings.ReplaceAll(strings.TrimSuffix(b.String(), "\n"), "/", `\/`)...)
+}
@@ -0,0 +1,119 @@
+package chconn
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormattedQuery(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected string
+		header   *formattedHeader
+		err      string
+	}{
+		{
+			name:     "format",
+			query:    "SELECT number FROM system.numbers LIMIT 3 FORMAT CSV",
+			expected: "SELECT formatRow('CSV', *) FROM (SELECT number FROM system.numbers LIMIT 3\n)",
+		},
+		{
+			name:     "format with settings and semicolon",
+			query:    "select 'a FORMAT b'\nformat JSONEachRow SETTINGS output_format_json_quote_64bit_integers=0;\n",
+			expected: "SELECT formatRow('JSONEachRow', *) FROM (select 'a FORMAT b'\n) SETTINGS output_format_json_quote_64bit_integers=0",
+		},
+		{
+			name:     "trailing comment",
+			query:    "SELECT 1 -- one\nFORMAT TSV",
+			expected: "SELECT formatRow('TSV', *) FROM (SELECT 1 -- one\n)",
+		},
+		{
+			name:     "with names and types",
+			query:    "SELECT 1 AS a FORMAT CSVWithNamesAndTypes",
+			expected: "SELECT formatRow('CSV', *) FROM (SELECT 1 AS a\n)",
+			header:   &formattedHeader{format: "CSV", types: true, describe: "DESCRIBE TABLE (SELECT 1 AS a\n)"},
+		},
+		{
+			name:     "with names",
+			query:    "SELECT 1 AS a FORMAT TabSeparatedRawWithNames",
+			expected: "SELECT formatRow('TSVRaw', *) FROM (SELECT 1 AS a\n)",
+			header:   &formattedHeader{format: "TSVRaw", describe: "DESCRIBE TABLE (SELECT 1 AS a\n)"},
+		},
+		{
+			name:  "no format",
+			query: "SELECT 1",
+			err:   "select formatted: query has no FORMAT clause",
+		},
+		{
+			name:  "not supported format",
+			query: "SELECT 1 FORMAT Parquet",
+			err: "select formatted: format Parquet is not supported: only row formats without prefix, suffix or " +
+				"row delimiter, and their *WithNames variants, can be formatted over the native protocol",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, header, err := formattedQuery(tc.query)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+			assert.Equal(t, tc.header, header)
+		})
+	}
+}
+
+func TestAppendHeaderRow(t *testing.T) {
+	t.Parallel()
+
+	values := []string{"id", `a"b`, "c\td/e"}
+	for format, expected := range map[string]string{
+		"CSV":                       "\"id\",\"a\"\"b\",\"c\td/e\"\n",
+		"TSV":                       "id\ta\"b\tc\\td/e\n",
+		"TSVRaw":                    "id\ta\"b\tc\td/e\n",
+		"JSONCompactEachRow":        "[\"id\", \"a\\\"b\", \"c\\td\\/e\"]\n",
+		"JSONCompactStringsEachRow": "[\"id\", \"a\\\"b\", \"c\\td\\/e\"]\n",
+	} {
+		assert.Equal(t, expected, string(appendHeaderRow(nil, format, values)), format)
+	}
+}
+
+func TestSelectFormatted(t *testing.T) {
+	t.Parallel()
+
+	c, err := Connect(context.Background(), os.Getenv("CHX_TEST_TCP_CONN_STRING"))
+	require.NoError(t, err)
+	defer c.Close()
+
+	var buf bytes.Buffer
+	err = c.SelectFormatted(context.Background(),
+		"SELECT toUInt32(number) AS id, toString(number) AS name FROM system.numbers LIMIT 3 FORMAT JSONEachRow", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"id\":0,\"name\":\"0\"}\n{\"id\":1,\"name\":\"1\"}\n{\"id\":2,\"name\":\"2\"}\n", buf.String())
+
+	buf.Reset()
+	err = c.SelectFormattedWithOption(context.Background(),
+		"SELECT number, 'a,b' FROM system.numbers LIMIT 2 FORMAT CSV", &buf, &QueryOptions{
+			Settings: Settings{{Name: "max_block_size", Value: "1"}},
+		})
+	require.NoError(t, err)
+	assert.Equal(t, "0,\"a,b\"\n1,\"a,b\"\n", buf.String())
+	assert.False(t, c.IsClosed())
+
+	buf.Reset()
+	err = c.SelectFormatted(context.Background(),
+		"SELECT toUInt32(number) AS id, toString(number) AS name FROM system.numbers LIMIT 2 -- two rows\n"+
+			"FORMAT CSVWithNamesAndTypes", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, "\"id\",\"name\"\n\"UInt32\",\"String\"\n0,\"0\"\n1,\"1\"\n", buf.String())
+}
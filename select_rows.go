@@ -111,6 +111,10 @@ type Rows interface {
 
 	// Conn returns the underlying Conn on which the query was executed
 	Conn() Conn
+
+	// Stats returns the statistics of the query, or nil if the query was not sent. They are complete
+	// after the Rows is closed.
+	Stats() *QueryStats
 }
 
 // Row is a convenience wrapper over Rows that is returned by QueryRow.
@@ -208,6 +212,11 @@ func (r *baseRows) CurrentRow() int {
 	return r.currentRow
 }
 
+// RowLayouts returns the layouts RowLayout.Get has built for the result set.
+func (r *baseRows) RowLayouts() *RowLayouts {
+	return &r.selectStmt.layouts
+}
+
 func (r *baseRows) fatal(err error) {
 	r.selectStmt.lastErr = err
 	r.Close()
@@ -224,6 +233,10 @@ func (r *baseRows) Close() {
 	r.selectStmt.Close()
 }
 
+func (r *baseRows) Stats() *QueryStats {
+	return r.selectStmt.Stats()
+}
+
 func (r *baseRows) Conn() Conn {
 	return r.selectStmt.conn
 }
@@ -3,6 +3,7 @@ package chconn
 import (
 	"context"
 	"iter"
+	"time"
 
 	"github.com/vahid-sohrabloo/chconn/v3/column"
 )
@@ -64,12 +65,16 @@ func (ch *conn) SelectWithOption(
 		ch.contextWatcher.Watch(ctx)
 	}
 
-	err = ch.sendQueryWithOption(query, queryOptions.QueryID, queryOptions.Settings, queryOptions.Parameters)
+	s.start = time.Now()
+	s.ctx = ch.startQuery(ctx, query, queryOptions, false)
+	err = ch.sendQueryWithOption(query, queryOptions)
 	if err != nil {
 		hasError = true
 		s.lastErr = preferContextOverNetTimeoutError(ctx, err)
+		ch.endQuery(s.ctx, s.start, &QueryDone{Query: query, QueryID: queryOptions.QueryID, Err: s.lastErr})
 		return s, s.lastErr
 	}
+	s.stats = ch.queryStats
 	res, err := s.conn.receiveAndProcessData(s.queryOptions)
 	if err != nil {
 		s.lastErr = err
@@ -93,7 +98,7 @@ func (ch *conn) SelectWithOption(
 			return s, nil
 		}
 	}
-	s.lastErr = &unexpectedPacket{expected: "serverData with zero len", actual: res}
+	s.lastErr = s.conn.unexpected("serverData with zero len", res)
 	return s, s.lastErr
 }
 
@@ -110,6 +115,12 @@ type SelectStmt interface {
 	RowsInBlock() int
 	// Columns return the columns of this select statement.
 	Columns() []column.ColumnCore
+	// Timezone returns the timezone used to decode DateTime and DateTime64 columns without an explicit
+	// timezone: the session_timezone of the query if it is set, otherwise the server timezone.
+	Timezone() string
+	// Stats returns the statistics of the query, or nil if the query was not sent. They are complete
+	// after Next returns false.
+	Stats() *QueryStats
 	// Close close the statement and release the connection
 	// If Next is called and returns false and there are no further blocks,
 	// the Rows are closed automatically and it will suffice to check the result of Err.
@@ -137,7 +148,11 @@ type selectStmt struct {
 	columnsForRead []column.ColumnCore
 	ctx            context.Context
 	finishSelect   bool
+	start          time.Time
+	rows           uint64
+	stats          *QueryStats
 	validateData   bool
+	layouts        RowLayouts
 }
 
 var _ SelectStmt = &selectStmt{}
@@ -158,10 +173,14 @@ func (s *selectStmt) skipBlock(b *block) error {
 			return err
 		}
 	} else if len(s.columnsForRead[0].Name()) != 0 {
-		s.columnsForRead, err = b.reorderColumns(s.columnsForRead)
+		var moved bool
+		s.columnsForRead, moved, err = b.reorderColumns(s.columnsForRead)
 		if err != nil {
 			return err
 		}
+		if moved {
+			s.layouts.reset()
+		}
 	}
 	return nil
 }
@@ -204,6 +223,7 @@ func (s *selectStmt) Next() bool {
 			s.Close()
 			return false
 		}
+		s.rows += block.NumRows
 		return true
 	}
 
@@ -214,7 +234,7 @@ func (s *selectStmt) Next() bool {
 		return false
 	}
 
-	s.lastErr = &unexpectedPacket{expected: "serverData", actual: res}
+	s.lastErr = s.conn.unexpected("serverData", res)
 	s.Close()
 	return false
 }
@@ -253,6 +273,15 @@ func (s *selectStmt) Close() {
 		if s.Err() != nil || !s.finishSelect {
 			s.conn.Close()
 		}
+		if s.stats != nil {
+			s.conn.endQuery(s.ctx, s.start, &QueryDone{
+				Query:   s.query,
+				QueryID: s.queryOptions.QueryID,
+				Rows:    s.rows,
+				Stats:   s.stats,
+				Err:     s.Err(),
+			})
+		}
 	}
 }
 
@@ -260,6 +289,14 @@ func (s *selectStmt) Columns() []column.ColumnCore {
 	return s.columnsForRead
 }
 
+func (s *selectStmt) Timezone() string {
+	return s.conn.timezone()
+}
+
+func (s *selectStmt) Stats() *QueryStats {
+	return s.stats
+}
+
 func (s *selectStmt) Rows() Rows {
 	return &baseRows{
 		selectStmt: s,
@@ -415,3 +415,49 @@ func TestSelectStmtRowIterBreak(t *testing.T) {
 	}
 	assert.Equal(t, 5, count)
 }
+
+func TestSelectSessionTimezone(t *testing.T) {
+	t.Parallel()
+	conn := getConnection(t)
+
+	stmt, err := conn.Select(context.Background(),
+		"SELECT toDateTime('2024-01-02 03:04:05') SETTINGS session_timezone = 'Asia/Tokyo'")
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", stmt.Timezone())
+	require.True(t, stmt.Next())
+	col, ok := stmt.Columns()[0].(*column.Date[types.DateTime])
+	require.True(t, ok)
+	assert.Equal(t, "Asia/Tokyo", col.Location().String())
+	assert.Equal(t, "2024-01-02 03:04:05", col.Row(0).Format(time.DateTime))
+	for stmt.Next() {
+	}
+	require.NoError(t, stmt.Err())
+
+	stmt, err = conn.Select(context.Background(), "SELECT toDateTime('2024-01-02 03:04:05')")
+	require.NoError(t, err)
+	assert.Equal(t, conn.ServerInfo().Timezone, stmt.Timezone())
+	for stmt.Next() {
+	}
+	require.NoError(t, stmt.Err())
+}
+
+func TestSelectSessionTimezoneDynamicJSON(t *testing.T) {
+	t.Parallel()
+	conn := getConnection(t)
+
+	stmt, err := conn.Select(context.Background(),
+		`SELECT toDateTime('2024-01-02 03:04:05')::Dynamic, '{"t": "2024-01-02 03:04:05"}'::JSON(t DateTime)
+		SETTINGS session_timezone = 'Asia/Tokyo', allow_experimental_dynamic_type = 1, allow_experimental_json_type = 1`)
+	require.NoError(t, err)
+	require.True(t, stmt.Next())
+	columns := stmt.Columns()
+	require.Len(t, columns, 2)
+	v, ok := columns[0].RowAny(0).(time.Time)
+	require.True(t, ok)
+	assert.Equal(t, "Asia/Tokyo", v.Location().String())
+	assert.Equal(t, "2024-01-02 03:04:05", v.Format(time.DateTime))
+	assert.Contains(t, string(columns[1].ToJSON(0, false, nil)), "2024-01-02 03:04:05")
+	for stmt.Next() {
+	}
+	require.NoError(t, stmt.Err())
+}
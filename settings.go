@@ -3,6 +3,7 @@ package chconn
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/vahid-sohrabloo/chconn/v3/internal/readerwriter"
 )
@@ -240,6 +241,123 @@ func StringSliceParameter(name string, v []string) Parameter {
 	}
 }
 
+// BoolParameter get bool query parameter.
+func BoolParameter(name string, v bool) Parameter {
+	return func() Setting {
+		return Setting{
+			Name:   name,
+			Value:  "'" + strconv.FormatBool(v) + "'",
+			Custom: true,
+		}
+	}
+}
+
+// BoolSliceParameter get bool slice query parameter.
+func BoolSliceParameter(name string, v []bool) Parameter {
+	return func() Setting {
+		var b strings.Builder
+		b.WriteString("[")
+		for i, v := range v {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString(strconv.FormatBool(v))
+		}
+		b.WriteString("]")
+		return Setting{
+			Name:   name,
+			Value:  "'" + b.String() + "'",
+			Custom: true,
+		}
+	}
+}
+
+// DateParameter get Date or Date32 query parameter, the date of v in its location.
+func DateParameter(name string, v time.Time) Parameter {
+	return func() Setting {
+		return Setting{
+			Name:   name,
+			Value:  "'" + v.Format(time.DateOnly) + "'",
+			Custom: true,
+		}
+	}
+}
+
+// DateSliceParameter get Date or Date32 slice query parameter.
+func DateSliceParameter(name string, v []time.Time) Parameter {
+	return func() Setting {
+		var b strings.Builder
+		b.WriteString("[")
+		for i, v := range v {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString(`\'` + v.Format(time.DateOnly) + `\'`)
+		}
+		b.WriteString("]")
+		return Setting{
+			Name:   name,
+			Value:  "'" + b.String() + "'",
+			Custom: true,
+		}
+	}
+}
+
+// DateTimeParameter get DateTime or DateTime64 query parameter. v is sent as a Unix
+// timestamp with precision fractional digits (0 for DateTime), so it does not depend
+// on the time zone of the server or of the parameter type.
+func DateTimeParameter(name string, v time.Time, precision int) Parameter {
+	return func() Setting {
+		return Setting{
+			Name:   name,
+			Value:  "'" + string(appendUnixTime(nil, v, precision)) + "'",
+			Custom: true,
+		}
+	}
+}
+
+// DateTimeSliceParameter get DateTime or DateTime64 slice query parameter.
+// See [DateTimeParameter].
+func DateTimeSliceParameter(name string, v []time.Time, precision int) Parameter {
+	return func() Setting {
+		b := []byte("[")
+		for i, v := range v {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, `\'`...)
+			b = appendUnixTime(b, v, precision)
+			b = append(b, `\'`...)
+		}
+		b = append(b, ']')
+		return Setting{
+			Name:   name,
+			Value:  "'" + string(b) + "'",
+			Custom: true,
+		}
+	}
+}
+
+// appendUnixTime appends t as Unix seconds with precision fractional digits.
+func appendUnixTime(b []byte, t time.Time, precision int) []byte {
+	precision = min(max(precision, 0), 9)
+	sec, nsec := t.Unix(), int64(t.Nanosecond())
+	if precision == 0 {
+		return strconv.AppendInt(b, sec, 10)
+	}
+	if sec < 0 && nsec > 0 {
+		// Unix rounds down, but the fraction of a negative time is written after the sign
+		sec, nsec = sec+1, 1e9-nsec
+		if sec == 0 {
+			b = append(b, '-')
+		}
+	}
+	b = strconv.AppendInt(b, sec, 10)
+	frac := strconv.AppendInt(nil, nsec+1e9, 10)
+	b = append(b, '.')
+	return append(b, frac[1:1+precision]...)
+}
+
 // skipSettings reads and discards a settings block from the reader.
 // Settings are serialized as repeated (name, flags, value) tuples,
 // terminated by an empty name string.
@@ -0,0 +1,36 @@
+package chconn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeAndBoolParameters(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2022, 8, 4, 18, 30, 53, 123456789, time.FixedZone("", 3600))
+	beforeEpoch := time.Unix(-2, 250_000_000)
+	tests := []struct {
+		param Parameter
+		want  string
+	}{
+		{BoolParameter("p", true), `'true'`},
+		{BoolSliceParameter("p", []bool{true, false}), `'[true,false]'`},
+		{DateParameter("p", ts), `'2022-08-04'`},
+		{DateSliceParameter("p", []time.Time{ts, ts.AddDate(0, 0, 1)}), `'[\'2022-08-04\',\'2022-08-05\']'`},
+		{DateTimeParameter("p", ts, 0), `'1659634253'`},
+		{DateTimeParameter("p", ts, 3), `'1659634253.123'`},
+		{DateTimeParameter("p", ts, 9), `'1659634253.123456789'`},
+		{DateTimeParameter("p", beforeEpoch, 2), `'-1.75'`},
+		{DateTimeParameter("p", time.Unix(0, -500_000_000), 1), `'-0.5'`},
+		{DateTimeSliceParameter("p", []time.Time{ts, beforeEpoch}, 6), `'[\'1659634253.123456\',\'-1.750000\']'`},
+	}
+	for _, tt := range tests {
+		s := tt.param()
+		assert.Equal(t, "p", s.Name)
+		assert.True(t, s.Custom)
+		assert.Equal(t, tt.want, s.Value)
+	}
+}
@@ -15,6 +15,10 @@ type ServerInfo struct {
 	ServerVersionPatch uint64
 	Timezone           string
 	PasswordPatterns   []ServerInfoPasswordRules
+	// ProtoSendChunked and ProtoRecvChunked are the chunked packet preferences of the server
+	// for the packets it sends and receives (e.g. "notchunked_optional").
+	ProtoSendChunked string
+	ProtoRecvChunked string
 }
 
 // ServerInfoPasswordRules contains a regex pattern and message for server-side password validation.
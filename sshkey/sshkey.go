@@ -0,0 +1,56 @@
+// Package sshkey loads SSH private key files for chconn's SSH key authentication.
+//
+// Importing the package registers Load as the loader of the ssh_key connection string setting:
+//
+//	import _ "github.com/vahid-sohrabloo/chconn/v3/sshkey"
+//
+// It is a separate package so programs that do not use SSH keys do not depend on
+// golang.org/x/crypto/ssh.
+package sshkey
+
+import (
+	"crypto"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/vahid-sohrabloo/chconn/v3"
+)
+
+func init() {
+	chconn.RegisterSSHKeyLoader(Load)
+}
+
+// Load returns the private key in the OpenSSH, PKCS#1, PKCS#8 or SEC1 PEM file at path.
+// passphrase decrypts an encrypted key and is ignored if empty.
+func Load(path, passphrase string) (crypto.Signer, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var key any
+	if passphrase != "" {
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase(pemBytes, []byte(passphrase))
+	} else {
+		key, err = ssh.ParseRawPrivateKey(pemBytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	return signer, nil
+}
+
+// AuthFromFile returns a chconn.SSHKeyAuth with the private key in the file at path, as Load
+// reads it.
+func AuthFromFile(path, passphrase string) (*chconn.SSHKeyAuth, error) {
+	signer, err := Load(path, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &chconn.SSHKeyAuth{Signer: signer}, nil
+}
@@ -0,0 +1,49 @@
+package sshkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/vahid-sohrabloo/chconn/v3"
+)
+
+func TestParseConfigSSHKey(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(key, "", []byte("secret"))
+	require.NoError(t, err)
+	keyPath := filepath.Join(dir, "id_ed25519")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(block), 0o600))
+
+	config, err := chconn.ParseConfig("host=localhost ssh_key=" + keyPath + " ssh_key_passphrase=secret")
+	require.NoError(t, err)
+	require.IsType(t, &chconn.SSHKeyAuth{}, config.Auth)
+	assert.Equal(t, key.Public(), config.Auth.(*chconn.SSHKeyAuth).Signer.Public())
+	assert.Empty(t, config.RuntimeParams)
+
+	_, err = chconn.ParseConfig("host=localhost ssh_key=" + keyPath)
+	require.Error(t, err)
+
+	auth, err := AuthFromFile(keyPath, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, key.Public(), auth.Signer.Public())
+
+	_, err = chconn.ParseConfig("ssh_key=invalid_address")
+	require.EqualError(t, err, "cannot parse `ssh_key=invalid_address`: invalid authentication (open invalid_address: no such file or directory)") //nolint:lll //can't change line length
+
+	notKey := filepath.Join(dir, "not_a_key")
+	require.NoError(t, os.WriteFile(notKey, []byte("invalid"), 0o600))
+	_, err = Load(notKey, "")
+	require.EqualError(t, err, "ssh: no key found")
+}
@@ -0,0 +1,127 @@
+package chconn
+
+import (
+	"context"
+	"time"
+)
+
+// Tracer traces the queries of a connection: Select, Exec and Query and the methods built on them.
+// It is set with Config.Tracer.
+//
+// A Tracer can also implement InsertTracer, ConnectTracer and chpool.AcquireTracer to trace
+// inserts, connects and pool acquires.
+type Tracer interface {
+	// TraceQueryStart is called before a query is sent. The returned context is passed to
+	// TraceQueryEnd.
+	TraceQueryStart(ctx context.Context, conn Conn, data TraceQueryStartData) context.Context
+	// TraceQueryEnd is called when the query is finished, successfully or not.
+	TraceQueryEnd(ctx context.Context, conn Conn, data TraceQueryEndData)
+}
+
+// TraceQueryStartData is the data of Tracer.TraceQueryStart.
+type TraceQueryStartData struct {
+	Query      string
+	QueryID    string
+	Settings   Settings
+	Parameters *Parameters
+}
+
+// TraceQueryEndData is the data of Tracer.TraceQueryEnd.
+type TraceQueryEndData struct {
+	// Rows is the number of rows received.
+	Rows uint64
+	// Stats is nil if the query could not be sent.
+	Stats *QueryStats
+	Err   error
+}
+
+// InsertTracer traces the inserts of a connection: Insert and InsertStream.
+type InsertTracer interface {
+	// TraceInsertStart is called before an insert query is sent. The returned context is passed to
+	// TraceInsertEnd.
+	TraceInsertStart(ctx context.Context, conn Conn, data TraceInsertStartData) context.Context
+	// TraceInsertEnd is called when the insert is finished, successfully or not.
+	TraceInsertEnd(ctx context.Context, conn Conn, data TraceInsertEndData)
+}
+
+// TraceInsertStartData is the data of InsertTracer.TraceInsertStart.
+type TraceInsertStartData struct {
+	Query    string
+	QueryID  string
+	Settings Settings
+}
+
+// TraceInsertEndData is the data of InsertTracer.TraceInsertEnd.
+type TraceInsertEndData struct {
+	// Rows is the number of rows sent.
+	Rows uint64
+	// Stats is nil if the query could not be sent.
+	Stats *QueryStats
+	Err   error
+}
+
+// ConnectTracer traces the establishment of connections.
+type ConnectTracer interface {
+	// TraceConnectStart is called at the start of ConnectConfig. The returned context is passed to
+	// TraceConnectEnd.
+	TraceConnectStart(ctx context.Context, data TraceConnectStartData) context.Context
+	// TraceConnectEnd is called when ConnectConfig returns.
+	TraceConnectEnd(ctx context.Context, data TraceConnectEndData)
+}
+
+// TraceConnectStartData is the data of ConnectTracer.TraceConnectStart.
+type TraceConnectStartData struct {
+	Config *Config
+}
+
+// TraceConnectEndData is the data of ConnectTracer.TraceConnectEnd.
+type TraceConnectEndData struct {
+	Conn Conn
+	Err  error
+}
+
+// startQuery calls the start hook of Config.Tracer for a query and returns the context of its end.
+func (ch *conn) startQuery(ctx context.Context, query string, queryOptions *QueryOptions, insert bool) context.Context {
+	if ch.config.Tracer == nil {
+		return ctx
+	}
+	if !insert {
+		return ch.config.Tracer.TraceQueryStart(ctx, ch, TraceQueryStartData{
+			Query:      query,
+			QueryID:    queryOptions.QueryID,
+			Settings:   queryOptions.Settings,
+			Parameters: queryOptions.Parameters,
+		})
+	}
+	if t, ok := ch.config.Tracer.(InsertTracer); ok {
+		return t.TraceInsertStart(ctx, ch, TraceInsertStartData{
+			Query:    query,
+			QueryID:  queryOptions.QueryID,
+			Settings: queryOptions.Settings,
+		})
+	}
+	return ctx
+}
+
+// endQuery reports a finished query to Config.Tracer and Config.OnQueryDone.
+func (ch *conn) endQuery(ctx context.Context, start time.Time, done *QueryDone) {
+	done.Duration = time.Since(start)
+	if ch.config.Tracer != nil {
+		if !done.Insert {
+			ch.config.Tracer.TraceQueryEnd(ctx, ch, TraceQueryEndData{
+				Rows:  done.Rows,
+				Stats: done.Stats,
+				Err:   done.Err,
+			})
+		} else if t, ok := ch.config.Tracer.(InsertTracer); ok {
+			t.TraceInsertEnd(ctx, ch, TraceInsertEndData{
+				Rows:  done.Rows,
+				Stats: done.Stats,
+				Err:   done.Err,
+			})
+		}
+	}
+	if ch.config.OnQueryDone != nil {
+		ch.config.OnQueryDone(ch, done)
+	}
+}
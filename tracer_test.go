@@ -0,0 +1,103 @@
+package chconn
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+type tracerKey struct{}
+
+type testTracer struct {
+	queryStart  []TraceQueryStartData
+	queryEnd    []TraceQueryEndData
+	insertStart []TraceInsertStartData
+	insertEnd   []TraceInsertEndData
+	connectEnd  []TraceConnectEndData
+}
+
+func (t *testTracer) TraceQueryStart(ctx context.Context, _ Conn, data TraceQueryStartData) context.Context {
+	t.queryStart = append(t.queryStart, data)
+	return context.WithValue(ctx, tracerKey{}, data.Query)
+}
+
+func (t *testTracer) TraceQueryEnd(ctx context.Context, _ Conn, data TraceQueryEndData) {
+	if ctx.Value(tracerKey{}) == nil {
+		panic("context of TraceQueryStart not passed")
+	}
+	t.queryEnd = append(t.queryEnd, data)
+}
+
+func (t *testTracer) TraceInsertStart(ctx context.Context, _ Conn, data TraceInsertStartData) context.Context {
+	t.insertStart = append(t.insertStart, data)
+	return ctx
+}
+
+func (t *testTracer) TraceInsertEnd(_ context.Context, _ Conn, data TraceInsertEndData) {
+	t.insertEnd = append(t.insertEnd, data)
+}
+
+func (t *testTracer) TraceConnectStart(ctx context.Context, _ TraceConnectStartData) context.Context {
+	return ctx
+}
+
+func (t *testTracer) TraceConnectEnd(_ context.Context, data TraceConnectEndData) {
+	t.connectEnd = append(t.connectEnd, data)
+}
+
+func TestTraceConnectError(t *testing.T) {
+	t.Parallel()
+
+	config, err := ParseConfig("host=127.0.0.1 port=1")
+	require.NoError(t, err)
+	tracer := &testTracer{}
+	config.Tracer = tracer
+
+	_, err = ConnectConfig(context.Background(), config)
+	require.Error(t, err)
+	require.Len(t, tracer.connectEnd, 1)
+	assert.Nil(t, tracer.connectEnd[0].Conn)
+	assert.Equal(t, err, tracer.connectEnd[0].Err)
+}
+
+func TestTracer(t *testing.T) {
+	t.Parallel()
+
+	config, err := ParseConfig(os.Getenv("CHX_TEST_TCP_CONN_STRING"))
+	require.NoError(t, err)
+	tracer := &testTracer{}
+	config.Tracer = tracer
+	c, err := ConnectConfig(context.Background(), config)
+	require.NoError(t, err)
+	require.Len(t, tracer.connectEnd, 1)
+	assert.Equal(t, c, tracer.connectEnd[0].Conn)
+
+	col := column.New[uint64]()
+	stmt, err := c.SelectWithOption(context.Background(), "SELECT number FROM system.numbers LIMIT 10",
+		&QueryOptions{QueryID: "trace_select"}, col)
+	require.NoError(t, err)
+	for stmt.Next() {
+	}
+	require.NoError(t, stmt.Err())
+	require.Len(t, tracer.queryEnd, 1)
+	assert.Equal(t, "trace_select", tracer.queryStart[0].QueryID)
+	assert.Equal(t, uint64(10), tracer.queryEnd[0].Rows)
+	assert.NoError(t, tracer.queryEnd[0].Err)
+
+	require.NoError(t, c.Exec(context.Background(), "CREATE TEMPORARY TABLE test_tracer (n UInt64)"))
+	col.Reset()
+	col.AppendMulti(1, 2, 3)
+	require.NoError(t, c.Insert(context.Background(), "INSERT INTO test_tracer VALUES", col))
+	require.Len(t, tracer.insertEnd, 1)
+	assert.Equal(t, "INSERT INTO test_tracer VALUES", tracer.insertStart[0].Query)
+	assert.Equal(t, uint64(3), tracer.insertEnd[0].Rows)
+	assert.NoError(t, tracer.insertEnd[0].Err)
+
+	err = c.Exec(context.Background(), "SELECT * FROM table_does_not_exist")
+	require.Error(t, err)
+	assert.Equal(t, err, tracer.queryEnd[len(tracer.queryEnd)-1].Err)
+}